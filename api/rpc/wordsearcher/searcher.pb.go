@@ -52,8 +52,65 @@ const (
 	SearchRequest_PLAYABILITY_RANGE SearchRequest_Condition = 18
 	SearchRequest_DELETED_WORD      SearchRequest_Condition = 19
 	// Word-level searches (return alphagrams containing matching words)
-	SearchRequest_CONTAINS_HOOKS      SearchRequest_Condition = 20
+	SearchRequest_CONTAINS_HOOKS SearchRequest_Condition = 20
+	// DEFINITION_CONTAINS (stringvalue) is a full-text search of the
+	// definitions if the lexicon database has the index for it: words are
+	// stemmed, and "quoted phrases", prefix* searches, AND, OR and NOT all
+	// work. Otherwise it's a plain substring search. Note that the full-text
+	// search only matches whole words (or their prefixes, with *), so "fish"
+	// finds "catfish" with the substring search but not with the index.
 	SearchRequest_DEFINITION_CONTAINS SearchRequest_Condition = 21
+	// A nested group of conditions; see ConditionGroup.
+	SearchRequest_CONDITION_GROUP SearchRequest_Condition = 22
+	// Letter-content searches. Letters are written the way they appear in
+	// alphagrams, so multi-character tiles work too.
+	// CONTAINS_LETTERS: the alphagram contains at least these letters,
+	// counting repeats (e.g. QEE, or SATIRE).
+	SearchRequest_CONTAINS_LETTERS SearchRequest_Condition = 23
+	// EXCLUDES_LETTERS: the alphagram contains none of these letters.
+	SearchRequest_EXCLUDES_LETTERS     SearchRequest_Condition = 24
+	SearchRequest_NUM_DISTINCT_LETTERS SearchRequest_Condition = 25
+	// MAX_LETTER_REPEATS is the count of the most repeated letter.
+	SearchRequest_MAX_LETTER_REPEATS SearchRequest_Condition = 26
+	// WORD_PATTERN returns the alphagrams of all words that match a
+	// PatternParam.
+	SearchRequest_WORD_PATTERN SearchRequest_Condition = 27
+	// Word-level hook counts (min/max). Like CONTAINS_HOOKS, these return
+	// the alphagrams that have at least one matching word.
+	SearchRequest_NUM_FRONT_HOOKS SearchRequest_Condition = 28
+	SearchRequest_NUM_BACK_HOOKS  SearchRequest_Condition = 29
+	// NUM_HOOKS counts front and back hooks together.
+	SearchRequest_NUM_HOOKS SearchRequest_Condition = 30
+	// HOOKLESS takes no param; it matches words with no hooks at all.
+	SearchRequest_HOOKLESS SearchRequest_Condition = 31
+	// The number of two-letter extensions, i.e. pairs of letters that can
+	// go in front of (or behind) the word to make a new word.
+	SearchRequest_NUM_FRONT_EXTENSIONS SearchRequest_Condition = 32
+	SearchRequest_NUM_BACK_EXTENSIONS  SearchRequest_Condition = 33
+	// CROSS_LEXICON compares against other lexica; see CrossLexiconParam.
+	SearchRequest_CROSS_LEXICON SearchRequest_Condition = 34
+	// LEXICON_SYMBOLS (stringvalue) matches words whose lexicon_symbols
+	// include every symbol in the value, e.g. "#" or "+". An empty value
+	// matches words with no symbols.
+	SearchRequest_LEXICON_SYMBOLS SearchRequest_Condition = 35
+	// ADDED_IN_LEXICON (stringvalue) matches words whose first lexicon in
+	// the family is the given one, e.g. NWL20.
+	SearchRequest_ADDED_IN_LEXICON SearchRequest_Condition = 36
+	// IN_EVERY_LEXICON_SINCE (stringvalue) matches words that are in every
+	// lexicon of the family from the given one up to the one searched.
+	SearchRequest_IN_EVERY_LEXICON_SINCE SearchRequest_Condition = 37
+	// PART_OF_SPEECH (stringvalue) matches words with at least one sense
+	// of that part of speech, e.g. "v" for verbs.
+	SearchRequest_PART_OF_SPEECH SearchRequest_Condition = 38
+	// INFLECTION_OF (stringvalue) matches the inflected forms of a word,
+	// e.g. HEXED, HEXES and HEXING for HEX.
+	SearchRequest_INFLECTION_OF SearchRequest_Condition = 39
+	// CHANGED_SINCE (stringarray) matches alphagrams with a change since a
+	// prior lexicon, as stored by "dbmaker diff -store". The first value is
+	// the prior lexicon; any others pick the kinds of change to match
+	// (added, deleted, anagrams, hooks or definition). Deleted words only
+	// show up through alphagrams that are still in the lexicon.
+	SearchRequest_CHANGED_SINCE SearchRequest_Condition = 40
 )
 
 // Enum value maps for SearchRequest_Condition.
@@ -81,6 +138,25 @@ var (
 		19: "DELETED_WORD",
 		20: "CONTAINS_HOOKS",
 		21: "DEFINITION_CONTAINS",
+		22: "CONDITION_GROUP",
+		23: "CONTAINS_LETTERS",
+		24: "EXCLUDES_LETTERS",
+		25: "NUM_DISTINCT_LETTERS",
+		26: "MAX_LETTER_REPEATS",
+		27: "WORD_PATTERN",
+		28: "NUM_FRONT_HOOKS",
+		29: "NUM_BACK_HOOKS",
+		30: "NUM_HOOKS",
+		31: "HOOKLESS",
+		32: "NUM_FRONT_EXTENSIONS",
+		33: "NUM_BACK_EXTENSIONS",
+		34: "CROSS_LEXICON",
+		35: "LEXICON_SYMBOLS",
+		36: "ADDED_IN_LEXICON",
+		37: "IN_EVERY_LEXICON_SINCE",
+		38: "PART_OF_SPEECH",
+		39: "INFLECTION_OF",
+		40: "CHANGED_SINCE",
 	}
 	SearchRequest_Condition_value = map[string]int32{
 		"LEXICON":                         0,
@@ -105,6 +181,25 @@ var (
 		"DELETED_WORD":                    19,
 		"CONTAINS_HOOKS":                  20,
 		"DEFINITION_CONTAINS":             21,
+		"CONDITION_GROUP":                 22,
+		"CONTAINS_LETTERS":                23,
+		"EXCLUDES_LETTERS":                24,
+		"NUM_DISTINCT_LETTERS":            25,
+		"MAX_LETTER_REPEATS":              26,
+		"WORD_PATTERN":                    27,
+		"NUM_FRONT_HOOKS":                 28,
+		"NUM_BACK_HOOKS":                  29,
+		"NUM_HOOKS":                       30,
+		"HOOKLESS":                        31,
+		"NUM_FRONT_EXTENSIONS":            32,
+		"NUM_BACK_EXTENSIONS":             33,
+		"CROSS_LEXICON":                   34,
+		"LEXICON_SYMBOLS":                 35,
+		"ADDED_IN_LEXICON":                36,
+		"IN_EVERY_LEXICON_SINCE":          37,
+		"PART_OF_SPEECH":                  38,
+		"INFLECTION_OF":                   39,
+		"CHANGED_SINCE":                   40,
 	}
 )
 
@@ -132,7 +227,7 @@ func (x SearchRequest_Condition) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SearchRequest_Condition.Descriptor instead.
 func (SearchRequest_Condition) EnumDescriptor() ([]byte, []int) {
-	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{2, 0}
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{3, 0}
 }
 
 type SearchRequest_NotInLexCondition int32
@@ -178,7 +273,7 @@ func (x SearchRequest_NotInLexCondition) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SearchRequest_NotInLexCondition.Descriptor instead.
 func (SearchRequest_NotInLexCondition) EnumDescriptor() ([]byte, []int) {
-	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{2, 1}
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{3, 1}
 }
 
 type SearchRequest_HookType int32
@@ -227,7 +322,119 @@ func (x SearchRequest_HookType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SearchRequest_HookType.Descriptor instead.
 func (SearchRequest_HookType) EnumDescriptor() ([]byte, []int) {
-	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{2, 2}
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{3, 2}
+}
+
+type SearchRequest_GroupOperator int32
+
+const (
+	SearchRequest_AND SearchRequest_GroupOperator = 0
+	SearchRequest_OR  SearchRequest_GroupOperator = 1
+	// NOT negates the AND of all the params in the group.
+	SearchRequest_NOT SearchRequest_GroupOperator = 2
+)
+
+// Enum value maps for SearchRequest_GroupOperator.
+var (
+	SearchRequest_GroupOperator_name = map[int32]string{
+		0: "AND",
+		1: "OR",
+		2: "NOT",
+	}
+	SearchRequest_GroupOperator_value = map[string]int32{
+		"AND": 0,
+		"OR":  1,
+		"NOT": 2,
+	}
+)
+
+func (x SearchRequest_GroupOperator) Enum() *SearchRequest_GroupOperator {
+	p := new(SearchRequest_GroupOperator)
+	*p = x
+	return p
+}
+
+func (x SearchRequest_GroupOperator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SearchRequest_GroupOperator) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_wordsearcher_searcher_proto_enumTypes[3].Descriptor()
+}
+
+func (SearchRequest_GroupOperator) Type() protoreflect.EnumType {
+	return &file_rpc_wordsearcher_searcher_proto_enumTypes[3]
+}
+
+func (x SearchRequest_GroupOperator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SearchRequest_GroupOperator.Descriptor instead.
+func (SearchRequest_GroupOperator) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{3, 3}
+}
+
+type SearchRequest_SortSpec_Field int32
+
+const (
+	SearchRequest_SortSpec_PROBABILITY  SearchRequest_SortSpec_Field = 0
+	SearchRequest_SortSpec_DIFFICULTY   SearchRequest_SortSpec_Field = 1
+	SearchRequest_SortSpec_PLAYABILITY  SearchRequest_SortSpec_Field = 2
+	SearchRequest_SortSpec_COMBINATIONS SearchRequest_SortSpec_Field = 3
+	SearchRequest_SortSpec_ALPHAGRAM    SearchRequest_SortSpec_Field = 4
+	SearchRequest_SortSpec_POINT_VALUE  SearchRequest_SortSpec_Field = 5
+	// A shuffled order that is always the same for the same seed.
+	SearchRequest_SortSpec_RANDOM SearchRequest_SortSpec_Field = 6
+)
+
+// Enum value maps for SearchRequest_SortSpec_Field.
+var (
+	SearchRequest_SortSpec_Field_name = map[int32]string{
+		0: "PROBABILITY",
+		1: "DIFFICULTY",
+		2: "PLAYABILITY",
+		3: "COMBINATIONS",
+		4: "ALPHAGRAM",
+		5: "POINT_VALUE",
+		6: "RANDOM",
+	}
+	SearchRequest_SortSpec_Field_value = map[string]int32{
+		"PROBABILITY":  0,
+		"DIFFICULTY":   1,
+		"PLAYABILITY":  2,
+		"COMBINATIONS": 3,
+		"ALPHAGRAM":    4,
+		"POINT_VALUE":  5,
+		"RANDOM":       6,
+	}
+)
+
+func (x SearchRequest_SortSpec_Field) Enum() *SearchRequest_SortSpec_Field {
+	p := new(SearchRequest_SortSpec_Field)
+	*p = x
+	return p
+}
+
+func (x SearchRequest_SortSpec_Field) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SearchRequest_SortSpec_Field) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_wordsearcher_searcher_proto_enumTypes[4].Descriptor()
+}
+
+func (SearchRequest_SortSpec_Field) Type() protoreflect.EnumType {
+	return &file_rpc_wordsearcher_searcher_proto_enumTypes[4]
+}
+
+func (x SearchRequest_SortSpec_Field) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SearchRequest_SortSpec_Field.Descriptor instead.
+func (SearchRequest_SortSpec_Field) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{3, 8, 0}
 }
 
 type AnagramRequest_Mode int32
@@ -263,11 +470,11 @@ func (x AnagramRequest_Mode) String() string {
 }
 
 func (AnagramRequest_Mode) Descriptor() protoreflect.EnumDescriptor {
-	return file_rpc_wordsearcher_searcher_proto_enumTypes[3].Descriptor()
+	return file_rpc_wordsearcher_searcher_proto_enumTypes[5].Descriptor()
 }
 
 func (AnagramRequest_Mode) Type() protoreflect.EnumType {
-	return &file_rpc_wordsearcher_searcher_proto_enumTypes[3]
+	return &file_rpc_wordsearcher_searcher_proto_enumTypes[5]
 }
 
 func (x AnagramRequest_Mode) Number() protoreflect.EnumNumber {
@@ -276,7 +483,7 @@ func (x AnagramRequest_Mode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use AnagramRequest_Mode.Descriptor instead.
 func (AnagramRequest_Mode) EnumDescriptor() ([]byte, []int) {
-	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{4, 0}
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{10, 0}
 }
 
 // An Alphagram encapsulates info about an alphagram, including the words,
@@ -297,6 +504,9 @@ type Alphagram struct {
 	Probability  int32 `protobuf:"varint,5,opt,name=probability,proto3" json:"probability,omitempty"`
 	Combinations int64 `protobuf:"varint,6,opt,name=combinations,proto3" json:"combinations,omitempty"`
 	Difficulty   int32 `protobuf:"varint,7,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	// playability is a score for how often this alphagram comes up in real
+	// games. Higher is more playable; 0 means we have no data for it.
+	Playability int32 `protobuf:"varint,8,opt,name=playability,proto3" json:"playability,omitempty"`
 }
 
 func (x *Alphagram) Reset() {
@@ -380,6 +590,13 @@ func (x *Alphagram) GetDifficulty() int32 {
 	return 0
 }
 
+func (x *Alphagram) GetPlayability() int32 {
+	if x != nil {
+		return x.Playability
+	}
+	return 0
+}
+
 // A Word is more than just the string representing the word. It has other
 // info like the definition, hooks, lex symbols, etc.
 type Word struct {
@@ -398,6 +615,16 @@ type Word struct {
 	LexiconSymbols string `protobuf:"bytes,6,opt,name=lexicon_symbols,json=lexiconSymbols,proto3" json:"lexicon_symbols,omitempty"`
 	InnerFrontHook bool   `protobuf:"varint,7,opt,name=inner_front_hook,json=innerFrontHook,proto3" json:"inner_front_hook,omitempty"`
 	InnerBackHook  bool   `protobuf:"varint,8,opt,name=inner_back_hook,json=innerBackHook,proto3" json:"inner_back_hook,omitempty"`
+	// The oldest and newest lexica in this lexicon's family that have the
+	// word. Empty for databases made before word history was stored.
+	FirstLexicon string `protobuf:"bytes,9,opt,name=first_lexicon,json=firstLexicon,proto3" json:"first_lexicon,omitempty"`
+	LastLexicon  string `protobuf:"bytes,10,opt,name=last_lexicon,json=lastLexicon,proto3" json:"last_lexicon,omitempty"`
+	// The senses of the definition, broken down. Only filled in alongside
+	// the definition, and only for databases that store them.
+	Definitions []*Definition `protobuf:"bytes,11,rep,name=definitions,proto3" json:"definitions,omitempty"`
+	// The part of the definition that matched a full-text definition search,
+	// with the matching terms highlighted. Only set by WordSearch.
+	DefinitionSnippet string `protobuf:"bytes,12,opt,name=definition_snippet,json=definitionSnippet,proto3" json:"definition_snippet,omitempty"`
 }
 
 func (x *Word) Reset() {
@@ -488,19 +715,53 @@ func (x *Word) GetInnerBackHook() bool {
 	return false
 }
 
-// A SearchRequest encapsulates a number of varied conditions and lets one
-// search for questions.
-type SearchRequest struct {
+func (x *Word) GetFirstLexicon() string {
+	if x != nil {
+		return x.FirstLexicon
+	}
+	return ""
+}
+
+func (x *Word) GetLastLexicon() string {
+	if x != nil {
+		return x.LastLexicon
+	}
+	return ""
+}
+
+func (x *Word) GetDefinitions() []*Definition {
+	if x != nil {
+		return x.Definitions
+	}
+	return nil
+}
+
+func (x *Word) GetDefinitionSnippet() string {
+	if x != nil {
+		return x.DefinitionSnippet
+	}
+	return ""
+}
+
+// Definition is one sense of a word's definition.
+type Definition struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Searchparams []*SearchRequest_SearchParam `protobuf:"bytes,1,rep,name=searchparams,proto3" json:"searchparams,omitempty"`
-	Expand       bool                         `protobuf:"varint,2,opt,name=expand,proto3" json:"expand,omitempty"`
+	// e.g. n, v, adj, adv, interj
+	PartOfSpeech string `protobuf:"bytes,1,opt,name=part_of_speech,json=partOfSpeech,proto3" json:"part_of_speech,omitempty"`
+	// The sense with links expanded, without the part of speech and
+	// inflections.
+	Gloss string `protobuf:"bytes,2,opt,name=gloss,proto3" json:"gloss,omitempty"`
+	// The inflected forms listed with the sense, e.g. HEXED HEXES HEXING.
+	Inflections []string `protobuf:"bytes,3,rep,name=inflections,proto3" json:"inflections,omitempty"`
+	// The word this one is an inflection of, if any; e.g. HEX for HEXED.
+	RootWord string `protobuf:"bytes,4,opt,name=root_word,json=rootWord,proto3" json:"root_word,omitempty"`
 }
 
-func (x *SearchRequest) Reset() {
-	*x = SearchRequest{}
+func (x *Definition) Reset() {
+	*x = Definition{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -508,13 +769,13 @@ func (x *SearchRequest) Reset() {
 	}
 }
 
-func (x *SearchRequest) String() string {
+func (x *Definition) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchRequest) ProtoMessage() {}
+func (*Definition) ProtoMessage() {}
 
-func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+func (x *Definition) ProtoReflect() protoreflect.Message {
 	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -526,36 +787,65 @@ func (x *SearchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
-func (*SearchRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Definition.ProtoReflect.Descriptor instead.
+func (*Definition) Descriptor() ([]byte, []int) {
 	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *SearchRequest) GetSearchparams() []*SearchRequest_SearchParam {
+func (x *Definition) GetPartOfSpeech() string {
 	if x != nil {
-		return x.Searchparams
+		return x.PartOfSpeech
+	}
+	return ""
+}
+
+func (x *Definition) GetGloss() string {
+	if x != nil {
+		return x.Gloss
+	}
+	return ""
+}
+
+func (x *Definition) GetInflections() []string {
+	if x != nil {
+		return x.Inflections
 	}
 	return nil
 }
 
-func (x *SearchRequest) GetExpand() bool {
+func (x *Definition) GetRootWord() string {
 	if x != nil {
-		return x.Expand
+		return x.RootWord
 	}
-	return false
+	return ""
 }
 
-type SearchResponse struct {
+// A SearchRequest encapsulates a number of varied conditions and lets one
+// search for questions.
+type SearchRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Alphagrams []*Alphagram `protobuf:"bytes,1,rep,name=alphagrams,proto3" json:"alphagrams,omitempty"`
-	Lexicon    string       `protobuf:"bytes,2,opt,name=lexicon,proto3" json:"lexicon,omitempty"`
+	Searchparams []*SearchRequest_SearchParam `protobuf:"bytes,1,rep,name=searchparams,proto3" json:"searchparams,omitempty"`
+	Expand       bool                         `protobuf:"varint,2,opt,name=expand,proto3" json:"expand,omitempty"`
+	// If page_size is set, at most page_size alphagrams are returned, and
+	// SearchResponse.next_page_token can be passed back in page_token to get
+	// the next page. Tokens are opaque and are only valid for the same
+	// searchparams. If the lexicon is updated between pages, the token is
+	// rejected with FAILED_PRECONDITION and the search has to start again.
+	// If page_size is not set, all results are returned at once.
+	PageSize  int32  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// sort picks the order of the results. If it is not set, results are
+	// sorted by probability. PROBABILITY_LIMIT and pagination count positions
+	// in this order, so for example DIFFICULTY descending with a
+	// PROBABILITY_LIMIT of 1-200 returns the 200 hardest alphagrams.
+	Sort *SearchRequest_SortSpec `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`
 }
 
-func (x *SearchResponse) Reset() {
-	*x = SearchResponse{}
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -563,13 +853,13 @@ func (x *SearchResponse) Reset() {
 	}
 }
 
-func (x *SearchResponse) String() string {
+func (x *SearchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchResponse) ProtoMessage() {}
+func (*SearchRequest) ProtoMessage() {}
 
-func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -581,38 +871,64 @@ func (x *SearchResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
-func (*SearchResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
 	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *SearchResponse) GetAlphagrams() []*Alphagram {
+func (x *SearchRequest) GetSearchparams() []*SearchRequest_SearchParam {
 	if x != nil {
-		return x.Alphagrams
+		return x.Searchparams
 	}
 	return nil
 }
 
-func (x *SearchResponse) GetLexicon() string {
+func (x *SearchRequest) GetExpand() bool {
 	if x != nil {
-		return x.Lexicon
+		return x.Expand
+	}
+	return false
+}
+
+func (x *SearchRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
 	}
 	return ""
 }
 
-type AnagramRequest struct {
+func (x *SearchRequest) GetSort() *SearchRequest_SortSpec {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+type SearchResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Lexicon string              `protobuf:"bytes,1,opt,name=lexicon,proto3" json:"lexicon,omitempty"`
-	Letters string              `protobuf:"bytes,2,opt,name=letters,proto3" json:"letters,omitempty"`
-	Mode    AnagramRequest_Mode `protobuf:"varint,3,opt,name=mode,proto3,enum=wordsearcher.AnagramRequest_Mode" json:"mode,omitempty"`
-	Expand  bool                `protobuf:"varint,4,opt,name=expand,proto3" json:"expand,omitempty"` // Whether to provide hooks, defos, ec.
+	Alphagrams []*Alphagram `protobuf:"bytes,1,rep,name=alphagrams,proto3" json:"alphagrams,omitempty"`
+	Lexicon    string       `protobuf:"bytes,2,opt,name=lexicon,proto3" json:"lexicon,omitempty"`
+	// next_page_token is empty when there are no more results.
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// page_size and page_token are only used when this message is the request
+	// to Expand. They page through the alphagrams in the request the same way
+	// SearchRequest.page_size and page_token do.
+	PageSize  int32  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 }
 
-func (x *AnagramRequest) Reset() {
-	*x = AnagramRequest{}
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -620,13 +936,13 @@ func (x *AnagramRequest) Reset() {
 	}
 }
 
-func (x *AnagramRequest) String() string {
+func (x *SearchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AnagramRequest) ProtoMessage() {}
+func (*SearchResponse) ProtoMessage() {}
 
-func (x *AnagramRequest) ProtoReflect() protoreflect.Message {
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -638,50 +954,67 @@ func (x *AnagramRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AnagramRequest.ProtoReflect.Descriptor instead.
-func (*AnagramRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
 	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *AnagramRequest) GetLexicon() string {
+func (x *SearchResponse) GetAlphagrams() []*Alphagram {
+	if x != nil {
+		return x.Alphagrams
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetLexicon() string {
 	if x != nil {
 		return x.Lexicon
 	}
 	return ""
 }
 
-func (x *AnagramRequest) GetLetters() string {
+func (x *SearchResponse) GetNextPageToken() string {
 	if x != nil {
-		return x.Letters
+		return x.NextPageToken
 	}
 	return ""
 }
 
-func (x *AnagramRequest) GetMode() AnagramRequest_Mode {
+func (x *SearchResponse) GetPageSize() int32 {
 	if x != nil {
-		return x.Mode
+		return x.PageSize
 	}
-	return AnagramRequest_EXACT
+	return 0
 }
 
-func (x *AnagramRequest) GetExpand() bool {
+func (x *SearchResponse) GetPageToken() string {
 	if x != nil {
-		return x.Expand
+		return x.PageToken
 	}
-	return false
+	return ""
 }
 
-type AnagramResponse struct {
+type SearchSummaryRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Words    []*Word `protobuf:"bytes,1,rep,name=words,proto3" json:"words,omitempty"`
-	NumWords int32   `protobuf:"varint,2,opt,name=num_words,json=numWords,proto3" json:"num_words,omitempty"`
-}
-
-func (x *AnagramResponse) Reset() {
-	*x = AnagramResponse{}
+	// The search to summarize. Its expand, sort and paging fields are
+	// ignored.
+	Search *SearchRequest `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
+	// Which facets to count, out of length, probability, difficulty,
+	// num_anagrams and lexicon_symbol. Empty means just the totals.
+	Facets []string `protobuf:"bytes,2,rep,name=facets,proto3" json:"facets,omitempty"`
+	// How many probabilities go in each probability bucket; 1000 if not set.
+	ProbabilityBucketSize int32 `protobuf:"varint,3,opt,name=probability_bucket_size,json=probabilityBucketSize,proto3" json:"probability_bucket_size,omitempty"`
+	// How many difficulty ratings (1 to 100) go in each difficulty band; 10
+	// if not set. Alphagrams with no difficulty rating are in a band of
+	// their own.
+	DifficultyBandSize int32 `protobuf:"varint,4,opt,name=difficulty_band_size,json=difficultyBandSize,proto3" json:"difficulty_band_size,omitempty"`
+}
+
+func (x *SearchSummaryRequest) Reset() {
+	*x = SearchSummaryRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -689,13 +1022,13 @@ func (x *AnagramResponse) Reset() {
 	}
 }
 
-func (x *AnagramResponse) String() string {
+func (x *SearchSummaryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AnagramResponse) ProtoMessage() {}
+func (*SearchSummaryRequest) ProtoMessage() {}
 
-func (x *AnagramResponse) ProtoReflect() protoreflect.Message {
+func (x *SearchSummaryRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -707,39 +1040,57 @@ func (x *AnagramResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AnagramResponse.ProtoReflect.Descriptor instead.
-func (*AnagramResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchSummaryRequest.ProtoReflect.Descriptor instead.
+func (*SearchSummaryRequest) Descriptor() ([]byte, []int) {
 	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *AnagramResponse) GetWords() []*Word {
+func (x *SearchSummaryRequest) GetSearch() *SearchRequest {
 	if x != nil {
-		return x.Words
+		return x.Search
 	}
 	return nil
 }
 
-func (x *AnagramResponse) GetNumWords() int32 {
+func (x *SearchSummaryRequest) GetFacets() []string {
 	if x != nil {
-		return x.NumWords
+		return x.Facets
+	}
+	return nil
+}
+
+func (x *SearchSummaryRequest) GetProbabilityBucketSize() int32 {
+	if x != nil {
+		return x.ProbabilityBucketSize
 	}
 	return 0
 }
 
-type BlankChallengeCreateRequest struct {
+func (x *SearchSummaryRequest) GetDifficultyBandSize() int32 {
+	if x != nil {
+		return x.DifficultyBandSize
+	}
+	return 0
+}
+
+// A FacetBucket counts the matches with one value of a facet, or with a
+// value in the range min to max.
+type FacetBucket struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Lexicon         string `protobuf:"bytes,1,opt,name=lexicon,proto3" json:"lexicon,omitempty"`
-	NumQuestions    int32  `protobuf:"varint,2,opt,name=num_questions,json=numQuestions,proto3" json:"num_questions,omitempty"`           // The number of questions to generate.
-	MaxSolutions    int32  `protobuf:"varint,3,opt,name=max_solutions,json=maxSolutions,proto3" json:"max_solutions,omitempty"`           // The max number of solutions per question.
-	NumWith_2Blanks int32  `protobuf:"varint,4,opt,name=num_with_2_blanks,json=numWith2Blanks,proto3" json:"num_with_2_blanks,omitempty"` // The number of questions with two blanks.
-	WordLength      int32  `protobuf:"varint,5,opt,name=word_length,json=wordLength,proto3" json:"word_length,omitempty"`
+	// e.g. "7" for length, "1001-2000" for probability, "#" for lexicon
+	// symbol.
+	Value         string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Min           int64  `protobuf:"varint,2,opt,name=min,proto3" json:"min,omitempty"`
+	Max           int64  `protobuf:"varint,3,opt,name=max,proto3" json:"max,omitempty"`
+	NumAlphagrams int32  `protobuf:"varint,4,opt,name=num_alphagrams,json=numAlphagrams,proto3" json:"num_alphagrams,omitempty"`
+	NumWords      int32  `protobuf:"varint,5,opt,name=num_words,json=numWords,proto3" json:"num_words,omitempty"`
 }
 
-func (x *BlankChallengeCreateRequest) Reset() {
-	*x = BlankChallengeCreateRequest{}
+func (x *FacetBucket) Reset() {
+	*x = FacetBucket{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -747,13 +1098,13 @@ func (x *BlankChallengeCreateRequest) Reset() {
 	}
 }
 
-func (x *BlankChallengeCreateRequest) String() string {
+func (x *FacetBucket) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BlankChallengeCreateRequest) ProtoMessage() {}
+func (*FacetBucket) ProtoMessage() {}
 
-func (x *BlankChallengeCreateRequest) ProtoReflect() protoreflect.Message {
+func (x *FacetBucket) ProtoReflect() protoreflect.Message {
 	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -765,61 +1116,57 @@ func (x *BlankChallengeCreateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BlankChallengeCreateRequest.ProtoReflect.Descriptor instead.
-func (*BlankChallengeCreateRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use FacetBucket.ProtoReflect.Descriptor instead.
+func (*FacetBucket) Descriptor() ([]byte, []int) {
 	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *BlankChallengeCreateRequest) GetLexicon() string {
+func (x *FacetBucket) GetValue() string {
 	if x != nil {
-		return x.Lexicon
+		return x.Value
 	}
 	return ""
 }
 
-func (x *BlankChallengeCreateRequest) GetNumQuestions() int32 {
+func (x *FacetBucket) GetMin() int64 {
 	if x != nil {
-		return x.NumQuestions
+		return x.Min
 	}
 	return 0
 }
 
-func (x *BlankChallengeCreateRequest) GetMaxSolutions() int32 {
+func (x *FacetBucket) GetMax() int64 {
 	if x != nil {
-		return x.MaxSolutions
+		return x.Max
 	}
 	return 0
 }
 
-func (x *BlankChallengeCreateRequest) GetNumWith_2Blanks() int32 {
+func (x *FacetBucket) GetNumAlphagrams() int32 {
 	if x != nil {
-		return x.NumWith_2Blanks
+		return x.NumAlphagrams
 	}
 	return 0
 }
 
-func (x *BlankChallengeCreateRequest) GetWordLength() int32 {
+func (x *FacetBucket) GetNumWords() int32 {
 	if x != nil {
-		return x.WordLength
+		return x.NumWords
 	}
 	return 0
 }
 
-type BuildChallengeCreateRequest struct {
+type Facet struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Lexicon               string `protobuf:"bytes,1,opt,name=lexicon,proto3" json:"lexicon,omitempty"`
-	MinSolutions          int32  `protobuf:"varint,2,opt,name=min_solutions,json=minSolutions,proto3" json:"min_solutions,omitempty"`
-	MaxSolutions          int32  `protobuf:"varint,3,opt,name=max_solutions,json=maxSolutions,proto3" json:"max_solutions,omitempty"`
-	MinLength             int32  `protobuf:"varint,4,opt,name=min_length,json=minLength,proto3" json:"min_length,omitempty"`
-	MaxLength             int32  `protobuf:"varint,5,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"`
-	RequireLengthSolution bool   `protobuf:"varint,6,opt,name=require_length_solution,json=requireLengthSolution,proto3" json:"require_length_solution,omitempty"` // Whether a solution for the given word length is required
+	Name    string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Buckets []*FacetBucket `protobuf:"bytes,2,rep,name=buckets,proto3" json:"buckets,omitempty"`
 }
 
-func (x *BuildChallengeCreateRequest) Reset() {
-	*x = BuildChallengeCreateRequest{}
+func (x *Facet) Reset() {
+	*x = Facet{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -827,13 +1174,13 @@ func (x *BuildChallengeCreateRequest) Reset() {
 	}
 }
 
-func (x *BuildChallengeCreateRequest) String() string {
+func (x *Facet) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BuildChallengeCreateRequest) ProtoMessage() {}
+func (*Facet) ProtoMessage() {}
 
-func (x *BuildChallengeCreateRequest) ProtoReflect() protoreflect.Message {
+func (x *Facet) ProtoReflect() protoreflect.Message {
 	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -845,68 +1192,39 @@ func (x *BuildChallengeCreateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BuildChallengeCreateRequest.ProtoReflect.Descriptor instead.
-func (*BuildChallengeCreateRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Facet.ProtoReflect.Descriptor instead.
+func (*Facet) Descriptor() ([]byte, []int) {
 	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *BuildChallengeCreateRequest) GetLexicon() string {
+func (x *Facet) GetName() string {
 	if x != nil {
-		return x.Lexicon
+		return x.Name
 	}
 	return ""
 }
 
-func (x *BuildChallengeCreateRequest) GetMinSolutions() int32 {
-	if x != nil {
-		return x.MinSolutions
-	}
-	return 0
-}
-
-func (x *BuildChallengeCreateRequest) GetMaxSolutions() int32 {
-	if x != nil {
-		return x.MaxSolutions
-	}
-	return 0
-}
-
-func (x *BuildChallengeCreateRequest) GetMinLength() int32 {
-	if x != nil {
-		return x.MinLength
-	}
-	return 0
-}
-
-func (x *BuildChallengeCreateRequest) GetMaxLength() int32 {
-	if x != nil {
-		return x.MaxLength
-	}
-	return 0
-}
-
-func (x *BuildChallengeCreateRequest) GetRequireLengthSolution() bool {
+func (x *Facet) GetBuckets() []*FacetBucket {
 	if x != nil {
-		return x.RequireLengthSolution
+		return x.Buckets
 	}
-	return false
+	return nil
 }
 
-type WordSearchRequest struct {
+type SearchSummaryResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Lexicon string `protobuf:"bytes,1,opt,name=lexicon,proto3" json:"lexicon,omitempty"`
-	// the only acceptable glob characters are * and ?. These get mapped
-	// to SQLITE % and _, respectively.
-	Glob string `protobuf:"bytes,2,opt,name=glob,proto3" json:"glob,omitempty"`
-	// Which field the glob applies to (word or definition?)
-	AppliesTo string `protobuf:"bytes,3,opt,name=applies_to,json=appliesTo,proto3" json:"applies_to,omitempty"`
+	Lexicon       string `protobuf:"bytes,1,opt,name=lexicon,proto3" json:"lexicon,omitempty"`
+	NumAlphagrams int32  `protobuf:"varint,2,opt,name=num_alphagrams,json=numAlphagrams,proto3" json:"num_alphagrams,omitempty"`
+	NumWords      int32  `protobuf:"varint,3,opt,name=num_words,json=numWords,proto3" json:"num_words,omitempty"`
+	// In the order requested.
+	Facets []*Facet `protobuf:"bytes,4,rep,name=facets,proto3" json:"facets,omitempty"`
 }
 
-func (x *WordSearchRequest) Reset() {
-	*x = WordSearchRequest{}
+func (x *SearchSummaryResponse) Reset() {
+	*x = SearchSummaryResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -914,13 +1232,13 @@ func (x *WordSearchRequest) Reset() {
 	}
 }
 
-func (x *WordSearchRequest) String() string {
+func (x *SearchSummaryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WordSearchRequest) ProtoMessage() {}
+func (*SearchSummaryResponse) ProtoMessage() {}
 
-func (x *WordSearchRequest) ProtoReflect() protoreflect.Message {
+func (x *SearchSummaryResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -932,43 +1250,62 @@ func (x *WordSearchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WordSearchRequest.ProtoReflect.Descriptor instead.
-func (*WordSearchRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchSummaryResponse.ProtoReflect.Descriptor instead.
+func (*SearchSummaryResponse) Descriptor() ([]byte, []int) {
 	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *WordSearchRequest) GetLexicon() string {
+func (x *SearchSummaryResponse) GetLexicon() string {
 	if x != nil {
 		return x.Lexicon
 	}
 	return ""
 }
 
-func (x *WordSearchRequest) GetGlob() string {
+func (x *SearchSummaryResponse) GetNumAlphagrams() int32 {
 	if x != nil {
-		return x.Glob
+		return x.NumAlphagrams
 	}
-	return ""
+	return 0
 }
 
-func (x *WordSearchRequest) GetAppliesTo() string {
+func (x *SearchSummaryResponse) GetNumWords() int32 {
 	if x != nil {
-		return x.AppliesTo
+		return x.NumWords
 	}
-	return ""
+	return 0
 }
 
-type DefineRequest struct {
+func (x *SearchSummaryResponse) GetFacets() []*Facet {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+type SearchByQueryRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Lexicon string `protobuf:"bytes,1,opt,name=lexicon,proto3" json:"lexicon,omitempty"`
-	Word    string `protobuf:"bytes,2,opt,name=word,proto3" json:"word,omitempty"`
-}
-
-func (x *DefineRequest) Reset() {
-	*x = DefineRequest{}
+	// A search written as text, e.g.
+	//   lex:CSW24 len:7-8 prob:1-5000 hooks:back=S -contains:U def:"fish"
+	//   order:difficulty desc
+	// Terms are field:value conditions, all of which must match. A - in
+	// front negates a term, OR between terms matches either, and terms can
+	// be grouped with parentheses. Values with spaces go in double quotes.
+	// See ParseSearchQuery in the searchserver package for the fields.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// The lexicon to search if the query has no lex: term.
+	DefaultLexicon string `protobuf:"bytes,2,opt,name=default_lexicon,json=defaultLexicon,proto3" json:"default_lexicon,omitempty"`
+	// These are as in SearchRequest.
+	Expand    bool   `protobuf:"varint,3,opt,name=expand,proto3" json:"expand,omitempty"`
+	PageSize  int32  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *SearchByQueryRequest) Reset() {
+	*x = SearchByQueryRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -976,13 +1313,13 @@ func (x *DefineRequest) Reset() {
 	}
 }
 
-func (x *DefineRequest) String() string {
+func (x *SearchByQueryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DefineRequest) ProtoMessage() {}
+func (*SearchByQueryRequest) ProtoMessage() {}
 
-func (x *DefineRequest) ProtoReflect() protoreflect.Message {
+func (x *SearchByQueryRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -994,35 +1331,59 @@ func (x *DefineRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DefineRequest.ProtoReflect.Descriptor instead.
-func (*DefineRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchByQueryRequest.ProtoReflect.Descriptor instead.
+func (*SearchByQueryRequest) Descriptor() ([]byte, []int) {
 	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *DefineRequest) GetLexicon() string {
+func (x *SearchByQueryRequest) GetQuery() string {
 	if x != nil {
-		return x.Lexicon
+		return x.Query
 	}
 	return ""
 }
 
-func (x *DefineRequest) GetWord() string {
+func (x *SearchByQueryRequest) GetDefaultLexicon() string {
 	if x != nil {
-		return x.Word
+		return x.DefaultLexicon
 	}
 	return ""
 }
 
-type WordSearchResponse struct {
+func (x *SearchByQueryRequest) GetExpand() bool {
+	if x != nil {
+		return x.Expand
+	}
+	return false
+}
+
+func (x *SearchByQueryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchByQueryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type AnagramRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Words []*Word `protobuf:"bytes,1,rep,name=words,proto3" json:"words,omitempty"`
+	Lexicon string              `protobuf:"bytes,1,opt,name=lexicon,proto3" json:"lexicon,omitempty"`
+	Letters string              `protobuf:"bytes,2,opt,name=letters,proto3" json:"letters,omitempty"`
+	Mode    AnagramRequest_Mode `protobuf:"varint,3,opt,name=mode,proto3,enum=wordsearcher.AnagramRequest_Mode" json:"mode,omitempty"`
+	Expand  bool                `protobuf:"varint,4,opt,name=expand,proto3" json:"expand,omitempty"` // Whether to provide hooks, defos, ec.
 }
 
-func (x *WordSearchResponse) Reset() {
-	*x = WordSearchResponse{}
+func (x *AnagramRequest) Reset() {
+	*x = AnagramRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1030,13 +1391,13 @@ func (x *WordSearchResponse) Reset() {
 	}
 }
 
-func (x *WordSearchResponse) String() string {
+func (x *AnagramRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WordSearchResponse) ProtoMessage() {}
+func (*AnagramRequest) ProtoMessage() {}
 
-func (x *WordSearchResponse) ProtoReflect() protoreflect.Message {
+func (x *AnagramRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1048,31 +1409,50 @@ func (x *WordSearchResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WordSearchResponse.ProtoReflect.Descriptor instead.
-func (*WordSearchResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AnagramRequest.ProtoReflect.Descriptor instead.
+func (*AnagramRequest) Descriptor() ([]byte, []int) {
 	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *WordSearchResponse) GetWords() []*Word {
+func (x *AnagramRequest) GetLexicon() string {
 	if x != nil {
-		return x.Words
+		return x.Lexicon
 	}
-	return nil
+	return ""
 }
 
-type SearchRequest_MinMax struct {
+func (x *AnagramRequest) GetLetters() string {
+	if x != nil {
+		return x.Letters
+	}
+	return ""
+}
+
+func (x *AnagramRequest) GetMode() AnagramRequest_Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return AnagramRequest_EXACT
+}
+
+func (x *AnagramRequest) GetExpand() bool {
+	if x != nil {
+		return x.Expand
+	}
+	return false
+}
+
+type AnagramResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Used for length, prob range, prob limit, num anagrams,
-	// num_vowels, point value
-	Min int32 `protobuf:"varint,1,opt,name=min,proto3" json:"min,omitempty"`
-	Max int32 `protobuf:"varint,2,opt,name=max,proto3" json:"max,omitempty"`
+	Words    []*Word `protobuf:"bytes,1,rep,name=words,proto3" json:"words,omitempty"`
+	NumWords int32   `protobuf:"varint,2,opt,name=num_words,json=numWords,proto3" json:"num_words,omitempty"`
 }
 
-func (x *SearchRequest_MinMax) Reset() {
-	*x = SearchRequest_MinMax{}
+func (x *AnagramResponse) Reset() {
+	*x = AnagramResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1080,13 +1460,13 @@ func (x *SearchRequest_MinMax) Reset() {
 	}
 }
 
-func (x *SearchRequest_MinMax) String() string {
+func (x *AnagramResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchRequest_MinMax) ProtoMessage() {}
+func (*AnagramResponse) ProtoMessage() {}
 
-func (x *SearchRequest_MinMax) ProtoReflect() protoreflect.Message {
+func (x *AnagramResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1098,36 +1478,39 @@ func (x *SearchRequest_MinMax) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchRequest_MinMax.ProtoReflect.Descriptor instead.
-func (*SearchRequest_MinMax) Descriptor() ([]byte, []int) {
-	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{2, 0}
+// Deprecated: Use AnagramResponse.ProtoReflect.Descriptor instead.
+func (*AnagramResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *SearchRequest_MinMax) GetMin() int32 {
+func (x *AnagramResponse) GetWords() []*Word {
 	if x != nil {
-		return x.Min
+		return x.Words
 	}
-	return 0
+	return nil
 }
 
-func (x *SearchRequest_MinMax) GetMax() int32 {
+func (x *AnagramResponse) GetNumWords() int32 {
 	if x != nil {
-		return x.Max
+		return x.NumWords
 	}
 	return 0
 }
 
-type SearchRequest_StringValue struct {
+type BlankChallengeCreateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Used for lexicon, matching anagram, not_in_lexicon
-	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Lexicon         string `protobuf:"bytes,1,opt,name=lexicon,proto3" json:"lexicon,omitempty"`
+	NumQuestions    int32  `protobuf:"varint,2,opt,name=num_questions,json=numQuestions,proto3" json:"num_questions,omitempty"`           // The number of questions to generate.
+	MaxSolutions    int32  `protobuf:"varint,3,opt,name=max_solutions,json=maxSolutions,proto3" json:"max_solutions,omitempty"`           // The max number of solutions per question.
+	NumWith_2Blanks int32  `protobuf:"varint,4,opt,name=num_with_2_blanks,json=numWith2Blanks,proto3" json:"num_with_2_blanks,omitempty"` // The number of questions with two blanks.
+	WordLength      int32  `protobuf:"varint,5,opt,name=word_length,json=wordLength,proto3" json:"word_length,omitempty"`
 }
 
-func (x *SearchRequest_StringValue) Reset() {
-	*x = SearchRequest_StringValue{}
+func (x *BlankChallengeCreateRequest) Reset() {
+	*x = BlankChallengeCreateRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1135,13 +1518,13 @@ func (x *SearchRequest_StringValue) Reset() {
 	}
 }
 
-func (x *SearchRequest_StringValue) String() string {
+func (x *BlankChallengeCreateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchRequest_StringValue) ProtoMessage() {}
+func (*BlankChallengeCreateRequest) ProtoMessage() {}
 
-func (x *SearchRequest_StringValue) ProtoReflect() protoreflect.Message {
+func (x *BlankChallengeCreateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1153,29 +1536,61 @@ func (x *SearchRequest_StringValue) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchRequest_StringValue.ProtoReflect.Descriptor instead.
-func (*SearchRequest_StringValue) Descriptor() ([]byte, []int) {
-	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{2, 1}
+// Deprecated: Use BlankChallengeCreateRequest.ProtoReflect.Descriptor instead.
+func (*BlankChallengeCreateRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *SearchRequest_StringValue) GetValue() string {
+func (x *BlankChallengeCreateRequest) GetLexicon() string {
 	if x != nil {
-		return x.Value
+		return x.Lexicon
 	}
 	return ""
 }
 
-type SearchRequest_StringArray struct {
+func (x *BlankChallengeCreateRequest) GetNumQuestions() int32 {
+	if x != nil {
+		return x.NumQuestions
+	}
+	return 0
+}
+
+func (x *BlankChallengeCreateRequest) GetMaxSolutions() int32 {
+	if x != nil {
+		return x.MaxSolutions
+	}
+	return 0
+}
+
+func (x *BlankChallengeCreateRequest) GetNumWith_2Blanks() int32 {
+	if x != nil {
+		return x.NumWith_2Blanks
+	}
+	return 0
+}
+
+func (x *BlankChallengeCreateRequest) GetWordLength() int32 {
+	if x != nil {
+		return x.WordLength
+	}
+	return 0
+}
+
+type BuildChallengeCreateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Used for alphagram_list
-	Values []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	Lexicon               string `protobuf:"bytes,1,opt,name=lexicon,proto3" json:"lexicon,omitempty"`
+	MinSolutions          int32  `protobuf:"varint,2,opt,name=min_solutions,json=minSolutions,proto3" json:"min_solutions,omitempty"`
+	MaxSolutions          int32  `protobuf:"varint,3,opt,name=max_solutions,json=maxSolutions,proto3" json:"max_solutions,omitempty"`
+	MinLength             int32  `protobuf:"varint,4,opt,name=min_length,json=minLength,proto3" json:"min_length,omitempty"`
+	MaxLength             int32  `protobuf:"varint,5,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"`
+	RequireLengthSolution bool   `protobuf:"varint,6,opt,name=require_length_solution,json=requireLengthSolution,proto3" json:"require_length_solution,omitempty"` // Whether a solution for the given word length is required
 }
 
-func (x *SearchRequest_StringArray) Reset() {
-	*x = SearchRequest_StringArray{}
+func (x *BuildChallengeCreateRequest) Reset() {
+	*x = BuildChallengeCreateRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1183,13 +1598,13 @@ func (x *SearchRequest_StringArray) Reset() {
 	}
 }
 
-func (x *SearchRequest_StringArray) String() string {
+func (x *BuildChallengeCreateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchRequest_StringArray) ProtoMessage() {}
+func (*BuildChallengeCreateRequest) ProtoMessage() {}
 
-func (x *SearchRequest_StringArray) ProtoReflect() protoreflect.Message {
+func (x *BuildChallengeCreateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1201,12 +1616,1502 @@ func (x *SearchRequest_StringArray) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchRequest_StringArray.ProtoReflect.Descriptor instead.
-func (*SearchRequest_StringArray) Descriptor() ([]byte, []int) {
-	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{2, 2}
+// Deprecated: Use BuildChallengeCreateRequest.ProtoReflect.Descriptor instead.
+func (*BuildChallengeCreateRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *SearchRequest_StringArray) GetValues() []string {
+func (x *BuildChallengeCreateRequest) GetLexicon() string {
+	if x != nil {
+		return x.Lexicon
+	}
+	return ""
+}
+
+func (x *BuildChallengeCreateRequest) GetMinSolutions() int32 {
+	if x != nil {
+		return x.MinSolutions
+	}
+	return 0
+}
+
+func (x *BuildChallengeCreateRequest) GetMaxSolutions() int32 {
+	if x != nil {
+		return x.MaxSolutions
+	}
+	return 0
+}
+
+func (x *BuildChallengeCreateRequest) GetMinLength() int32 {
+	if x != nil {
+		return x.MinLength
+	}
+	return 0
+}
+
+func (x *BuildChallengeCreateRequest) GetMaxLength() int32 {
+	if x != nil {
+		return x.MaxLength
+	}
+	return 0
+}
+
+func (x *BuildChallengeCreateRequest) GetRequireLengthSolution() bool {
+	if x != nil {
+		return x.RequireLengthSolution
+	}
+	return false
+}
+
+type StemRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lexicon string `protobuf:"bytes,1,opt,name=lexicon,proto3" json:"lexicon,omitempty"`
+	// The stems to look up, e.g. SATINE or RETAIN. If there are none, the
+	// most productive stems of stem_length are read from the lexicon
+	// database instead; those are only precomputed for 6-letter stems plus
+	// one or two letters and 7-letter stems plus one letter.
+	Stems []string `protobuf:"bytes,2,rep,name=stems,proto3" json:"stems,omitempty"`
+	// How many letters to add to each stem: 1 or 2.
+	NumAdded   int32 `protobuf:"varint,3,opt,name=num_added,json=numAdded,proto3" json:"num_added,omitempty"`
+	StemLength int32 `protobuf:"varint,4,opt,name=stem_length,json=stemLength,proto3" json:"stem_length,omitempty"`
+	// The most stems to return when reading them from the database. 0 means
+	// the default of 100.
+	Limit int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *StemRequest) Reset() {
+	*x = StemRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StemRequest) ProtoMessage() {}
+
+func (x *StemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StemRequest.ProtoReflect.Descriptor instead.
+func (*StemRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StemRequest) GetLexicon() string {
+	if x != nil {
+		return x.Lexicon
+	}
+	return ""
+}
+
+func (x *StemRequest) GetStems() []string {
+	if x != nil {
+		return x.Stems
+	}
+	return nil
+}
+
+func (x *StemRequest) GetNumAdded() int32 {
+	if x != nil {
+		return x.NumAdded
+	}
+	return 0
+}
+
+func (x *StemRequest) GetStemLength() int32 {
+	if x != nil {
+		return x.StemLength
+	}
+	return 0
+}
+
+func (x *StemRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// A StemAddition is a letter (or pair of letters) that can be added to a
+// stem, and the words it makes.
+type StemAddition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Letters string  `protobuf:"bytes,1,opt,name=letters,proto3" json:"letters,omitempty"`
+	Words   []*Word `protobuf:"bytes,2,rep,name=words,proto3" json:"words,omitempty"`
+}
+
+func (x *StemAddition) Reset() {
+	*x = StemAddition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StemAddition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StemAddition) ProtoMessage() {}
+
+func (x *StemAddition) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StemAddition.ProtoReflect.Descriptor instead.
+func (*StemAddition) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StemAddition) GetLetters() string {
+	if x != nil {
+		return x.Letters
+	}
+	return ""
+}
+
+func (x *StemAddition) GetWords() []*Word {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+type Stem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stem string `protobuf:"bytes,1,opt,name=stem,proto3" json:"stem,omitempty"`
+	// The productivity of the stem: how many distinct words it makes.
+	NumBingos int32           `protobuf:"varint,2,opt,name=num_bingos,json=numBingos,proto3" json:"num_bingos,omitempty"`
+	Additions []*StemAddition `protobuf:"bytes,3,rep,name=additions,proto3" json:"additions,omitempty"`
+}
+
+func (x *Stem) Reset() {
+	*x = Stem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Stem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stem) ProtoMessage() {}
+
+func (x *Stem) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stem.ProtoReflect.Descriptor instead.
+func (*Stem) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Stem) GetStem() string {
+	if x != nil {
+		return x.Stem
+	}
+	return ""
+}
+
+func (x *Stem) GetNumBingos() int32 {
+	if x != nil {
+		return x.NumBingos
+	}
+	return 0
+}
+
+func (x *Stem) GetAdditions() []*StemAddition {
+	if x != nil {
+		return x.Additions
+	}
+	return nil
+}
+
+type StemResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Sorted by productivity, most productive first.
+	Stems []*Stem `protobuf:"bytes,1,rep,name=stems,proto3" json:"stems,omitempty"`
+}
+
+func (x *StemResponse) Reset() {
+	*x = StemResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StemResponse) ProtoMessage() {}
+
+func (x *StemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StemResponse.ProtoReflect.Descriptor instead.
+func (*StemResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StemResponse) GetStems() []*Stem {
+	if x != nil {
+		return x.Stems
+	}
+	return nil
+}
+
+type ListLexicaRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListLexicaRequest) Reset() {
+	*x = ListLexicaRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListLexicaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLexicaRequest) ProtoMessage() {}
+
+func (x *ListLexicaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLexicaRequest.ProtoReflect.Descriptor instead.
+func (*ListLexicaRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{18}
+}
+
+type LetterDistributionTile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Letter string `protobuf:"bytes,1,opt,name=letter,proto3" json:"letter,omitempty"`
+	Count  int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Score  int32  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	Vowel  bool   `protobuf:"varint,4,opt,name=vowel,proto3" json:"vowel,omitempty"`
+}
+
+func (x *LetterDistributionTile) Reset() {
+	*x = LetterDistributionTile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LetterDistributionTile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LetterDistributionTile) ProtoMessage() {}
+
+func (x *LetterDistributionTile) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LetterDistributionTile.ProtoReflect.Descriptor instead.
+func (*LetterDistributionTile) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LetterDistributionTile) GetLetter() string {
+	if x != nil {
+		return x.Letter
+	}
+	return ""
+}
+
+func (x *LetterDistributionTile) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *LetterDistributionTile) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *LetterDistributionTile) GetVowel() bool {
+	if x != nil {
+		return x.Vowel
+	}
+	return false
+}
+
+type WordLengthCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Length   int32 `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"`
+	NumWords int32 `protobuf:"varint,2,opt,name=num_words,json=numWords,proto3" json:"num_words,omitempty"`
+}
+
+func (x *WordLengthCount) Reset() {
+	*x = WordLengthCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WordLengthCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordLengthCount) ProtoMessage() {}
+
+func (x *WordLengthCount) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordLengthCount.ProtoReflect.Descriptor instead.
+func (*WordLengthCount) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WordLengthCount) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *WordLengthCount) GetNumWords() int32 {
+	if x != nil {
+		return x.NumWords
+	}
+	return 0
+}
+
+type LexiconDescription struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name            string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DescriptiveName string `protobuf:"bytes,2,opt,name=descriptive_name,json=descriptiveName,proto3" json:"descriptive_name,omitempty"`
+	// The family of lexica this one belongs to, e.g. CSW or TWL. Empty if
+	// the database doesn't say.
+	Family             string                    `protobuf:"bytes,3,opt,name=family,proto3" json:"family,omitempty"`
+	DbVersion          int32                     `protobuf:"varint,4,opt,name=db_version,json=dbVersion,proto3" json:"db_version,omitempty"`
+	WordCounts         []*WordLengthCount        `protobuf:"bytes,5,rep,name=word_counts,json=wordCounts,proto3" json:"word_counts,omitempty"`
+	LetterDistribution string                    `protobuf:"bytes,6,opt,name=letter_distribution,json=letterDistribution,proto3" json:"letter_distribution,omitempty"`
+	Tiles              []*LetterDistributionTile `protobuf:"bytes,7,rep,name=tiles,proto3" json:"tiles,omitempty"`
+	HasDifficulty      bool                      `protobuf:"varint,8,opt,name=has_difficulty,json=hasDifficulty,proto3" json:"has_difficulty,omitempty"`
+	HasPlayability     bool                      `protobuf:"varint,9,opt,name=has_playability,json=hasPlayability,proto3" json:"has_playability,omitempty"`
+	// The previous lexicon in the family, if any.
+	PriorLexicon string `protobuf:"bytes,10,opt,name=prior_lexicon,json=priorLexicon,proto3" json:"prior_lexicon,omitempty"`
+}
+
+func (x *LexiconDescription) Reset() {
+	*x = LexiconDescription{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LexiconDescription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LexiconDescription) ProtoMessage() {}
+
+func (x *LexiconDescription) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LexiconDescription.ProtoReflect.Descriptor instead.
+func (*LexiconDescription) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *LexiconDescription) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LexiconDescription) GetDescriptiveName() string {
+	if x != nil {
+		return x.DescriptiveName
+	}
+	return ""
+}
+
+func (x *LexiconDescription) GetFamily() string {
+	if x != nil {
+		return x.Family
+	}
+	return ""
+}
+
+func (x *LexiconDescription) GetDbVersion() int32 {
+	if x != nil {
+		return x.DbVersion
+	}
+	return 0
+}
+
+func (x *LexiconDescription) GetWordCounts() []*WordLengthCount {
+	if x != nil {
+		return x.WordCounts
+	}
+	return nil
+}
+
+func (x *LexiconDescription) GetLetterDistribution() string {
+	if x != nil {
+		return x.LetterDistribution
+	}
+	return ""
+}
+
+func (x *LexiconDescription) GetTiles() []*LetterDistributionTile {
+	if x != nil {
+		return x.Tiles
+	}
+	return nil
+}
+
+func (x *LexiconDescription) GetHasDifficulty() bool {
+	if x != nil {
+		return x.HasDifficulty
+	}
+	return false
+}
+
+func (x *LexiconDescription) GetHasPlayability() bool {
+	if x != nil {
+		return x.HasPlayability
+	}
+	return false
+}
+
+func (x *LexiconDescription) GetPriorLexicon() string {
+	if x != nil {
+		return x.PriorLexicon
+	}
+	return ""
+}
+
+type ListLexicaResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lexica []*LexiconDescription `protobuf:"bytes,1,rep,name=lexica,proto3" json:"lexica,omitempty"`
+}
+
+func (x *ListLexicaResponse) Reset() {
+	*x = ListLexicaResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListLexicaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLexicaResponse) ProtoMessage() {}
+
+func (x *ListLexicaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLexicaResponse.ProtoReflect.Descriptor instead.
+func (*ListLexicaResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListLexicaResponse) GetLexica() []*LexiconDescription {
+	if x != nil {
+		return x.Lexica
+	}
+	return nil
+}
+
+// A PatternParam matches words against a pattern. The pattern language is:
+//
+//	A      a literal tile. Multi-character tiles are written as they are
+//	       in words.
+//	? or . any one tile
+//	*      zero or more tiles
+//	[ABC]  one of the tiles A, B or C
+//	[^ABC] any one tile except A, B or C
+//	^      at the start, anchors the pattern to the start of the word
+//	$      at the end, anchors the pattern to the end of the word
+//
+// A pattern without anchors can match anywhere in the word, so for example
+// "^UN" finds words starting with UN and "IEST$" finds words ending in
+// IEST.
+type PatternParam struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pattern string `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// Word length bounds. 0 means no bound.
+	MinLength    int32                    `protobuf:"varint,2,opt,name=min_length,json=minLength,proto3" json:"min_length,omitempty"`
+	MaxLength    int32                    `protobuf:"varint,3,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"`
+	LetterCounts []*LetterCountConstraint `protobuf:"bytes,4,rep,name=letter_counts,json=letterCounts,proto3" json:"letter_counts,omitempty"`
+}
+
+func (x *PatternParam) Reset() {
+	*x = PatternParam{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PatternParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatternParam) ProtoMessage() {}
+
+func (x *PatternParam) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatternParam.ProtoReflect.Descriptor instead.
+func (*PatternParam) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PatternParam) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *PatternParam) GetMinLength() int32 {
+	if x != nil {
+		return x.MinLength
+	}
+	return 0
+}
+
+func (x *PatternParam) GetMaxLength() int32 {
+	if x != nil {
+		return x.MaxLength
+	}
+	return 0
+}
+
+func (x *PatternParam) GetLetterCounts() []*LetterCountConstraint {
+	if x != nil {
+		return x.LetterCounts
+	}
+	return nil
+}
+
+// A LetterCountConstraint bounds how many of a word's tiles are among the
+// given letters. For example, letters AEIOU with min 0 and max 1 allows at
+// most one vowel. If max is less than min there is no upper bound, so
+// letters Q with min 1 requires a Q, and letters U with min 0 and max 0
+// excludes the U.
+type LetterCountConstraint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Letters string `protobuf:"bytes,1,opt,name=letters,proto3" json:"letters,omitempty"`
+	Min     int32  `protobuf:"varint,2,opt,name=min,proto3" json:"min,omitempty"`
+	Max     int32  `protobuf:"varint,3,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *LetterCountConstraint) Reset() {
+	*x = LetterCountConstraint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LetterCountConstraint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LetterCountConstraint) ProtoMessage() {}
+
+func (x *LetterCountConstraint) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LetterCountConstraint.ProtoReflect.Descriptor instead.
+func (*LetterCountConstraint) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *LetterCountConstraint) GetLetters() string {
+	if x != nil {
+		return x.Letters
+	}
+	return ""
+}
+
+func (x *LetterCountConstraint) GetMin() int32 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *LetterCountConstraint) GetMax() int32 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+type WordSearchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lexicon string `protobuf:"bytes,1,opt,name=lexicon,proto3" json:"lexicon,omitempty"`
+	// the only acceptable glob characters are * and ?. These get mapped
+	// to SQLITE % and _, respectively.
+	Glob string `protobuf:"bytes,2,opt,name=glob,proto3" json:"glob,omitempty"`
+	// Which field the glob applies to (word or definition?)
+	AppliesTo string `protobuf:"bytes,3,opt,name=applies_to,json=appliesTo,proto3" json:"applies_to,omitempty"`
+	// If pattern is set, words are matched against it instead, and glob and
+	// applies_to are ignored.
+	Pattern *PatternParam `protobuf:"bytes,4,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// For full-text definition searches (see DEFINITION_CONTAINS), the glob
+	// is the search, and the words come back most relevant first, with
+	// definition snippets. These mark the matching terms in the snippets;
+	// they default to <b> and </b>.
+	HighlightStart string `protobuf:"bytes,5,opt,name=highlight_start,json=highlightStart,proto3" json:"highlight_start,omitempty"`
+	HighlightEnd   string `protobuf:"bytes,6,opt,name=highlight_end,json=highlightEnd,proto3" json:"highlight_end,omitempty"`
+	// If positive, at most this many of the most relevant words are returned
+	// by a full-text definition search, or this many of the first matches
+	// (alphabetically) by a pattern search. A pattern search can't ask for
+	// more than the server's maximum, and without a limit it fails with
+	// RESOURCE_EXHAUSTED if it matches more than that.
+	Limit int32 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *WordSearchRequest) Reset() {
+	*x = WordSearchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WordSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordSearchRequest) ProtoMessage() {}
+
+func (x *WordSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordSearchRequest.ProtoReflect.Descriptor instead.
+func (*WordSearchRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *WordSearchRequest) GetLexicon() string {
+	if x != nil {
+		return x.Lexicon
+	}
+	return ""
+}
+
+func (x *WordSearchRequest) GetGlob() string {
+	if x != nil {
+		return x.Glob
+	}
+	return ""
+}
+
+func (x *WordSearchRequest) GetAppliesTo() string {
+	if x != nil {
+		return x.AppliesTo
+	}
+	return ""
+}
+
+func (x *WordSearchRequest) GetPattern() *PatternParam {
+	if x != nil {
+		return x.Pattern
+	}
+	return nil
+}
+
+func (x *WordSearchRequest) GetHighlightStart() string {
+	if x != nil {
+		return x.HighlightStart
+	}
+	return ""
+}
+
+func (x *WordSearchRequest) GetHighlightEnd() string {
+	if x != nil {
+		return x.HighlightEnd
+	}
+	return ""
+}
+
+func (x *WordSearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type DefineRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lexicon string `protobuf:"bytes,1,opt,name=lexicon,proto3" json:"lexicon,omitempty"`
+	Word    string `protobuf:"bytes,2,opt,name=word,proto3" json:"word,omitempty"`
+}
+
+func (x *DefineRequest) Reset() {
+	*x = DefineRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DefineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DefineRequest) ProtoMessage() {}
+
+func (x *DefineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DefineRequest.ProtoReflect.Descriptor instead.
+func (*DefineRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DefineRequest) GetLexicon() string {
+	if x != nil {
+		return x.Lexicon
+	}
+	return ""
+}
+
+func (x *DefineRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+type WordSearchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Words []*Word `protobuf:"bytes,1,rep,name=words,proto3" json:"words,omitempty"`
+}
+
+func (x *WordSearchResponse) Reset() {
+	*x = WordSearchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WordSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordSearchResponse) ProtoMessage() {}
+
+func (x *WordSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordSearchResponse.ProtoReflect.Descriptor instead.
+func (*WordSearchResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *WordSearchResponse) GetWords() []*Word {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+// RelatedWordsResponse is the family of a word: its roots, their
+// inflections, and their variants. Only words in the lexicon are included.
+type RelatedWordsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Word *Word `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	// The words this one is an inflection of, or just the word itself if it
+	// isn't an inflection of anything.
+	Roots []*Word `protobuf:"bytes,2,rep,name=roots,proto3" json:"roots,omitempty"`
+	// The inflections of the roots, e.g. CATS, CATTED and CATTING for CAT.
+	Inflections []*Word `protobuf:"bytes,3,rep,name=inflections,proto3" json:"inflections,omitempty"`
+	// Other forms of the roots, as cross-referenced by their definitions;
+	// e.g. EMIR for EMEER, and EMEER for EMIR.
+	Variants []*Word `protobuf:"bytes,4,rep,name=variants,proto3" json:"variants,omitempty"`
+}
+
+func (x *RelatedWordsResponse) Reset() {
+	*x = RelatedWordsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RelatedWordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelatedWordsResponse) ProtoMessage() {}
+
+func (x *RelatedWordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelatedWordsResponse.ProtoReflect.Descriptor instead.
+func (*RelatedWordsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RelatedWordsResponse) GetWord() *Word {
+	if x != nil {
+		return x.Word
+	}
+	return nil
+}
+
+func (x *RelatedWordsResponse) GetRoots() []*Word {
+	if x != nil {
+		return x.Roots
+	}
+	return nil
+}
+
+func (x *RelatedWordsResponse) GetInflections() []*Word {
+	if x != nil {
+		return x.Inflections
+	}
+	return nil
+}
+
+func (x *RelatedWordsResponse) GetVariants() []*Word {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+type CheckWordsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lexicon string `protobuf:"bytes,1,opt,name=lexicon,proto3" json:"lexicon,omitempty"`
+	// The words to check, e.g. all the words formed by a play. Case doesn't
+	// matter, so blanks can be written in lower case.
+	Words []string `protobuf:"bytes,2,rep,name=words,proto3" json:"words,omitempty"`
+	// More lexica to check the same words in, e.g. NWL2023 as well as CSW24.
+	// Each gets a verdict of its own. There can be at most 5, none of them
+	// listed twice or the same as lexicon.
+	OtherLexica []string `protobuf:"bytes,3,rep,name=other_lexica,json=otherLexica,proto3" json:"other_lexica,omitempty"`
+	// If set, the words' info (lexicon symbols, definitions and so on) isn't
+	// looked up; only their validity is checked.
+	ValidityOnly bool `protobuf:"varint,4,opt,name=validity_only,json=validityOnly,proto3" json:"validity_only,omitempty"`
+}
+
+func (x *CheckWordsRequest) Reset() {
+	*x = CheckWordsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckWordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckWordsRequest) ProtoMessage() {}
+
+func (x *CheckWordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckWordsRequest.ProtoReflect.Descriptor instead.
+func (*CheckWordsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CheckWordsRequest) GetLexicon() string {
+	if x != nil {
+		return x.Lexicon
+	}
+	return ""
+}
+
+func (x *CheckWordsRequest) GetWords() []string {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+func (x *CheckWordsRequest) GetOtherLexica() []string {
+	if x != nil {
+		return x.OtherLexica
+	}
+	return nil
+}
+
+func (x *CheckWordsRequest) GetValidityOnly() bool {
+	if x != nil {
+		return x.ValidityOnly
+	}
+	return false
+}
+
+// CheckedWord is the ruling on one word in one lexicon.
+type CheckedWord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The word as it was checked, in upper case.
+	Word  string `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	Valid bool   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	// The word's info from the lexicon database. Only set for valid words,
+	// and not if validity_only is set or the lexicon has no database.
+	Info *Word `protobuf:"bytes,3,opt,name=info,proto3" json:"info,omitempty"`
+}
+
+func (x *CheckedWord) Reset() {
+	*x = CheckedWord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckedWord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckedWord) ProtoMessage() {}
+
+func (x *CheckedWord) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckedWord.ProtoReflect.Descriptor instead.
+func (*CheckedWord) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CheckedWord) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *CheckedWord) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *CheckedWord) GetInfo() *Word {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+type LexiconVerdict struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lexicon string `protobuf:"bytes,1,opt,name=lexicon,proto3" json:"lexicon,omitempty"`
+	// Whether every word is valid, i.e. whether a play forming these words
+	// stands if challenged.
+	Valid bool `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	// The words, in the order they were asked about.
+	Words []*CheckedWord `protobuf:"bytes,3,rep,name=words,proto3" json:"words,omitempty"`
+}
+
+func (x *LexiconVerdict) Reset() {
+	*x = LexiconVerdict{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LexiconVerdict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LexiconVerdict) ProtoMessage() {}
+
+func (x *LexiconVerdict) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LexiconVerdict.ProtoReflect.Descriptor instead.
+func (*LexiconVerdict) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *LexiconVerdict) GetLexicon() string {
+	if x != nil {
+		return x.Lexicon
+	}
+	return ""
+}
+
+func (x *LexiconVerdict) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *LexiconVerdict) GetWords() []*CheckedWord {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+type CheckWordsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The verdict in the request's lexicon.
+	Valid bool `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	// The request's lexicon first, then other_lexica in order.
+	Verdicts []*LexiconVerdict `protobuf:"bytes,2,rep,name=verdicts,proto3" json:"verdicts,omitempty"`
+}
+
+func (x *CheckWordsResponse) Reset() {
+	*x = CheckWordsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckWordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckWordsResponse) ProtoMessage() {}
+
+func (x *CheckWordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckWordsResponse.ProtoReflect.Descriptor instead.
+func (*CheckWordsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CheckWordsResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *CheckWordsResponse) GetVerdicts() []*LexiconVerdict {
+	if x != nil {
+		return x.Verdicts
+	}
+	return nil
+}
+
+type SearchRequest_MinMax struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Used for length, prob range, prob limit, num anagrams,
+	// num_vowels, point value, num distinct letters, max letter repeats,
+	// hook and extension counts
+	Min int32 `protobuf:"varint,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max int32 `protobuf:"varint,2,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *SearchRequest_MinMax) Reset() {
+	*x = SearchRequest_MinMax{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchRequest_MinMax) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest_MinMax) ProtoMessage() {}
+
+func (x *SearchRequest_MinMax) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest_MinMax.ProtoReflect.Descriptor instead.
+func (*SearchRequest_MinMax) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *SearchRequest_MinMax) GetMin() int32 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *SearchRequest_MinMax) GetMax() int32 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+type SearchRequest_StringValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Used for lexicon, matching anagram, not_in_lexicon, contains letters,
+	// excludes letters, lexicon symbols, added in lexicon, in every lexicon
+	// since, part of speech, inflection of
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *SearchRequest_StringValue) Reset() {
+	*x = SearchRequest_StringValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchRequest_StringValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest_StringValue) ProtoMessage() {}
+
+func (x *SearchRequest_StringValue) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest_StringValue.ProtoReflect.Descriptor instead.
+func (*SearchRequest_StringValue) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{3, 1}
+}
+
+func (x *SearchRequest_StringValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type SearchRequest_StringArray struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Used for alphagram_list, changed since
+	Values []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *SearchRequest_StringArray) Reset() {
+	*x = SearchRequest_StringArray{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchRequest_StringArray) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest_StringArray) ProtoMessage() {}
+
+func (x *SearchRequest_StringArray) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest_StringArray.ProtoReflect.Descriptor instead.
+func (*SearchRequest_StringArray) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{3, 2}
+}
+
+func (x *SearchRequest_StringArray) GetValues() []string {
 	if x != nil {
 		return x.Values
 	}
@@ -1225,7 +3130,7 @@ type SearchRequest_NumberArray struct {
 func (x *SearchRequest_NumberArray) Reset() {
 	*x = SearchRequest_NumberArray{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[14]
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1238,7 +3143,7 @@ func (x *SearchRequest_NumberArray) String() string {
 func (*SearchRequest_NumberArray) ProtoMessage() {}
 
 func (x *SearchRequest_NumberArray) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[14]
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1251,7 +3156,7 @@ func (x *SearchRequest_NumberArray) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchRequest_NumberArray.ProtoReflect.Descriptor instead.
 func (*SearchRequest_NumberArray) Descriptor() ([]byte, []int) {
-	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{2, 3}
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{3, 3}
 }
 
 func (x *SearchRequest_NumberArray) GetValues() []int32 {
@@ -1272,7 +3177,7 @@ type SearchRequest_NumberValue struct {
 func (x *SearchRequest_NumberValue) Reset() {
 	*x = SearchRequest_NumberValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[15]
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1285,7 +3190,7 @@ func (x *SearchRequest_NumberValue) String() string {
 func (*SearchRequest_NumberValue) ProtoMessage() {}
 
 func (x *SearchRequest_NumberValue) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[15]
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1298,7 +3203,7 @@ func (x *SearchRequest_NumberValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchRequest_NumberValue.ProtoReflect.Descriptor instead.
 func (*SearchRequest_NumberValue) Descriptor() ([]byte, []int) {
-	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{2, 4}
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{3, 4}
 }
 
 func (x *SearchRequest_NumberValue) GetValue() int32 {
@@ -1321,7 +3226,7 @@ type SearchRequest_HooksParam struct {
 func (x *SearchRequest_HooksParam) Reset() {
 	*x = SearchRequest_HooksParam{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[16]
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1334,7 +3239,7 @@ func (x *SearchRequest_HooksParam) String() string {
 func (*SearchRequest_HooksParam) ProtoMessage() {}
 
 func (x *SearchRequest_HooksParam) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[16]
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1347,7 +3252,7 @@ func (x *SearchRequest_HooksParam) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchRequest_HooksParam.ProtoReflect.Descriptor instead.
 func (*SearchRequest_HooksParam) Descriptor() ([]byte, []int) {
-	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{2, 5}
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{3, 5}
 }
 
 func (x *SearchRequest_HooksParam) GetHookType() SearchRequest_HookType {
@@ -1371,6 +3276,199 @@ func (x *SearchRequest_HooksParam) GetNotCondition() bool {
 	return false
 }
 
+type SearchRequest_ConditionGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Used for condition_group. The top-level searchparams list is an
+	// implicit AND group; groups can nest arbitrarily deep. LEXICON,
+	// PROBABILITY_LIMIT, WORD_LIST and DELETED_WORD are not allowed
+	// inside a group.
+	Operator SearchRequest_GroupOperator  `protobuf:"varint,1,opt,name=operator,proto3,enum=wordsearcher.SearchRequest_GroupOperator" json:"operator,omitempty"`
+	Params   []*SearchRequest_SearchParam `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty"`
+}
+
+func (x *SearchRequest_ConditionGroup) Reset() {
+	*x = SearchRequest_ConditionGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchRequest_ConditionGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest_ConditionGroup) ProtoMessage() {}
+
+func (x *SearchRequest_ConditionGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest_ConditionGroup.ProtoReflect.Descriptor instead.
+func (*SearchRequest_ConditionGroup) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{3, 6}
+}
+
+func (x *SearchRequest_ConditionGroup) GetOperator() SearchRequest_GroupOperator {
+	if x != nil {
+		return x.Operator
+	}
+	return SearchRequest_AND
+}
+
+func (x *SearchRequest_ConditionGroup) GetParams() []*SearchRequest_SearchParam {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type SearchRequest_CrossLexiconParam struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The lexica the word must be valid in, and the ones it must not be
+	// valid in. For example, searching CSW24 with not_in_lexica NWL2023
+	// finds the CSW-only words.
+	InLexica    []string `protobuf:"bytes,1,rep,name=in_lexica,json=inLexica,proto3" json:"in_lexica,omitempty"`
+	NotInLexica []string `protobuf:"bytes,2,rep,name=not_in_lexica,json=notInLexica,proto3" json:"not_in_lexica,omitempty"`
+	// If word_level is false, the conditions apply to alphagrams: an
+	// alphagram is in a lexicon if any of its anagrams is valid there. If
+	// it is true, they apply to individual words, and the alphagrams with
+	// at least one matching word are returned.
+	WordLevel bool `protobuf:"varint,3,opt,name=word_level,json=wordLevel,proto3" json:"word_level,omitempty"`
+}
+
+func (x *SearchRequest_CrossLexiconParam) Reset() {
+	*x = SearchRequest_CrossLexiconParam{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchRequest_CrossLexiconParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest_CrossLexiconParam) ProtoMessage() {}
+
+func (x *SearchRequest_CrossLexiconParam) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest_CrossLexiconParam.ProtoReflect.Descriptor instead.
+func (*SearchRequest_CrossLexiconParam) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{3, 7}
+}
+
+func (x *SearchRequest_CrossLexiconParam) GetInLexica() []string {
+	if x != nil {
+		return x.InLexica
+	}
+	return nil
+}
+
+func (x *SearchRequest_CrossLexiconParam) GetNotInLexica() []string {
+	if x != nil {
+		return x.NotInLexica
+	}
+	return nil
+}
+
+func (x *SearchRequest_CrossLexiconParam) GetWordLevel() bool {
+	if x != nil {
+		return x.WordLevel
+	}
+	return false
+}
+
+type SearchRequest_SortSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field      SearchRequest_SortSpec_Field `protobuf:"varint,1,opt,name=field,proto3,enum=wordsearcher.SearchRequest_SortSpec_Field" json:"field,omitempty"`
+	Descending bool                         `protobuf:"varint,2,opt,name=descending,proto3" json:"descending,omitempty"`
+	// Only used for RANDOM.
+	Seed int64 `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`
+}
+
+func (x *SearchRequest_SortSpec) Reset() {
+	*x = SearchRequest_SortSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchRequest_SortSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest_SortSpec) ProtoMessage() {}
+
+func (x *SearchRequest_SortSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest_SortSpec.ProtoReflect.Descriptor instead.
+func (*SearchRequest_SortSpec) Descriptor() ([]byte, []int) {
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{3, 8}
+}
+
+func (x *SearchRequest_SortSpec) GetField() SearchRequest_SortSpec_Field {
+	if x != nil {
+		return x.Field
+	}
+	return SearchRequest_SortSpec_PROBABILITY
+}
+
+func (x *SearchRequest_SortSpec) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *SearchRequest_SortSpec) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
 type SearchRequest_SearchParam struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1385,13 +3483,16 @@ type SearchRequest_SearchParam struct {
 	//	*SearchRequest_SearchParam_Numberarray
 	//	*SearchRequest_SearchParam_Numbervalue
 	//	*SearchRequest_SearchParam_Hooksparam
+	//	*SearchRequest_SearchParam_Group
+	//	*SearchRequest_SearchParam_Pattern
+	//	*SearchRequest_SearchParam_Crosslexicon
 	Conditionparam isSearchRequest_SearchParam_Conditionparam `protobuf_oneof:"conditionparam"`
 }
 
 func (x *SearchRequest_SearchParam) Reset() {
 	*x = SearchRequest_SearchParam{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[17]
+		mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1404,7 +3505,7 @@ func (x *SearchRequest_SearchParam) String() string {
 func (*SearchRequest_SearchParam) ProtoMessage() {}
 
 func (x *SearchRequest_SearchParam) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[17]
+	mi := &file_rpc_wordsearcher_searcher_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1417,7 +3518,7 @@ func (x *SearchRequest_SearchParam) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchRequest_SearchParam.ProtoReflect.Descriptor instead.
 func (*SearchRequest_SearchParam) Descriptor() ([]byte, []int) {
-	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{2, 6}
+	return file_rpc_wordsearcher_searcher_proto_rawDescGZIP(), []int{3, 9}
 }
 
 func (x *SearchRequest_SearchParam) GetCondition() SearchRequest_Condition {
@@ -1476,6 +3577,27 @@ func (x *SearchRequest_SearchParam) GetHooksparam() *SearchRequest_HooksParam {
 	return nil
 }
 
+func (x *SearchRequest_SearchParam) GetGroup() *SearchRequest_ConditionGroup {
+	if x, ok := x.GetConditionparam().(*SearchRequest_SearchParam_Group); ok {
+		return x.Group
+	}
+	return nil
+}
+
+func (x *SearchRequest_SearchParam) GetPattern() *PatternParam {
+	if x, ok := x.GetConditionparam().(*SearchRequest_SearchParam_Pattern); ok {
+		return x.Pattern
+	}
+	return nil
+}
+
+func (x *SearchRequest_SearchParam) GetCrosslexicon() *SearchRequest_CrossLexiconParam {
+	if x, ok := x.GetConditionparam().(*SearchRequest_SearchParam_Crosslexicon); ok {
+		return x.Crosslexicon
+	}
+	return nil
+}
+
 type isSearchRequest_SearchParam_Conditionparam interface {
 	isSearchRequest_SearchParam_Conditionparam()
 }
@@ -1504,6 +3626,18 @@ type SearchRequest_SearchParam_Hooksparam struct {
 	Hooksparam *SearchRequest_HooksParam `protobuf:"bytes,7,opt,name=hooksparam,proto3,oneof"`
 }
 
+type SearchRequest_SearchParam_Group struct {
+	Group *SearchRequest_ConditionGroup `protobuf:"bytes,8,opt,name=group,proto3,oneof"`
+}
+
+type SearchRequest_SearchParam_Pattern struct {
+	Pattern *PatternParam `protobuf:"bytes,9,opt,name=pattern,proto3,oneof"`
+}
+
+type SearchRequest_SearchParam_Crosslexicon struct {
+	Crosslexicon *SearchRequest_CrossLexiconParam `protobuf:"bytes,10,opt,name=crosslexicon,proto3,oneof"`
+}
+
 func (*SearchRequest_SearchParam_Minmax) isSearchRequest_SearchParam_Conditionparam() {}
 
 func (*SearchRequest_SearchParam_Stringvalue) isSearchRequest_SearchParam_Conditionparam() {}
@@ -1516,13 +3650,19 @@ func (*SearchRequest_SearchParam_Numbervalue) isSearchRequest_SearchParam_Condit
 
 func (*SearchRequest_SearchParam_Hooksparam) isSearchRequest_SearchParam_Conditionparam() {}
 
+func (*SearchRequest_SearchParam_Group) isSearchRequest_SearchParam_Conditionparam() {}
+
+func (*SearchRequest_SearchParam_Pattern) isSearchRequest_SearchParam_Conditionparam() {}
+
+func (*SearchRequest_SearchParam_Crosslexicon) isSearchRequest_SearchParam_Conditionparam() {}
+
 var File_rpc_wordsearcher_searcher_proto protoreflect.FileDescriptor
 
 var file_rpc_wordsearcher_searcher_proto_rawDesc = []byte{
 	0x0a, 0x1f, 0x72, 0x70, 0x63, 0x2f, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68,
 	0x65, 0x72, 0x2f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x12, 0x0c, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x22,
-	0xf5, 0x01, 0x0a, 0x09, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x1c, 0x0a,
+	0x97, 0x02, 0x0a, 0x09, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x1c, 0x0a,
 	0x09, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x09, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x28, 0x0a, 0x05, 0x77,
 	0x6f, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x77, 0x6f, 0x72,
@@ -1537,243 +3677,577 @@ var file_rpc_wordsearcher_searcher_proto_rawDesc = []byte{
 	0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x6f, 0x6d, 0x62, 0x69,
 	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x69, 0x66, 0x66, 0x69,
 	0x63, 0x75, 0x6c, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x64, 0x69, 0x66,
-	0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x22, 0x93, 0x02, 0x0a, 0x04, 0x57, 0x6f, 0x72, 0x64,
-	0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x77, 0x6f, 0x72, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x67, 0x72, 0x61,
-	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x67, 0x72,
-	0x61, 0x6d, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x5f, 0x68, 0x6f, 0x6f, 0x6b,
-	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x48, 0x6f,
-	0x6f, 0x6b, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x68, 0x6f, 0x6f, 0x6b,
-	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x48, 0x6f, 0x6f,
-	0x6b, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x5f, 0x73, 0x79,
-	0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6c, 0x65, 0x78,
-	0x69, 0x63, 0x6f, 0x6e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x69,
-	0x6e, 0x6e, 0x65, 0x72, 0x5f, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x46, 0x72, 0x6f, 0x6e,
-	0x74, 0x48, 0x6f, 0x6f, 0x6b, 0x12, 0x26, 0x0a, 0x0f, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x5f, 0x62,
-	0x61, 0x63, 0x6b, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d,
-	0x69, 0x6e, 0x6e, 0x65, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x48, 0x6f, 0x6f, 0x6b, 0x22, 0xbc, 0x0c,
-	0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x4b, 0x0a, 0x0c, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x0c,
-	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x16, 0x0a, 0x06,
-	0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78,
-	0x70, 0x61, 0x6e, 0x64, 0x1a, 0x2c, 0x0a, 0x06, 0x4d, 0x69, 0x6e, 0x4d, 0x61, 0x78, 0x12, 0x10,
-	0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x69, 0x6e,
-	0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d,
-	0x61, 0x78, 0x1a, 0x23, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x25, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x69, 0x6e,
-	0x67, 0x41, 0x72, 0x72, 0x61, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x1a, 0x25,
-	0x0a, 0x0b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x72, 0x72, 0x61, 0x79, 0x12, 0x16, 0x0a,
-	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x06, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x73, 0x1a, 0x23, 0x0a, 0x0b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x8a, 0x01, 0x0a, 0x0a, 0x48,
-	0x6f, 0x6f, 0x6b, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x41, 0x0a, 0x09, 0x68, 0x6f, 0x6f,
-	0x6b, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x77,
-	0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x48, 0x6f, 0x6f, 0x6b, 0x54, 0x79,
-	0x70, 0x65, 0x52, 0x08, 0x68, 0x6f, 0x6f, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05,
-	0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f, 0x6f,
-	0x6b, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x6f, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6e, 0x6f, 0x74, 0x43, 0x6f,
-	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0xa0, 0x04, 0x0a, 0x0b, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x43, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x77, 0x6f, 0x72,
-	0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3c, 0x0a, 0x06,
-	0x6d, 0x69, 0x6e, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x77,
-	0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x69, 0x6e, 0x4d, 0x61, 0x78,
-	0x48, 0x00, 0x52, 0x06, 0x6d, 0x69, 0x6e, 0x6d, 0x61, 0x78, 0x12, 0x4b, 0x0a, 0x0b, 0x73, 0x74,
-	0x72, 0x69, 0x6e, 0x67, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x27, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53,
-	0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x72,
-	0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x74, 0x72, 0x69,
-	0x6e, 0x67, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x4b, 0x0a, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x6e,
-	0x67, 0x61, 0x72, 0x72, 0x61, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x77,
-	0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
-	0x41, 0x72, 0x72, 0x61, 0x79, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x61,
-	0x72, 0x72, 0x61, 0x79, 0x12, 0x4b, 0x0a, 0x0b, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x61, 0x72,
-	0x72, 0x61, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x77, 0x6f, 0x72, 0x64,
-	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x72, 0x72,
-	0x61, 0x79, 0x48, 0x00, 0x52, 0x0b, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x61, 0x72, 0x72, 0x61,
-	0x79, 0x12, 0x4b, 0x0a, 0x0b, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61,
+	0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x6c, 0x61, 0x79, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x6c,
+	0x61, 0x79, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0xc6, 0x03, 0x0a, 0x04, 0x57, 0x6f,
+	0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x67,
+	0x72, 0x61, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x67, 0x72, 0x61, 0x6d, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x5f, 0x68, 0x6f,
+	0x6f, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x72, 0x6f, 0x6e, 0x74,
+	0x48, 0x6f, 0x6f, 0x6b, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x68, 0x6f,
+	0x6f, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x48,
+	0x6f, 0x6f, 0x6b, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x5f,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6c,
+	0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x12, 0x28, 0x0a,
+	0x10, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x5f, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x5f, 0x68, 0x6f, 0x6f,
+	0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x46, 0x72,
+	0x6f, 0x6e, 0x74, 0x48, 0x6f, 0x6f, 0x6b, 0x12, 0x26, 0x0a, 0x0f, 0x69, 0x6e, 0x6e, 0x65, 0x72,
+	0x5f, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0d, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x48, 0x6f, 0x6f, 0x6b, 0x12,
+	0x23, 0x0a, 0x0d, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4c, 0x65, 0x78,
+	0x69, 0x63, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6c, 0x65, 0x78,
+	0x69, 0x63, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74,
+	0x4c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x0b, 0x64, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x77,
+	0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x66, 0x69,
+	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x11, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x6e, 0x69, 0x70, 0x70,
+	0x65, 0x74, 0x22, 0x87, 0x01, 0x0a, 0x0a, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x61, 0x72, 0x74, 0x5f, 0x6f, 0x66, 0x5f, 0x73, 0x70, 0x65,
+	0x65, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x4f,
+	0x66, 0x53, 0x70, 0x65, 0x65, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x6c, 0x6f, 0x73, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x6c, 0x6f, 0x73, 0x73, 0x12, 0x20, 0x0a,
+	0x0b, 0x69, 0x6e, 0x66, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x66, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x72, 0x6f, 0x6f, 0x74, 0x57, 0x6f, 0x72, 0x64, 0x22, 0xcc, 0x16, 0x0a,
+	0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4b,
+	0x0a, 0x0c, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x0c, 0x73,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65,
+	0x78, 0x70, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x70,
+	0x61, 0x6e, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x38, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x53,
+	0x70, 0x65, 0x63, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x1a, 0x2c, 0x0a, 0x06, 0x4d, 0x69, 0x6e,
+	0x4d, 0x61, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x1a, 0x23, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x25, 0x0a, 0x0b,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x72, 0x72, 0x61, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x1a, 0x25, 0x0a, 0x0b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x72, 0x72,
+	0x61, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x05, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x1a, 0x23, 0x0a, 0x0b, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a,
+	0x8a, 0x01, 0x0a, 0x0a, 0x48, 0x6f, 0x6f, 0x6b, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x41,
+	0x0a, 0x09, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x24, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72,
+	0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x48,
+	0x6f, 0x6f, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x68, 0x6f, 0x6f, 0x6b, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x6f, 0x74, 0x5f, 0x63,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c,
+	0x6e, 0x6f, 0x74, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x98, 0x01, 0x0a,
+	0x0e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12,
+	0x45, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x29, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72,
+	0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x3f, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61,
 	0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48,
-	0x00, 0x52, 0x0b, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x48,
-	0x0a, 0x0a, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65,
-	0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
-	0x48, 0x6f, 0x6f, 0x6b, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x48, 0x00, 0x52, 0x0a, 0x68, 0x6f,
-	0x6f, 0x6b, 0x73, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x42, 0x10, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x64,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x22, 0xd3, 0x03, 0x0a, 0x09, 0x43,
-	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x4c, 0x45, 0x58, 0x49,
-	0x43, 0x4f, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x45, 0x4e, 0x47, 0x54, 0x48, 0x10,
-	0x01, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x52, 0x4f, 0x42, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59,
-	0x5f, 0x52, 0x41, 0x4e, 0x47, 0x45, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x52, 0x4f, 0x42,
-	0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x03, 0x12, 0x15,
-	0x0a, 0x11, 0x50, 0x52, 0x4f, 0x42, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x4c, 0x49,
-	0x4d, 0x49, 0x54, 0x10, 0x04, 0x12, 0x16, 0x0a, 0x12, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f,
-	0x4f, 0x46, 0x5f, 0x41, 0x4e, 0x41, 0x47, 0x52, 0x41, 0x4d, 0x53, 0x10, 0x05, 0x12, 0x14, 0x0a,
-	0x10, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x4f, 0x46, 0x5f, 0x56, 0x4f, 0x57, 0x45, 0x4c,
-	0x53, 0x10, 0x06, 0x12, 0x0c, 0x0a, 0x08, 0x48, 0x41, 0x53, 0x5f, 0x54, 0x41, 0x47, 0x53, 0x10,
-	0x07, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x4f, 0x49, 0x4e, 0x54, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45,
-	0x10, 0x08, 0x12, 0x14, 0x0a, 0x10, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x49, 0x4e, 0x47, 0x5f, 0x41,
-	0x4e, 0x41, 0x47, 0x52, 0x41, 0x4d, 0x10, 0x09, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x4c, 0x50, 0x48,
-	0x41, 0x47, 0x52, 0x41, 0x4d, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x0a, 0x12, 0x12, 0x0a, 0x0e,
-	0x4e, 0x4f, 0x54, 0x5f, 0x49, 0x4e, 0x5f, 0x4c, 0x45, 0x58, 0x49, 0x43, 0x4f, 0x4e, 0x10, 0x0b,
-	0x12, 0x23, 0x0a, 0x1f, 0x55, 0x50, 0x4c, 0x4f, 0x41, 0x44, 0x45, 0x44, 0x5f, 0x57, 0x4f, 0x52,
-	0x44, 0x5f, 0x4f, 0x52, 0x5f, 0x41, 0x4c, 0x50, 0x48, 0x41, 0x47, 0x52, 0x41, 0x4d, 0x5f, 0x4c,
-	0x49, 0x53, 0x54, 0x10, 0x0c, 0x12, 0x0d, 0x0a, 0x09, 0x57, 0x4f, 0x52, 0x44, 0x5f, 0x4c, 0x49,
-	0x53, 0x54, 0x10, 0x0d, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x49, 0x4e, 0x47, 0x4c, 0x45, 0x5f, 0x56,
-	0x41, 0x4c, 0x55, 0x45, 0x5f, 0x4c, 0x45, 0x4e, 0x47, 0x54, 0x48, 0x10, 0x0e, 0x12, 0x12, 0x0a,
-	0x0e, 0x4e, 0x55, 0x4d, 0x5f, 0x54, 0x57, 0x4f, 0x5f, 0x42, 0x4c, 0x41, 0x4e, 0x4b, 0x53, 0x10,
-	0x0f, 0x12, 0x11, 0x0a, 0x0d, 0x4d, 0x41, 0x58, 0x5f, 0x53, 0x4f, 0x4c, 0x55, 0x54, 0x49, 0x4f,
-	0x4e, 0x53, 0x10, 0x10, 0x12, 0x14, 0x0a, 0x10, 0x44, 0x49, 0x46, 0x46, 0x49, 0x43, 0x55, 0x4c,
-	0x54, 0x59, 0x5f, 0x52, 0x41, 0x4e, 0x47, 0x45, 0x10, 0x11, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x4c,
-	0x41, 0x59, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x52, 0x41, 0x4e, 0x47, 0x45, 0x10,
-	0x12, 0x12, 0x10, 0x0a, 0x0c, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x5f, 0x57, 0x4f, 0x52,
-	0x44, 0x10, 0x13, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49, 0x4e, 0x53, 0x5f,
-	0x48, 0x4f, 0x4f, 0x4b, 0x53, 0x10, 0x14, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x45, 0x46, 0x49, 0x4e,
-	0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49, 0x4e, 0x53, 0x10, 0x15,
-	0x22, 0x3c, 0x0a, 0x11, 0x4e, 0x6f, 0x74, 0x49, 0x6e, 0x4c, 0x65, 0x78, 0x43, 0x6f, 0x6e, 0x64,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x11, 0x0a, 0x0d, 0x4f, 0x54, 0x48, 0x45, 0x52, 0x5f, 0x45,
-	0x4e, 0x47, 0x4c, 0x49, 0x53, 0x48, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x52, 0x45, 0x56,
-	0x49, 0x4f, 0x55, 0x53, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x22, 0x3c,
-	0x0a, 0x08, 0x48, 0x6f, 0x6f, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x46, 0x52,
-	0x4f, 0x4e, 0x54, 0x5f, 0x48, 0x4f, 0x4f, 0x4b, 0x53, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x42,
-	0x41, 0x43, 0x4b, 0x5f, 0x48, 0x4f, 0x4f, 0x4b, 0x53, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x49,
-	0x4e, 0x4e, 0x45, 0x52, 0x5f, 0x48, 0x4f, 0x4f, 0x4b, 0x53, 0x10, 0x02, 0x22, 0x63, 0x0a, 0x0e,
+	0x65, 0x73, 0x74, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52,
+	0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x73, 0x0a, 0x11, 0x43, 0x72, 0x6f, 0x73, 0x73,
+	0x4c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x1b, 0x0a, 0x09,
+	0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x69, 0x6e, 0x4c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x12, 0x22, 0x0a, 0x0d, 0x6e, 0x6f, 0x74,
+	0x5f, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0b, 0x6e, 0x6f, 0x74, 0x49, 0x6e, 0x4c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x12, 0x1d, 0x0a,
+	0x0a, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x77, 0x6f, 0x72, 0x64, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x1a, 0xf9, 0x01, 0x0a,
+	0x08, 0x53, 0x6f, 0x72, 0x74, 0x53, 0x70, 0x65, 0x63, 0x12, 0x40, 0x0a, 0x05, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x53, 0x70, 0x65, 0x63, 0x2e, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x64,
+	0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x22,
+	0x77, 0x0a, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x52, 0x4f, 0x42,
+	0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x49, 0x46,
+	0x46, 0x49, 0x43, 0x55, 0x4c, 0x54, 0x59, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x4c, 0x41,
+	0x59, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x4f,
+	0x4d, 0x42, 0x49, 0x4e, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09,
+	0x41, 0x4c, 0x50, 0x48, 0x41, 0x47, 0x52, 0x41, 0x4d, 0x10, 0x04, 0x12, 0x0f, 0x0a, 0x0b, 0x50,
+	0x4f, 0x49, 0x4e, 0x54, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x10, 0x05, 0x12, 0x0a, 0x0a, 0x06,
+	0x52, 0x41, 0x4e, 0x44, 0x4f, 0x4d, 0x10, 0x06, 0x1a, 0xf1, 0x05, 0x0a, 0x0b, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x43, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x77, 0x6f,
+	0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3c, 0x0a,
+	0x06, 0x6d, 0x69, 0x6e, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x69, 0x6e, 0x4d, 0x61,
+	0x78, 0x48, 0x00, 0x52, 0x06, 0x6d, 0x69, 0x6e, 0x6d, 0x61, 0x78, 0x12, 0x4b, 0x0a, 0x0b, 0x73,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x27, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x4b, 0x0a, 0x0b, 0x73, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x61, 0x72, 0x72, 0x61, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e,
+	0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x41, 0x72, 0x72, 0x61, 0x79, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x61, 0x72, 0x72, 0x61, 0x79, 0x12, 0x4b, 0x0a, 0x0b, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x61,
+	0x72, 0x72, 0x61, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x77, 0x6f, 0x72,
+	0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x72,
+	0x72, 0x61, 0x79, 0x48, 0x00, 0x52, 0x0b, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x61, 0x72, 0x72,
+	0x61, 0x79, 0x12, 0x4b, 0x0a, 0x0b, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x48, 0x00, 0x52, 0x0b, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x48, 0x0a, 0x0a, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x48, 0x6f, 0x6f, 0x6b, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x48, 0x00, 0x52, 0x0a, 0x68,
+	0x6f, 0x6f, 0x6b, 0x73, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x42, 0x0a, 0x05, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x48, 0x00, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x36, 0x0a,
+	0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x50, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x48, 0x00, 0x52, 0x07, 0x70, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x53, 0x0a, 0x0c, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x6c, 0x65,
+	0x78, 0x69, 0x63, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x77, 0x6f,
+	0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x4c, 0x65,
+	0x78, 0x69, 0x63, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x72,
+	0x6f, 0x73, 0x73, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x42, 0x10, 0x0a, 0x0e, 0x63, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x22, 0xe5, 0x06, 0x0a,
+	0x09, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x4c, 0x45,
+	0x58, 0x49, 0x43, 0x4f, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x45, 0x4e, 0x47, 0x54,
+	0x48, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x52, 0x4f, 0x42, 0x41, 0x42, 0x49, 0x4c, 0x49,
+	0x54, 0x59, 0x5f, 0x52, 0x41, 0x4e, 0x47, 0x45, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x52,
+	0x4f, 0x42, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x03,
+	0x12, 0x15, 0x0a, 0x11, 0x50, 0x52, 0x4f, 0x42, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f,
+	0x4c, 0x49, 0x4d, 0x49, 0x54, 0x10, 0x04, 0x12, 0x16, 0x0a, 0x12, 0x4e, 0x55, 0x4d, 0x42, 0x45,
+	0x52, 0x5f, 0x4f, 0x46, 0x5f, 0x41, 0x4e, 0x41, 0x47, 0x52, 0x41, 0x4d, 0x53, 0x10, 0x05, 0x12,
+	0x14, 0x0a, 0x10, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x4f, 0x46, 0x5f, 0x56, 0x4f, 0x57,
+	0x45, 0x4c, 0x53, 0x10, 0x06, 0x12, 0x0c, 0x0a, 0x08, 0x48, 0x41, 0x53, 0x5f, 0x54, 0x41, 0x47,
+	0x53, 0x10, 0x07, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x4f, 0x49, 0x4e, 0x54, 0x5f, 0x56, 0x41, 0x4c,
+	0x55, 0x45, 0x10, 0x08, 0x12, 0x14, 0x0a, 0x10, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x49, 0x4e, 0x47,
+	0x5f, 0x41, 0x4e, 0x41, 0x47, 0x52, 0x41, 0x4d, 0x10, 0x09, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x4c,
+	0x50, 0x48, 0x41, 0x47, 0x52, 0x41, 0x4d, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x0a, 0x12, 0x12,
+	0x0a, 0x0e, 0x4e, 0x4f, 0x54, 0x5f, 0x49, 0x4e, 0x5f, 0x4c, 0x45, 0x58, 0x49, 0x43, 0x4f, 0x4e,
+	0x10, 0x0b, 0x12, 0x23, 0x0a, 0x1f, 0x55, 0x50, 0x4c, 0x4f, 0x41, 0x44, 0x45, 0x44, 0x5f, 0x57,
+	0x4f, 0x52, 0x44, 0x5f, 0x4f, 0x52, 0x5f, 0x41, 0x4c, 0x50, 0x48, 0x41, 0x47, 0x52, 0x41, 0x4d,
+	0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x0c, 0x12, 0x0d, 0x0a, 0x09, 0x57, 0x4f, 0x52, 0x44, 0x5f,
+	0x4c, 0x49, 0x53, 0x54, 0x10, 0x0d, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x49, 0x4e, 0x47, 0x4c, 0x45,
+	0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x5f, 0x4c, 0x45, 0x4e, 0x47, 0x54, 0x48, 0x10, 0x0e, 0x12,
+	0x12, 0x0a, 0x0e, 0x4e, 0x55, 0x4d, 0x5f, 0x54, 0x57, 0x4f, 0x5f, 0x42, 0x4c, 0x41, 0x4e, 0x4b,
+	0x53, 0x10, 0x0f, 0x12, 0x11, 0x0a, 0x0d, 0x4d, 0x41, 0x58, 0x5f, 0x53, 0x4f, 0x4c, 0x55, 0x54,
+	0x49, 0x4f, 0x4e, 0x53, 0x10, 0x10, 0x12, 0x14, 0x0a, 0x10, 0x44, 0x49, 0x46, 0x46, 0x49, 0x43,
+	0x55, 0x4c, 0x54, 0x59, 0x5f, 0x52, 0x41, 0x4e, 0x47, 0x45, 0x10, 0x11, 0x12, 0x15, 0x0a, 0x11,
+	0x50, 0x4c, 0x41, 0x59, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x52, 0x41, 0x4e, 0x47,
+	0x45, 0x10, 0x12, 0x12, 0x10, 0x0a, 0x0c, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x5f, 0x57,
+	0x4f, 0x52, 0x44, 0x10, 0x13, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49, 0x4e,
+	0x53, 0x5f, 0x48, 0x4f, 0x4f, 0x4b, 0x53, 0x10, 0x14, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x45, 0x46,
+	0x49, 0x4e, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49, 0x4e, 0x53,
+	0x10, 0x15, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x4f, 0x4e, 0x44, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x47, 0x52, 0x4f, 0x55, 0x50, 0x10, 0x16, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x4f, 0x4e, 0x54, 0x41,
+	0x49, 0x4e, 0x53, 0x5f, 0x4c, 0x45, 0x54, 0x54, 0x45, 0x52, 0x53, 0x10, 0x17, 0x12, 0x14, 0x0a,
+	0x10, 0x45, 0x58, 0x43, 0x4c, 0x55, 0x44, 0x45, 0x53, 0x5f, 0x4c, 0x45, 0x54, 0x54, 0x45, 0x52,
+	0x53, 0x10, 0x18, 0x12, 0x18, 0x0a, 0x14, 0x4e, 0x55, 0x4d, 0x5f, 0x44, 0x49, 0x53, 0x54, 0x49,
+	0x4e, 0x43, 0x54, 0x5f, 0x4c, 0x45, 0x54, 0x54, 0x45, 0x52, 0x53, 0x10, 0x19, 0x12, 0x16, 0x0a,
+	0x12, 0x4d, 0x41, 0x58, 0x5f, 0x4c, 0x45, 0x54, 0x54, 0x45, 0x52, 0x5f, 0x52, 0x45, 0x50, 0x45,
+	0x41, 0x54, 0x53, 0x10, 0x1a, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x4f, 0x52, 0x44, 0x5f, 0x50, 0x41,
+	0x54, 0x54, 0x45, 0x52, 0x4e, 0x10, 0x1b, 0x12, 0x13, 0x0a, 0x0f, 0x4e, 0x55, 0x4d, 0x5f, 0x46,
+	0x52, 0x4f, 0x4e, 0x54, 0x5f, 0x48, 0x4f, 0x4f, 0x4b, 0x53, 0x10, 0x1c, 0x12, 0x12, 0x0a, 0x0e,
+	0x4e, 0x55, 0x4d, 0x5f, 0x42, 0x41, 0x43, 0x4b, 0x5f, 0x48, 0x4f, 0x4f, 0x4b, 0x53, 0x10, 0x1d,
+	0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x55, 0x4d, 0x5f, 0x48, 0x4f, 0x4f, 0x4b, 0x53, 0x10, 0x1e, 0x12,
+	0x0c, 0x0a, 0x08, 0x48, 0x4f, 0x4f, 0x4b, 0x4c, 0x45, 0x53, 0x53, 0x10, 0x1f, 0x12, 0x18, 0x0a,
+	0x14, 0x4e, 0x55, 0x4d, 0x5f, 0x46, 0x52, 0x4f, 0x4e, 0x54, 0x5f, 0x45, 0x58, 0x54, 0x45, 0x4e,
+	0x53, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x20, 0x12, 0x17, 0x0a, 0x13, 0x4e, 0x55, 0x4d, 0x5f, 0x42,
+	0x41, 0x43, 0x4b, 0x5f, 0x45, 0x58, 0x54, 0x45, 0x4e, 0x53, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x21,
+	0x12, 0x11, 0x0a, 0x0d, 0x43, 0x52, 0x4f, 0x53, 0x53, 0x5f, 0x4c, 0x45, 0x58, 0x49, 0x43, 0x4f,
+	0x4e, 0x10, 0x22, 0x12, 0x13, 0x0a, 0x0f, 0x4c, 0x45, 0x58, 0x49, 0x43, 0x4f, 0x4e, 0x5f, 0x53,
+	0x59, 0x4d, 0x42, 0x4f, 0x4c, 0x53, 0x10, 0x23, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x44, 0x44, 0x45,
+	0x44, 0x5f, 0x49, 0x4e, 0x5f, 0x4c, 0x45, 0x58, 0x49, 0x43, 0x4f, 0x4e, 0x10, 0x24, 0x12, 0x1a,
+	0x0a, 0x16, 0x49, 0x4e, 0x5f, 0x45, 0x56, 0x45, 0x52, 0x59, 0x5f, 0x4c, 0x45, 0x58, 0x49, 0x43,
+	0x4f, 0x4e, 0x5f, 0x53, 0x49, 0x4e, 0x43, 0x45, 0x10, 0x25, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x41,
+	0x52, 0x54, 0x5f, 0x4f, 0x46, 0x5f, 0x53, 0x50, 0x45, 0x45, 0x43, 0x48, 0x10, 0x26, 0x12, 0x11,
+	0x0a, 0x0d, 0x49, 0x4e, 0x46, 0x4c, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4f, 0x46, 0x10,
+	0x27, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x5f, 0x53, 0x49, 0x4e,
+	0x43, 0x45, 0x10, 0x28, 0x22, 0x3c, 0x0a, 0x11, 0x4e, 0x6f, 0x74, 0x49, 0x6e, 0x4c, 0x65, 0x78,
+	0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x11, 0x0a, 0x0d, 0x4f, 0x54, 0x48,
+	0x45, 0x52, 0x5f, 0x45, 0x4e, 0x47, 0x4c, 0x49, 0x53, 0x48, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10,
+	0x50, 0x52, 0x45, 0x56, 0x49, 0x4f, 0x55, 0x53, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e,
+	0x10, 0x01, 0x22, 0x3c, 0x0a, 0x08, 0x48, 0x6f, 0x6f, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0f,
+	0x0a, 0x0b, 0x46, 0x52, 0x4f, 0x4e, 0x54, 0x5f, 0x48, 0x4f, 0x4f, 0x4b, 0x53, 0x10, 0x00, 0x12,
+	0x0e, 0x0a, 0x0a, 0x42, 0x41, 0x43, 0x4b, 0x5f, 0x48, 0x4f, 0x4f, 0x4b, 0x53, 0x10, 0x01, 0x12,
+	0x0f, 0x0a, 0x0b, 0x49, 0x4e, 0x4e, 0x45, 0x52, 0x5f, 0x48, 0x4f, 0x4f, 0x4b, 0x53, 0x10, 0x02,
+	0x22, 0x29, 0x0a, 0x0d, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4e, 0x44, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x52,
+	0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x4f, 0x54, 0x10, 0x02, 0x22, 0xc7, 0x01, 0x0a, 0x0e,
 	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37,
 	0x0a, 0x0a, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03,
 	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65,
 	0x72, 0x2e, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x52, 0x0a, 0x61, 0x6c, 0x70,
 	0x68, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x65, 0x78, 0x69, 0x63,
 	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f,
-	0x6e, 0x22, 0xbc, 0x01, 0x0a, 0x0e, 0x41, 0x6e, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x12, 0x18,
-	0x0a, 0x07, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x12, 0x35, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x41, 0x6e, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x06, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x22, 0x27, 0x0a, 0x04, 0x4d, 0x6f, 0x64, 0x65, 0x12,
-	0x09, 0x0a, 0x05, 0x45, 0x58, 0x41, 0x43, 0x54, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x55,
-	0x49, 0x4c, 0x44, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x55, 0x50, 0x45, 0x52, 0x10, 0x02,
-	0x22, 0x58, 0x0a, 0x0f, 0x41, 0x6e, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65,
-	0x72, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1b, 0x0a,
-	0x09, 0x6e, 0x75, 0x6d, 0x5f, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x08, 0x6e, 0x75, 0x6d, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x22, 0xcd, 0x01, 0x0a, 0x1b, 0x42,
-	0x6c, 0x61, 0x6e, 0x6b, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x65,
-	0x78, 0x69, 0x63, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x78,
-	0x69, 0x63, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x75, 0x6d, 0x5f, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6e, 0x75, 0x6d,
-	0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x61, 0x78,
-	0x5f, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x0c, 0x6d, 0x61, 0x78, 0x53, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x29,
-	0x0a, 0x11, 0x6e, 0x75, 0x6d, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x32, 0x5f, 0x62, 0x6c, 0x61,
-	0x6e, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6e, 0x75, 0x6d, 0x57, 0x69,
-	0x74, 0x68, 0x32, 0x42, 0x6c, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x6f, 0x72,
-	0x64, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
-	0x77, 0x6f, 0x72, 0x64, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0xf7, 0x01, 0x0a, 0x1b, 0x42,
-	0x75, 0x69, 0x6c, 0x64, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x65,
-	0x78, 0x69, 0x63, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x78,
-	0x69, 0x63, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x6f, 0x6c, 0x75,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x69, 0x6e,
-	0x53, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x61, 0x78,
-	0x5f, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x0c, 0x6d, 0x61, 0x78, 0x53, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d,
-	0x0a, 0x0a, 0x6d, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x1d, 0x0a,
-	0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x36, 0x0a, 0x17,
-	0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x5f, 0x73,
-	0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x72,
-	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x53, 0x6f, 0x6c, 0x75,
-	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x60, 0x0a, 0x11, 0x57, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x65, 0x78,
+	0x6e, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74,
+	0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61,
+	0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xcd, 0x01, 0x0a, 0x14, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33,
+	0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x06, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x63, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x61, 0x63, 0x65, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x70,
+	0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x15, 0x70, 0x72,
+	0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x64, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74,
+	0x79, 0x5f, 0x62, 0x61, 0x6e, 0x64, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x12, 0x64, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x42, 0x61, 0x6e,
+	0x64, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x8b, 0x01, 0x0a, 0x0b, 0x46, 0x61, 0x63, 0x65, 0x74, 0x42,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d,
+	0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a,
+	0x03, 0x6d, 0x61, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12,
+	0x25, 0x0a, 0x0e, 0x6e, 0x75, 0x6d, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x67, 0x72, 0x61, 0x6d,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6e, 0x75, 0x6d, 0x41, 0x6c, 0x70, 0x68,
+	0x61, 0x67, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x75, 0x6d, 0x5f, 0x77, 0x6f,
+	0x72, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x57, 0x6f,
+	0x72, 0x64, 0x73, 0x22, 0x50, 0x0a, 0x05, 0x46, 0x61, 0x63, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x33, 0x0a, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72,
+	0x2e, 0x46, 0x61, 0x63, 0x65, 0x74, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x07, 0x62, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x22, 0xa2, 0x01, 0x0a, 0x15, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x6e, 0x75, 0x6d,
+	0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0d, 0x6e, 0x75, 0x6d, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x73,
+	0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x75, 0x6d, 0x5f, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x2b, 0x0a,
+	0x06, 0x66, 0x61, 0x63, 0x65, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x46, 0x61, 0x63,
+	0x65, 0x74, 0x52, 0x06, 0x66, 0x61, 0x63, 0x65, 0x74, 0x73, 0x22, 0xa9, 0x01, 0x0a, 0x14, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x42, 0x79, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x5f, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4c, 0x65, 0x78, 0x69, 0x63,
+	0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70,
+	0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xbc, 0x01, 0x0a, 0x0e, 0x41, 0x6e, 0x61, 0x67, 0x72,
+	0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x65, 0x78,
 	0x69, 0x63, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x78, 0x69,
-	0x63, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x6c, 0x6f, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x67, 0x6c, 0x6f, 0x62, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x6c, 0x69,
-	0x65, 0x73, 0x5f, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x70, 0x70,
-	0x6c, 0x69, 0x65, 0x73, 0x54, 0x6f, 0x22, 0x3d, 0x0a, 0x0d, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x65, 0x78, 0x69, 0x63,
-	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f,
-	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x3e, 0x0a, 0x12, 0x57, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x77,
-	0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x77, 0x6f, 0x72,
-	0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x05,
-	0x77, 0x6f, 0x72, 0x64, 0x73, 0x32, 0xa7, 0x01, 0x0a, 0x10, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69,
-	0x6f, 0x6e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x12, 0x48, 0x0a, 0x06, 0x53, 0x65,
-	0x61, 0x72, 0x63, 0x68, 0x12, 0x1b, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63,
-	0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1c, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72,
-	0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x03, 0x90, 0x02, 0x01, 0x12, 0x49, 0x0a, 0x06, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x12, 0x1c,
+	0x63, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x12, 0x35, 0x0a,
+	0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x77, 0x6f,
+	0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x41, 0x6e, 0x61, 0x67, 0x72,
+	0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x22, 0x27, 0x0a, 0x04,
+	0x4d, 0x6f, 0x64, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x58, 0x41, 0x43, 0x54, 0x10, 0x00, 0x12,
+	0x09, 0x0a, 0x05, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x55,
+	0x50, 0x45, 0x52, 0x10, 0x02, 0x22, 0x58, 0x0a, 0x0f, 0x41, 0x6e, 0x61, 0x67, 0x72, 0x61, 0x6d,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x05, 0x77, 0x6f, 0x72,
+	0x64, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x75, 0x6d, 0x5f, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x22,
+	0xcd, 0x01, 0x0a, 0x1b, 0x42, 0x6c, 0x61, 0x6e, 0x6b, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e,
+	0x67, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x75, 0x6d,
+	0x5f, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0c, 0x6e, 0x75, 0x6d, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x53, 0x6f, 0x6c, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x29, 0x0a, 0x11, 0x6e, 0x75, 0x6d, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f,
+	0x32, 0x5f, 0x62, 0x6c, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e,
+	0x6e, 0x75, 0x6d, 0x57, 0x69, 0x74, 0x68, 0x32, 0x42, 0x6c, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x1f,
+	0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x64, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22,
+	0xf7, 0x01, 0x0a, 0x1b, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e,
+	0x67, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x69, 0x6e,
+	0x5f, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0c, 0x6d, 0x69, 0x6e, 0x53, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x53, 0x6f, 0x6c, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67,
+	0x74, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x4c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x12, 0x36, 0x0a, 0x17, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x5f, 0x6c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x5f, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x15, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x4c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x53, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x91, 0x01, 0x0a, 0x0b, 0x53, 0x74,
+	0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x65, 0x78,
+	0x69, 0x63, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x78, 0x69,
+	0x63, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x75, 0x6d,
+	0x5f, 0x61, 0x64, 0x64, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x75,
+	0x6d, 0x41, 0x64, 0x64, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x6c,
+	0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x65,
+	0x6d, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x52, 0x0a,
+	0x0c, 0x53, 0x74, 0x65, 0x6d, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a,
+	0x07, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x12, 0x28, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x64, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x64,
+	0x73, 0x22, 0x73, 0x0a, 0x04, 0x53, 0x74, 0x65, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x65,
+	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x1d, 0x0a,
+	0x0a, 0x6e, 0x75, 0x6d, 0x5f, 0x62, 0x69, 0x6e, 0x67, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x09, 0x6e, 0x75, 0x6d, 0x42, 0x69, 0x6e, 0x67, 0x6f, 0x73, 0x12, 0x38, 0x0a, 0x09,
+	0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53,
+	0x74, 0x65, 0x6d, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x61, 0x64, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x38, 0x0a, 0x0c, 0x53, 0x74, 0x65, 0x6d, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x73, 0x74, 0x65, 0x6d, 0x73,
+	0x22, 0x13, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x72, 0x0a, 0x16, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x44,
+	0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6c, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x63,
+	0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x6f, 0x77, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x76, 0x6f, 0x77, 0x65, 0x6c, 0x22, 0x46, 0x0a, 0x0f, 0x57, 0x6f, 0x72,
+	0x64, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x75, 0x6d, 0x5f, 0x77, 0x6f, 0x72, 0x64,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x57, 0x6f, 0x72, 0x64,
+	0x73, 0x22, 0xac, 0x03, 0x0a, 0x12, 0x4c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x44, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x76, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x12,
+	0x1d, 0x0a, 0x0a, 0x64, 0x62, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x64, 0x62, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3e,
+	0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x2f,
+	0x0a, 0x13, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6c, 0x65, 0x74,
+	0x74, 0x65, 0x72, 0x44, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x3a, 0x0a, 0x05, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24,
+	0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x4c, 0x65,
+	0x74, 0x74, 0x65, 0x72, 0x44, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e,
+	0x54, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x68,
+	0x61, 0x73, 0x5f, 0x64, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0d, 0x68, 0x61, 0x73, 0x44, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c,
+	0x74, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x68, 0x61, 0x73, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x68, 0x61, 0x73,
+	0x50, 0x6c, 0x61, 0x79, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x70,
+	0x72, 0x69, 0x6f, 0x72, 0x5f, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x4c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e,
+	0x22, 0x4e, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x06, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x61,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x4c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x44, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x61,
+	0x22, 0xb0, 0x01, 0x0a, 0x0c, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x6d,
+	0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x6d, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61,
+	0x78, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
+	0x6d, 0x61, 0x78, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x48, 0x0a, 0x0d, 0x6c, 0x65, 0x74,
+	0x74, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e,
+	0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x74,
+	0x72, 0x61, 0x69, 0x6e, 0x74, 0x52, 0x0c, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x22, 0x55, 0x0a, 0x15, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c,
+	0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0xfa, 0x01, 0x0a, 0x11, 0x57,
+	0x6f, 0x72, 0x64, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x6c,
+	0x6f, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x67, 0x6c, 0x6f, 0x62, 0x12, 0x1d,
+	0x0a, 0x0a, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x5f, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x54, 0x6f, 0x12, 0x34, 0x0a,
+	0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x50, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x68, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74,
+	0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x68, 0x69,
+	0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x23, 0x0a, 0x0d,
+	0x68, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x68, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x45, 0x6e,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x3d, 0x0a, 0x0d, 0x44, 0x65, 0x66, 0x69, 0x6e,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x65, 0x78, 0x69,
+	0x63, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x78, 0x69, 0x63,
+	0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x3e, 0x0a, 0x12, 0x57, 0x6f, 0x72, 0x64, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x05,
+	0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x77, 0x6f,
+	0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52,
+	0x05, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x22, 0xce, 0x01, 0x0a, 0x14, 0x52, 0x65, 0x6c, 0x61, 0x74,
+	0x65, 0x64, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x26, 0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72,
+	0x64, 0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x28, 0x0a, 0x05, 0x72, 0x6f, 0x6f, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x05, 0x72, 0x6f, 0x6f, 0x74,
+	0x73, 0x12, 0x34, 0x0a, 0x0b, 0x69, 0x6e, 0x66, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x0b, 0x69, 0x6e, 0x66, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2e, 0x0a, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61,
+	0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x64,
+	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x08, 0x76,
+	0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x73, 0x22, 0x8b, 0x01, 0x0a, 0x11, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x64, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x4c, 0x65, 0x78, 0x69, 0x63, 0x61,
+	0x12, 0x23, 0x0a, 0x0d, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x69, 0x74, 0x79, 0x5f, 0x6f, 0x6e, 0x6c,
+	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x69, 0x74,
+	0x79, 0x4f, 0x6e, 0x6c, 0x79, 0x22, 0x5f, 0x0a, 0x0b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64,
+	0x57, 0x6f, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x26,
+	0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x77,
+	0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x64,
+	0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0x71, 0x0a, 0x0e, 0x4c, 0x65, 0x78, 0x69, 0x63, 0x6f,
+	0x6e, 0x56, 0x65, 0x72, 0x64, 0x69, 0x63, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x65, 0x78, 0x69,
+	0x63, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x78, 0x69, 0x63,
+	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x2f, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x64,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x57, 0x6f,
+	0x72, 0x64, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x64, 0x0a, 0x12, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x64, 0x69, 0x63, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x4c, 0x65, 0x78, 0x69, 0x63, 0x6f, 0x6e, 0x56, 0x65,
+	0x72, 0x64, 0x69, 0x63, 0x74, 0x52, 0x08, 0x76, 0x65, 0x72, 0x64, 0x69, 0x63, 0x74, 0x73, 0x32,
+	0x86, 0x04, 0x0a, 0x10, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x65, 0x72, 0x12, 0x48, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x1b,
 	0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65,
-	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x1c, 0x2e, 0x77,
+	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x77, 0x6f,
+	0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x12, 0x50,
+	0x0a, 0x0c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1b,
+	0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x77, 0x6f,
+	0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x30, 0x01,
+	0x12, 0x49, 0x0a, 0x06, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x12, 0x1c, 0x2e, 0x77, 0x6f, 0x72,
+	0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x1c, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x12, 0x5d, 0x0a, 0x0d, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x22, 0x2e, 0x77,
 	0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x32,
-	0xa7, 0x02, 0x0a, 0x0a, 0x41, 0x6e, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x6d, 0x65, 0x72, 0x12, 0x4b,
-	0x0a, 0x07, 0x41, 0x6e, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x1c, 0x2e, 0x77, 0x6f, 0x72, 0x64,
-	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x41, 0x6e, 0x61, 0x67, 0x72, 0x61, 0x6d,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65,
-	0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x41, 0x6e, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x52, 0x65,
+	0x63, 0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x23, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x12, 0x56, 0x0a, 0x0d, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x42, 0x79, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x22, 0x2e, 0x77, 0x6f,
+	0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x42, 0x79, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90,
+	0x02, 0x01, 0x12, 0x54, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x65, 0x78, 0x69, 0x63, 0x61,
+	0x12, 0x1f, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x4c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x20, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x32, 0xf1, 0x02, 0x0a, 0x0a, 0x41, 0x6e, 0x61,
+	0x67, 0x72, 0x61, 0x6d, 0x6d, 0x65, 0x72, 0x12, 0x4b, 0x0a, 0x07, 0x41, 0x6e, 0x61, 0x67, 0x72,
+	0x61, 0x6d, 0x12, 0x1c, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65,
+	0x72, 0x2e, 0x41, 0x6e, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1d, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e,
+	0x41, 0x6e, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x03, 0x90, 0x02, 0x01, 0x12, 0x65, 0x0a, 0x15, 0x42, 0x6c, 0x61, 0x6e, 0x6b, 0x43, 0x68, 0x61,
+	0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x29, 0x2e,
+	0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x61,
+	0x6e, 0x6b, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65,
 	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x12, 0x65, 0x0a, 0x15, 0x42,
-	0x6c, 0x61, 0x6e, 0x6b, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x43, 0x72, 0x65,
+	0x75, 0x69, 0x6c, 0x64, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x43, 0x72, 0x65,
 	0x61, 0x74, 0x6f, 0x72, 0x12, 0x29, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63,
-	0x68, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x61, 0x6e, 0x6b, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e,
+	0x68, 0x65, 0x72, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e,
 	0x67, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
 	0x1c, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53,
 	0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90,
-	0x02, 0x01, 0x12, 0x65, 0x0a, 0x15, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x68, 0x61, 0x6c, 0x6c,
-	0x65, 0x6e, 0x67, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x29, 0x2e, 0x77, 0x6f,
-	0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64,
-	0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x32, 0xbe, 0x01, 0x0a, 0x0c, 0x57, 0x6f,
-	0x72, 0x64, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x12, 0x58, 0x0a, 0x12, 0x47, 0x65,
-	0x74, 0x57, 0x6f, 0x72, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x02, 0x01, 0x12, 0x48, 0x0a, 0x0a, 0x53, 0x74, 0x65, 0x6d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x12, 0x19, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e,
+	0x53, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x77, 0x6f,
+	0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x65, 0x6d, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x32, 0xed, 0x02, 0x0a,
+	0x0c, 0x57, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x12, 0x58, 0x0a,
+	0x12, 0x47, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x65, 0x72, 0x2e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x20, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e,
+	0x57, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x12, 0x54, 0x0a, 0x0a, 0x57, 0x6f, 0x72, 0x64, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x1f, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x12, 0x57, 0x0a,
+	0x0f, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x57, 0x6f, 0x72, 0x64, 0x73,
 	0x12, 0x1b, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e,
-	0x44, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e,
-	0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72,
-	0x64, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x03, 0x90, 0x02, 0x01, 0x12, 0x54, 0x0a, 0x0a, 0x57, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x12, 0x1f, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65,
-	0x72, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x42, 0xaa, 0x01, 0x0a, 0x10, 0x63,
-	0x6f, 0x6d, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x42,
-	0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01,
-	0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x6f, 0x6d,
-	0x69, 0x6e, 0x6f, 0x31, 0x34, 0x2f, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x64, 0x62, 0x5f, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x77, 0x6f, 0x72,
-	0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0xa2, 0x02, 0x03, 0x57, 0x58, 0x58, 0xaa,
-	0x02, 0x0c, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0xca, 0x02,
-	0x0c, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0xe2, 0x02, 0x18,
-	0x57, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x5c, 0x47, 0x50, 0x42,
-	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0c, 0x57, 0x6f, 0x72, 0x64, 0x73,
-	0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x44, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e,
+	0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x6c,
+	0x61, 0x74, 0x65, 0x64, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x12, 0x54, 0x0a, 0x0a, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x57,
+	0x6f, 0x72, 0x64, 0x73, 0x12, 0x1f, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x42, 0xaa, 0x01, 0x0a,
+	0x10, 0x63, 0x6f, 0x6d, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65,
+	0x72, 0x42, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x50, 0x01, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64,
+	0x6f, 0x6d, 0x69, 0x6e, 0x6f, 0x31, 0x34, 0x2f, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x64, 0x62, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x77,
+	0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0xa2, 0x02, 0x03, 0x57, 0x58,
+	0x58, 0xaa, 0x02, 0x0c, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72,
+	0xca, 0x02, 0x0c, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0xe2,
+	0x02, 0x18, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x5c, 0x47,
+	0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0c, 0x57, 0x6f, 0x72,
+	0x64, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -1788,66 +4262,133 @@ func file_rpc_wordsearcher_searcher_proto_rawDescGZIP() []byte {
 	return file_rpc_wordsearcher_searcher_proto_rawDescData
 }
 
-var file_rpc_wordsearcher_searcher_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_rpc_wordsearcher_searcher_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_rpc_wordsearcher_searcher_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_rpc_wordsearcher_searcher_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_rpc_wordsearcher_searcher_proto_goTypes = []interface{}{
-	(SearchRequest_Condition)(0),         // 0: wordsearcher.SearchRequest.Condition
-	(SearchRequest_NotInLexCondition)(0), // 1: wordsearcher.SearchRequest.NotInLexCondition
-	(SearchRequest_HookType)(0),          // 2: wordsearcher.SearchRequest.HookType
-	(AnagramRequest_Mode)(0),             // 3: wordsearcher.AnagramRequest.Mode
-	(*Alphagram)(nil),                    // 4: wordsearcher.Alphagram
-	(*Word)(nil),                         // 5: wordsearcher.Word
-	(*SearchRequest)(nil),                // 6: wordsearcher.SearchRequest
-	(*SearchResponse)(nil),               // 7: wordsearcher.SearchResponse
-	(*AnagramRequest)(nil),               // 8: wordsearcher.AnagramRequest
-	(*AnagramResponse)(nil),              // 9: wordsearcher.AnagramResponse
-	(*BlankChallengeCreateRequest)(nil),  // 10: wordsearcher.BlankChallengeCreateRequest
-	(*BuildChallengeCreateRequest)(nil),  // 11: wordsearcher.BuildChallengeCreateRequest
-	(*WordSearchRequest)(nil),            // 12: wordsearcher.WordSearchRequest
-	(*DefineRequest)(nil),                // 13: wordsearcher.DefineRequest
-	(*WordSearchResponse)(nil),           // 14: wordsearcher.WordSearchResponse
-	(*SearchRequest_MinMax)(nil),         // 15: wordsearcher.SearchRequest.MinMax
-	(*SearchRequest_StringValue)(nil),    // 16: wordsearcher.SearchRequest.StringValue
-	(*SearchRequest_StringArray)(nil),    // 17: wordsearcher.SearchRequest.StringArray
-	(*SearchRequest_NumberArray)(nil),    // 18: wordsearcher.SearchRequest.NumberArray
-	(*SearchRequest_NumberValue)(nil),    // 19: wordsearcher.SearchRequest.NumberValue
-	(*SearchRequest_HooksParam)(nil),     // 20: wordsearcher.SearchRequest.HooksParam
-	(*SearchRequest_SearchParam)(nil),    // 21: wordsearcher.SearchRequest.SearchParam
+	(SearchRequest_Condition)(0),            // 0: wordsearcher.SearchRequest.Condition
+	(SearchRequest_NotInLexCondition)(0),    // 1: wordsearcher.SearchRequest.NotInLexCondition
+	(SearchRequest_HookType)(0),             // 2: wordsearcher.SearchRequest.HookType
+	(SearchRequest_GroupOperator)(0),        // 3: wordsearcher.SearchRequest.GroupOperator
+	(SearchRequest_SortSpec_Field)(0),       // 4: wordsearcher.SearchRequest.SortSpec.Field
+	(AnagramRequest_Mode)(0),                // 5: wordsearcher.AnagramRequest.Mode
+	(*Alphagram)(nil),                       // 6: wordsearcher.Alphagram
+	(*Word)(nil),                            // 7: wordsearcher.Word
+	(*Definition)(nil),                      // 8: wordsearcher.Definition
+	(*SearchRequest)(nil),                   // 9: wordsearcher.SearchRequest
+	(*SearchResponse)(nil),                  // 10: wordsearcher.SearchResponse
+	(*SearchSummaryRequest)(nil),            // 11: wordsearcher.SearchSummaryRequest
+	(*FacetBucket)(nil),                     // 12: wordsearcher.FacetBucket
+	(*Facet)(nil),                           // 13: wordsearcher.Facet
+	(*SearchSummaryResponse)(nil),           // 14: wordsearcher.SearchSummaryResponse
+	(*SearchByQueryRequest)(nil),            // 15: wordsearcher.SearchByQueryRequest
+	(*AnagramRequest)(nil),                  // 16: wordsearcher.AnagramRequest
+	(*AnagramResponse)(nil),                 // 17: wordsearcher.AnagramResponse
+	(*BlankChallengeCreateRequest)(nil),     // 18: wordsearcher.BlankChallengeCreateRequest
+	(*BuildChallengeCreateRequest)(nil),     // 19: wordsearcher.BuildChallengeCreateRequest
+	(*StemRequest)(nil),                     // 20: wordsearcher.StemRequest
+	(*StemAddition)(nil),                    // 21: wordsearcher.StemAddition
+	(*Stem)(nil),                            // 22: wordsearcher.Stem
+	(*StemResponse)(nil),                    // 23: wordsearcher.StemResponse
+	(*ListLexicaRequest)(nil),               // 24: wordsearcher.ListLexicaRequest
+	(*LetterDistributionTile)(nil),          // 25: wordsearcher.LetterDistributionTile
+	(*WordLengthCount)(nil),                 // 26: wordsearcher.WordLengthCount
+	(*LexiconDescription)(nil),              // 27: wordsearcher.LexiconDescription
+	(*ListLexicaResponse)(nil),              // 28: wordsearcher.ListLexicaResponse
+	(*PatternParam)(nil),                    // 29: wordsearcher.PatternParam
+	(*LetterCountConstraint)(nil),           // 30: wordsearcher.LetterCountConstraint
+	(*WordSearchRequest)(nil),               // 31: wordsearcher.WordSearchRequest
+	(*DefineRequest)(nil),                   // 32: wordsearcher.DefineRequest
+	(*WordSearchResponse)(nil),              // 33: wordsearcher.WordSearchResponse
+	(*RelatedWordsResponse)(nil),            // 34: wordsearcher.RelatedWordsResponse
+	(*CheckWordsRequest)(nil),               // 35: wordsearcher.CheckWordsRequest
+	(*CheckedWord)(nil),                     // 36: wordsearcher.CheckedWord
+	(*LexiconVerdict)(nil),                  // 37: wordsearcher.LexiconVerdict
+	(*CheckWordsResponse)(nil),              // 38: wordsearcher.CheckWordsResponse
+	(*SearchRequest_MinMax)(nil),            // 39: wordsearcher.SearchRequest.MinMax
+	(*SearchRequest_StringValue)(nil),       // 40: wordsearcher.SearchRequest.StringValue
+	(*SearchRequest_StringArray)(nil),       // 41: wordsearcher.SearchRequest.StringArray
+	(*SearchRequest_NumberArray)(nil),       // 42: wordsearcher.SearchRequest.NumberArray
+	(*SearchRequest_NumberValue)(nil),       // 43: wordsearcher.SearchRequest.NumberValue
+	(*SearchRequest_HooksParam)(nil),        // 44: wordsearcher.SearchRequest.HooksParam
+	(*SearchRequest_ConditionGroup)(nil),    // 45: wordsearcher.SearchRequest.ConditionGroup
+	(*SearchRequest_CrossLexiconParam)(nil), // 46: wordsearcher.SearchRequest.CrossLexiconParam
+	(*SearchRequest_SortSpec)(nil),          // 47: wordsearcher.SearchRequest.SortSpec
+	(*SearchRequest_SearchParam)(nil),       // 48: wordsearcher.SearchRequest.SearchParam
 }
 var file_rpc_wordsearcher_searcher_proto_depIdxs = []int32{
-	5,  // 0: wordsearcher.Alphagram.words:type_name -> wordsearcher.Word
-	21, // 1: wordsearcher.SearchRequest.searchparams:type_name -> wordsearcher.SearchRequest.SearchParam
-	4,  // 2: wordsearcher.SearchResponse.alphagrams:type_name -> wordsearcher.Alphagram
-	3,  // 3: wordsearcher.AnagramRequest.mode:type_name -> wordsearcher.AnagramRequest.Mode
-	5,  // 4: wordsearcher.AnagramResponse.words:type_name -> wordsearcher.Word
-	5,  // 5: wordsearcher.WordSearchResponse.words:type_name -> wordsearcher.Word
-	2,  // 6: wordsearcher.SearchRequest.HooksParam.hook_type:type_name -> wordsearcher.SearchRequest.HookType
-	0,  // 7: wordsearcher.SearchRequest.SearchParam.condition:type_name -> wordsearcher.SearchRequest.Condition
-	15, // 8: wordsearcher.SearchRequest.SearchParam.minmax:type_name -> wordsearcher.SearchRequest.MinMax
-	16, // 9: wordsearcher.SearchRequest.SearchParam.stringvalue:type_name -> wordsearcher.SearchRequest.StringValue
-	17, // 10: wordsearcher.SearchRequest.SearchParam.stringarray:type_name -> wordsearcher.SearchRequest.StringArray
-	18, // 11: wordsearcher.SearchRequest.SearchParam.numberarray:type_name -> wordsearcher.SearchRequest.NumberArray
-	19, // 12: wordsearcher.SearchRequest.SearchParam.numbervalue:type_name -> wordsearcher.SearchRequest.NumberValue
-	20, // 13: wordsearcher.SearchRequest.SearchParam.hooksparam:type_name -> wordsearcher.SearchRequest.HooksParam
-	6,  // 14: wordsearcher.QuestionSearcher.Search:input_type -> wordsearcher.SearchRequest
-	7,  // 15: wordsearcher.QuestionSearcher.Expand:input_type -> wordsearcher.SearchResponse
-	8,  // 16: wordsearcher.Anagrammer.Anagram:input_type -> wordsearcher.AnagramRequest
-	10, // 17: wordsearcher.Anagrammer.BlankChallengeCreator:input_type -> wordsearcher.BlankChallengeCreateRequest
-	11, // 18: wordsearcher.Anagrammer.BuildChallengeCreator:input_type -> wordsearcher.BuildChallengeCreateRequest
-	13, // 19: wordsearcher.WordSearcher.GetWordInformation:input_type -> wordsearcher.DefineRequest
-	12, // 20: wordsearcher.WordSearcher.WordSearch:input_type -> wordsearcher.WordSearchRequest
-	7,  // 21: wordsearcher.QuestionSearcher.Search:output_type -> wordsearcher.SearchResponse
-	7,  // 22: wordsearcher.QuestionSearcher.Expand:output_type -> wordsearcher.SearchResponse
-	9,  // 23: wordsearcher.Anagrammer.Anagram:output_type -> wordsearcher.AnagramResponse
-	7,  // 24: wordsearcher.Anagrammer.BlankChallengeCreator:output_type -> wordsearcher.SearchResponse
-	7,  // 25: wordsearcher.Anagrammer.BuildChallengeCreator:output_type -> wordsearcher.SearchResponse
-	14, // 26: wordsearcher.WordSearcher.GetWordInformation:output_type -> wordsearcher.WordSearchResponse
-	14, // 27: wordsearcher.WordSearcher.WordSearch:output_type -> wordsearcher.WordSearchResponse
-	21, // [21:28] is the sub-list for method output_type
-	14, // [14:21] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	7,  // 0: wordsearcher.Alphagram.words:type_name -> wordsearcher.Word
+	8,  // 1: wordsearcher.Word.definitions:type_name -> wordsearcher.Definition
+	48, // 2: wordsearcher.SearchRequest.searchparams:type_name -> wordsearcher.SearchRequest.SearchParam
+	47, // 3: wordsearcher.SearchRequest.sort:type_name -> wordsearcher.SearchRequest.SortSpec
+	6,  // 4: wordsearcher.SearchResponse.alphagrams:type_name -> wordsearcher.Alphagram
+	9,  // 5: wordsearcher.SearchSummaryRequest.search:type_name -> wordsearcher.SearchRequest
+	12, // 6: wordsearcher.Facet.buckets:type_name -> wordsearcher.FacetBucket
+	13, // 7: wordsearcher.SearchSummaryResponse.facets:type_name -> wordsearcher.Facet
+	5,  // 8: wordsearcher.AnagramRequest.mode:type_name -> wordsearcher.AnagramRequest.Mode
+	7,  // 9: wordsearcher.AnagramResponse.words:type_name -> wordsearcher.Word
+	7,  // 10: wordsearcher.StemAddition.words:type_name -> wordsearcher.Word
+	21, // 11: wordsearcher.Stem.additions:type_name -> wordsearcher.StemAddition
+	22, // 12: wordsearcher.StemResponse.stems:type_name -> wordsearcher.Stem
+	26, // 13: wordsearcher.LexiconDescription.word_counts:type_name -> wordsearcher.WordLengthCount
+	25, // 14: wordsearcher.LexiconDescription.tiles:type_name -> wordsearcher.LetterDistributionTile
+	27, // 15: wordsearcher.ListLexicaResponse.lexica:type_name -> wordsearcher.LexiconDescription
+	30, // 16: wordsearcher.PatternParam.letter_counts:type_name -> wordsearcher.LetterCountConstraint
+	29, // 17: wordsearcher.WordSearchRequest.pattern:type_name -> wordsearcher.PatternParam
+	7,  // 18: wordsearcher.WordSearchResponse.words:type_name -> wordsearcher.Word
+	7,  // 19: wordsearcher.RelatedWordsResponse.word:type_name -> wordsearcher.Word
+	7,  // 20: wordsearcher.RelatedWordsResponse.roots:type_name -> wordsearcher.Word
+	7,  // 21: wordsearcher.RelatedWordsResponse.inflections:type_name -> wordsearcher.Word
+	7,  // 22: wordsearcher.RelatedWordsResponse.variants:type_name -> wordsearcher.Word
+	7,  // 23: wordsearcher.CheckedWord.info:type_name -> wordsearcher.Word
+	36, // 24: wordsearcher.LexiconVerdict.words:type_name -> wordsearcher.CheckedWord
+	37, // 25: wordsearcher.CheckWordsResponse.verdicts:type_name -> wordsearcher.LexiconVerdict
+	2,  // 26: wordsearcher.SearchRequest.HooksParam.hook_type:type_name -> wordsearcher.SearchRequest.HookType
+	3,  // 27: wordsearcher.SearchRequest.ConditionGroup.operator:type_name -> wordsearcher.SearchRequest.GroupOperator
+	48, // 28: wordsearcher.SearchRequest.ConditionGroup.params:type_name -> wordsearcher.SearchRequest.SearchParam
+	4,  // 29: wordsearcher.SearchRequest.SortSpec.field:type_name -> wordsearcher.SearchRequest.SortSpec.Field
+	0,  // 30: wordsearcher.SearchRequest.SearchParam.condition:type_name -> wordsearcher.SearchRequest.Condition
+	39, // 31: wordsearcher.SearchRequest.SearchParam.minmax:type_name -> wordsearcher.SearchRequest.MinMax
+	40, // 32: wordsearcher.SearchRequest.SearchParam.stringvalue:type_name -> wordsearcher.SearchRequest.StringValue
+	41, // 33: wordsearcher.SearchRequest.SearchParam.stringarray:type_name -> wordsearcher.SearchRequest.StringArray
+	42, // 34: wordsearcher.SearchRequest.SearchParam.numberarray:type_name -> wordsearcher.SearchRequest.NumberArray
+	43, // 35: wordsearcher.SearchRequest.SearchParam.numbervalue:type_name -> wordsearcher.SearchRequest.NumberValue
+	44, // 36: wordsearcher.SearchRequest.SearchParam.hooksparam:type_name -> wordsearcher.SearchRequest.HooksParam
+	45, // 37: wordsearcher.SearchRequest.SearchParam.group:type_name -> wordsearcher.SearchRequest.ConditionGroup
+	29, // 38: wordsearcher.SearchRequest.SearchParam.pattern:type_name -> wordsearcher.PatternParam
+	46, // 39: wordsearcher.SearchRequest.SearchParam.crosslexicon:type_name -> wordsearcher.SearchRequest.CrossLexiconParam
+	9,  // 40: wordsearcher.QuestionSearcher.Search:input_type -> wordsearcher.SearchRequest
+	9,  // 41: wordsearcher.QuestionSearcher.SearchStream:input_type -> wordsearcher.SearchRequest
+	10, // 42: wordsearcher.QuestionSearcher.Expand:input_type -> wordsearcher.SearchResponse
+	11, // 43: wordsearcher.QuestionSearcher.SearchSummary:input_type -> wordsearcher.SearchSummaryRequest
+	15, // 44: wordsearcher.QuestionSearcher.SearchByQuery:input_type -> wordsearcher.SearchByQueryRequest
+	24, // 45: wordsearcher.QuestionSearcher.ListLexica:input_type -> wordsearcher.ListLexicaRequest
+	16, // 46: wordsearcher.Anagrammer.Anagram:input_type -> wordsearcher.AnagramRequest
+	18, // 47: wordsearcher.Anagrammer.BlankChallengeCreator:input_type -> wordsearcher.BlankChallengeCreateRequest
+	19, // 48: wordsearcher.Anagrammer.BuildChallengeCreator:input_type -> wordsearcher.BuildChallengeCreateRequest
+	20, // 49: wordsearcher.Anagrammer.StemSearch:input_type -> wordsearcher.StemRequest
+	32, // 50: wordsearcher.WordSearcher.GetWordInformation:input_type -> wordsearcher.DefineRequest
+	31, // 51: wordsearcher.WordSearcher.WordSearch:input_type -> wordsearcher.WordSearchRequest
+	32, // 52: wordsearcher.WordSearcher.GetRelatedWords:input_type -> wordsearcher.DefineRequest
+	35, // 53: wordsearcher.WordSearcher.CheckWords:input_type -> wordsearcher.CheckWordsRequest
+	10, // 54: wordsearcher.QuestionSearcher.Search:output_type -> wordsearcher.SearchResponse
+	10, // 55: wordsearcher.QuestionSearcher.SearchStream:output_type -> wordsearcher.SearchResponse
+	10, // 56: wordsearcher.QuestionSearcher.Expand:output_type -> wordsearcher.SearchResponse
+	14, // 57: wordsearcher.QuestionSearcher.SearchSummary:output_type -> wordsearcher.SearchSummaryResponse
+	10, // 58: wordsearcher.QuestionSearcher.SearchByQuery:output_type -> wordsearcher.SearchResponse
+	28, // 59: wordsearcher.QuestionSearcher.ListLexica:output_type -> wordsearcher.ListLexicaResponse
+	17, // 60: wordsearcher.Anagrammer.Anagram:output_type -> wordsearcher.AnagramResponse
+	10, // 61: wordsearcher.Anagrammer.BlankChallengeCreator:output_type -> wordsearcher.SearchResponse
+	10, // 62: wordsearcher.Anagrammer.BuildChallengeCreator:output_type -> wordsearcher.SearchResponse
+	23, // 63: wordsearcher.Anagrammer.StemSearch:output_type -> wordsearcher.StemResponse
+	33, // 64: wordsearcher.WordSearcher.GetWordInformation:output_type -> wordsearcher.WordSearchResponse
+	33, // 65: wordsearcher.WordSearcher.WordSearch:output_type -> wordsearcher.WordSearchResponse
+	34, // 66: wordsearcher.WordSearcher.GetRelatedWords:output_type -> wordsearcher.RelatedWordsResponse
+	38, // 67: wordsearcher.WordSearcher.CheckWords:output_type -> wordsearcher.CheckWordsResponse
+	54, // [54:68] is the sub-list for method output_type
+	40, // [40:54] is the sub-list for method input_type
+	40, // [40:40] is the sub-list for extension type_name
+	40, // [40:40] is the sub-list for extension extendee
+	0,  // [0:40] is the sub-list for field type_name
 }
 
 func init() { file_rpc_wordsearcher_searcher_proto_init() }
@@ -1868,8 +4409,44 @@ func file_rpc_wordsearcher_searcher_proto_init() {
 				return nil
 			}
 		}
-		file_rpc_wordsearcher_searcher_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Word); i {
+		file_rpc_wordsearcher_searcher_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Word); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Definition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1880,8 +4457,8 @@ func file_rpc_wordsearcher_searcher_proto_init() {
 				return nil
 			}
 		}
-		file_rpc_wordsearcher_searcher_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchRequest); i {
+		file_rpc_wordsearcher_searcher_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchSummaryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1892,8 +4469,8 @@ func file_rpc_wordsearcher_searcher_proto_init() {
 				return nil
 			}
 		}
-		file_rpc_wordsearcher_searcher_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchResponse); i {
+		file_rpc_wordsearcher_searcher_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FacetBucket); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1904,7 +4481,43 @@ func file_rpc_wordsearcher_searcher_proto_init() {
 				return nil
 			}
 		}
-		file_rpc_wordsearcher_searcher_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_rpc_wordsearcher_searcher_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Facet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchSummaryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchByQueryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AnagramRequest); i {
 			case 0:
 				return &v.state
@@ -1916,7 +4529,7 @@ func file_rpc_wordsearcher_searcher_proto_init() {
 				return nil
 			}
 		}
-		file_rpc_wordsearcher_searcher_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_rpc_wordsearcher_searcher_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AnagramResponse); i {
 			case 0:
 				return &v.state
@@ -1928,7 +4541,7 @@ func file_rpc_wordsearcher_searcher_proto_init() {
 				return nil
 			}
 		}
-		file_rpc_wordsearcher_searcher_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_rpc_wordsearcher_searcher_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BlankChallengeCreateRequest); i {
 			case 0:
 				return &v.state
@@ -1940,7 +4553,7 @@ func file_rpc_wordsearcher_searcher_proto_init() {
 				return nil
 			}
 		}
-		file_rpc_wordsearcher_searcher_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_rpc_wordsearcher_searcher_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BuildChallengeCreateRequest); i {
 			case 0:
 				return &v.state
@@ -1952,7 +4565,139 @@ func file_rpc_wordsearcher_searcher_proto_init() {
 				return nil
 			}
 		}
-		file_rpc_wordsearcher_searcher_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_rpc_wordsearcher_searcher_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StemRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StemAddition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Stem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StemResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListLexicaRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LetterDistributionTile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WordLengthCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LexiconDescription); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListLexicaResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PatternParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LetterCountConstraint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WordSearchRequest); i {
 			case 0:
 				return &v.state
@@ -1964,7 +4709,7 @@ func file_rpc_wordsearcher_searcher_proto_init() {
 				return nil
 			}
 		}
-		file_rpc_wordsearcher_searcher_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_rpc_wordsearcher_searcher_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DefineRequest); i {
 			case 0:
 				return &v.state
@@ -1976,7 +4721,7 @@ func file_rpc_wordsearcher_searcher_proto_init() {
 				return nil
 			}
 		}
-		file_rpc_wordsearcher_searcher_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_rpc_wordsearcher_searcher_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WordSearchResponse); i {
 			case 0:
 				return &v.state
@@ -1988,7 +4733,67 @@ func file_rpc_wordsearcher_searcher_proto_init() {
 				return nil
 			}
 		}
-		file_rpc_wordsearcher_searcher_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_rpc_wordsearcher_searcher_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RelatedWordsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckWordsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckedWord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LexiconVerdict); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckWordsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SearchRequest_MinMax); i {
 			case 0:
 				return &v.state
@@ -2000,7 +4805,7 @@ func file_rpc_wordsearcher_searcher_proto_init() {
 				return nil
 			}
 		}
-		file_rpc_wordsearcher_searcher_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_rpc_wordsearcher_searcher_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SearchRequest_StringValue); i {
 			case 0:
 				return &v.state
@@ -2012,7 +4817,7 @@ func file_rpc_wordsearcher_searcher_proto_init() {
 				return nil
 			}
 		}
-		file_rpc_wordsearcher_searcher_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_rpc_wordsearcher_searcher_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SearchRequest_StringArray); i {
 			case 0:
 				return &v.state
@@ -2024,7 +4829,7 @@ func file_rpc_wordsearcher_searcher_proto_init() {
 				return nil
 			}
 		}
-		file_rpc_wordsearcher_searcher_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_rpc_wordsearcher_searcher_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SearchRequest_NumberArray); i {
 			case 0:
 				return &v.state
@@ -2036,7 +4841,7 @@ func file_rpc_wordsearcher_searcher_proto_init() {
 				return nil
 			}
 		}
-		file_rpc_wordsearcher_searcher_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_rpc_wordsearcher_searcher_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SearchRequest_NumberValue); i {
 			case 0:
 				return &v.state
@@ -2048,7 +4853,7 @@ func file_rpc_wordsearcher_searcher_proto_init() {
 				return nil
 			}
 		}
-		file_rpc_wordsearcher_searcher_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_rpc_wordsearcher_searcher_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SearchRequest_HooksParam); i {
 			case 0:
 				return &v.state
@@ -2060,7 +4865,43 @@ func file_rpc_wordsearcher_searcher_proto_init() {
 				return nil
 			}
 		}
-		file_rpc_wordsearcher_searcher_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_rpc_wordsearcher_searcher_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchRequest_ConditionGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchRequest_CrossLexiconParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchRequest_SortSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_wordsearcher_searcher_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SearchRequest_SearchParam); i {
 			case 0:
 				return &v.state
@@ -2073,21 +4914,24 @@ func file_rpc_wordsearcher_searcher_proto_init() {
 			}
 		}
 	}
-	file_rpc_wordsearcher_searcher_proto_msgTypes[17].OneofWrappers = []interface{}{
+	file_rpc_wordsearcher_searcher_proto_msgTypes[42].OneofWrappers = []interface{}{
 		(*SearchRequest_SearchParam_Minmax)(nil),
 		(*SearchRequest_SearchParam_Stringvalue)(nil),
 		(*SearchRequest_SearchParam_Stringarray)(nil),
 		(*SearchRequest_SearchParam_Numberarray)(nil),
 		(*SearchRequest_SearchParam_Numbervalue)(nil),
 		(*SearchRequest_SearchParam_Hooksparam)(nil),
+		(*SearchRequest_SearchParam_Group)(nil),
+		(*SearchRequest_SearchParam_Pattern)(nil),
+		(*SearchRequest_SearchParam_Crosslexicon)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_rpc_wordsearcher_searcher_proto_rawDesc,
-			NumEnums:      4,
-			NumMessages:   18,
+			NumEnums:      6,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   3,
 		},
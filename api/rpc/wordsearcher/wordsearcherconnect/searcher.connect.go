@@ -39,8 +39,20 @@ const (
 const (
 	// QuestionSearcherSearchProcedure is the fully-qualified name of the QuestionSearcher's Search RPC.
 	QuestionSearcherSearchProcedure = "/wordsearcher.QuestionSearcher/Search"
+	// QuestionSearcherSearchStreamProcedure is the fully-qualified name of the QuestionSearcher's
+	// SearchStream RPC.
+	QuestionSearcherSearchStreamProcedure = "/wordsearcher.QuestionSearcher/SearchStream"
 	// QuestionSearcherExpandProcedure is the fully-qualified name of the QuestionSearcher's Expand RPC.
 	QuestionSearcherExpandProcedure = "/wordsearcher.QuestionSearcher/Expand"
+	// QuestionSearcherSearchSummaryProcedure is the fully-qualified name of the QuestionSearcher's
+	// SearchSummary RPC.
+	QuestionSearcherSearchSummaryProcedure = "/wordsearcher.QuestionSearcher/SearchSummary"
+	// QuestionSearcherSearchByQueryProcedure is the fully-qualified name of the QuestionSearcher's
+	// SearchByQuery RPC.
+	QuestionSearcherSearchByQueryProcedure = "/wordsearcher.QuestionSearcher/SearchByQuery"
+	// QuestionSearcherListLexicaProcedure is the fully-qualified name of the QuestionSearcher's
+	// ListLexica RPC.
+	QuestionSearcherListLexicaProcedure = "/wordsearcher.QuestionSearcher/ListLexica"
 	// AnagrammerAnagramProcedure is the fully-qualified name of the Anagrammer's Anagram RPC.
 	AnagrammerAnagramProcedure = "/wordsearcher.Anagrammer/Anagram"
 	// AnagrammerBlankChallengeCreatorProcedure is the fully-qualified name of the Anagrammer's
@@ -49,37 +61,69 @@ const (
 	// AnagrammerBuildChallengeCreatorProcedure is the fully-qualified name of the Anagrammer's
 	// BuildChallengeCreator RPC.
 	AnagrammerBuildChallengeCreatorProcedure = "/wordsearcher.Anagrammer/BuildChallengeCreator"
+	// AnagrammerStemSearchProcedure is the fully-qualified name of the Anagrammer's StemSearch RPC.
+	AnagrammerStemSearchProcedure = "/wordsearcher.Anagrammer/StemSearch"
 	// WordSearcherGetWordInformationProcedure is the fully-qualified name of the WordSearcher's
 	// GetWordInformation RPC.
 	WordSearcherGetWordInformationProcedure = "/wordsearcher.WordSearcher/GetWordInformation"
 	// WordSearcherWordSearchProcedure is the fully-qualified name of the WordSearcher's WordSearch RPC.
 	WordSearcherWordSearchProcedure = "/wordsearcher.WordSearcher/WordSearch"
+	// WordSearcherGetRelatedWordsProcedure is the fully-qualified name of the WordSearcher's
+	// GetRelatedWords RPC.
+	WordSearcherGetRelatedWordsProcedure = "/wordsearcher.WordSearcher/GetRelatedWords"
+	// WordSearcherCheckWordsProcedure is the fully-qualified name of the WordSearcher's CheckWords RPC.
+	WordSearcherCheckWordsProcedure = "/wordsearcher.WordSearcher/CheckWords"
 )
 
 // These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
 var (
 	questionSearcherServiceDescriptor               = wordsearcher.File_rpc_wordsearcher_searcher_proto.Services().ByName("QuestionSearcher")
 	questionSearcherSearchMethodDescriptor          = questionSearcherServiceDescriptor.Methods().ByName("Search")
+	questionSearcherSearchStreamMethodDescriptor    = questionSearcherServiceDescriptor.Methods().ByName("SearchStream")
 	questionSearcherExpandMethodDescriptor          = questionSearcherServiceDescriptor.Methods().ByName("Expand")
+	questionSearcherSearchSummaryMethodDescriptor   = questionSearcherServiceDescriptor.Methods().ByName("SearchSummary")
+	questionSearcherSearchByQueryMethodDescriptor   = questionSearcherServiceDescriptor.Methods().ByName("SearchByQuery")
+	questionSearcherListLexicaMethodDescriptor      = questionSearcherServiceDescriptor.Methods().ByName("ListLexica")
 	anagrammerServiceDescriptor                     = wordsearcher.File_rpc_wordsearcher_searcher_proto.Services().ByName("Anagrammer")
 	anagrammerAnagramMethodDescriptor               = anagrammerServiceDescriptor.Methods().ByName("Anagram")
 	anagrammerBlankChallengeCreatorMethodDescriptor = anagrammerServiceDescriptor.Methods().ByName("BlankChallengeCreator")
 	anagrammerBuildChallengeCreatorMethodDescriptor = anagrammerServiceDescriptor.Methods().ByName("BuildChallengeCreator")
+	anagrammerStemSearchMethodDescriptor            = anagrammerServiceDescriptor.Methods().ByName("StemSearch")
 	wordSearcherServiceDescriptor                   = wordsearcher.File_rpc_wordsearcher_searcher_proto.Services().ByName("WordSearcher")
 	wordSearcherGetWordInformationMethodDescriptor  = wordSearcherServiceDescriptor.Methods().ByName("GetWordInformation")
 	wordSearcherWordSearchMethodDescriptor          = wordSearcherServiceDescriptor.Methods().ByName("WordSearch")
+	wordSearcherGetRelatedWordsMethodDescriptor     = wordSearcherServiceDescriptor.Methods().ByName("GetRelatedWords")
+	wordSearcherCheckWordsMethodDescriptor          = wordSearcherServiceDescriptor.Methods().ByName("CheckWords")
 )
 
 // QuestionSearcherClient is a client for the wordsearcher.QuestionSearcher service.
 type QuestionSearcherClient interface {
 	// Search takes in a search request and returns a search response.
 	// This response can be expanded or not, depending on the `expand` field
-	// in SearchRequest.
+	// in SearchRequest. Searches that aren't paginated fail with
+	// RESOURCE_EXHAUSTED if they match more words than the server allows in
+	// one response; anonymous callers are allowed fewer than signed-in ones.
 	Search(context.Context, *connect.Request[wordsearcher.SearchRequest]) (*connect.Response[wordsearcher.SearchResponse], error)
+	// SearchStream is like Search, but sends the results in batches as they
+	// are read from the database, with no limit on the number of results.
+	// page_size, if set, is the batch size. Every batch carries a
+	// next_page_token that can be passed to Search or SearchStream to resume
+	// after that batch.
+	SearchStream(context.Context, *connect.Request[wordsearcher.SearchRequest]) (*connect.ServerStreamForClient[wordsearcher.SearchResponse], error)
 	// Expand takes in an unexpanded search response and returns a
 	// search response (fully expanded). See expandedRepr above in
 	// the Alphagram field.
 	Expand(context.Context, *connect.Request[wordsearcher.SearchResponse]) (*connect.Response[wordsearcher.SearchResponse], error)
+	// SearchSummary counts the alphagrams and words a search matches, and
+	// optionally breaks the counts down by facet, without returning the
+	// matches themselves.
+	SearchSummary(context.Context, *connect.Request[wordsearcher.SearchSummaryRequest]) (*connect.Response[wordsearcher.SearchSummaryResponse], error)
+	// SearchByQuery parses a text query into a SearchRequest and runs it.
+	// Mistakes in the query are InvalidArgument errors that give the
+	// position of the mistake.
+	SearchByQuery(context.Context, *connect.Request[wordsearcher.SearchByQueryRequest]) (*connect.Response[wordsearcher.SearchResponse], error)
+	// ListLexica describes every lexicon the server has a database for.
+	ListLexica(context.Context, *connect.Request[wordsearcher.ListLexicaRequest]) (*connect.Response[wordsearcher.ListLexicaResponse], error)
 }
 
 // NewQuestionSearcherClient constructs a client for the wordsearcher.QuestionSearcher service. By
@@ -99,6 +143,13 @@ func NewQuestionSearcherClient(httpClient connect.HTTPClient, baseURL string, op
 			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 			connect.WithClientOptions(opts...),
 		),
+		searchStream: connect.NewClient[wordsearcher.SearchRequest, wordsearcher.SearchResponse](
+			httpClient,
+			baseURL+QuestionSearcherSearchStreamProcedure,
+			connect.WithSchema(questionSearcherSearchStreamMethodDescriptor),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
 		expand: connect.NewClient[wordsearcher.SearchResponse, wordsearcher.SearchResponse](
 			httpClient,
 			baseURL+QuestionSearcherExpandProcedure,
@@ -106,13 +157,38 @@ func NewQuestionSearcherClient(httpClient connect.HTTPClient, baseURL string, op
 			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 			connect.WithClientOptions(opts...),
 		),
+		searchSummary: connect.NewClient[wordsearcher.SearchSummaryRequest, wordsearcher.SearchSummaryResponse](
+			httpClient,
+			baseURL+QuestionSearcherSearchSummaryProcedure,
+			connect.WithSchema(questionSearcherSearchSummaryMethodDescriptor),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		searchByQuery: connect.NewClient[wordsearcher.SearchByQueryRequest, wordsearcher.SearchResponse](
+			httpClient,
+			baseURL+QuestionSearcherSearchByQueryProcedure,
+			connect.WithSchema(questionSearcherSearchByQueryMethodDescriptor),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		listLexica: connect.NewClient[wordsearcher.ListLexicaRequest, wordsearcher.ListLexicaResponse](
+			httpClient,
+			baseURL+QuestionSearcherListLexicaProcedure,
+			connect.WithSchema(questionSearcherListLexicaMethodDescriptor),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // questionSearcherClient implements QuestionSearcherClient.
 type questionSearcherClient struct {
-	search *connect.Client[wordsearcher.SearchRequest, wordsearcher.SearchResponse]
-	expand *connect.Client[wordsearcher.SearchResponse, wordsearcher.SearchResponse]
+	search        *connect.Client[wordsearcher.SearchRequest, wordsearcher.SearchResponse]
+	searchStream  *connect.Client[wordsearcher.SearchRequest, wordsearcher.SearchResponse]
+	expand        *connect.Client[wordsearcher.SearchResponse, wordsearcher.SearchResponse]
+	searchSummary *connect.Client[wordsearcher.SearchSummaryRequest, wordsearcher.SearchSummaryResponse]
+	searchByQuery *connect.Client[wordsearcher.SearchByQueryRequest, wordsearcher.SearchResponse]
+	listLexica    *connect.Client[wordsearcher.ListLexicaRequest, wordsearcher.ListLexicaResponse]
 }
 
 // Search calls wordsearcher.QuestionSearcher.Search.
@@ -120,21 +196,59 @@ func (c *questionSearcherClient) Search(ctx context.Context, req *connect.Reques
 	return c.search.CallUnary(ctx, req)
 }
 
+// SearchStream calls wordsearcher.QuestionSearcher.SearchStream.
+func (c *questionSearcherClient) SearchStream(ctx context.Context, req *connect.Request[wordsearcher.SearchRequest]) (*connect.ServerStreamForClient[wordsearcher.SearchResponse], error) {
+	return c.searchStream.CallServerStream(ctx, req)
+}
+
 // Expand calls wordsearcher.QuestionSearcher.Expand.
 func (c *questionSearcherClient) Expand(ctx context.Context, req *connect.Request[wordsearcher.SearchResponse]) (*connect.Response[wordsearcher.SearchResponse], error) {
 	return c.expand.CallUnary(ctx, req)
 }
 
+// SearchSummary calls wordsearcher.QuestionSearcher.SearchSummary.
+func (c *questionSearcherClient) SearchSummary(ctx context.Context, req *connect.Request[wordsearcher.SearchSummaryRequest]) (*connect.Response[wordsearcher.SearchSummaryResponse], error) {
+	return c.searchSummary.CallUnary(ctx, req)
+}
+
+// SearchByQuery calls wordsearcher.QuestionSearcher.SearchByQuery.
+func (c *questionSearcherClient) SearchByQuery(ctx context.Context, req *connect.Request[wordsearcher.SearchByQueryRequest]) (*connect.Response[wordsearcher.SearchResponse], error) {
+	return c.searchByQuery.CallUnary(ctx, req)
+}
+
+// ListLexica calls wordsearcher.QuestionSearcher.ListLexica.
+func (c *questionSearcherClient) ListLexica(ctx context.Context, req *connect.Request[wordsearcher.ListLexicaRequest]) (*connect.Response[wordsearcher.ListLexicaResponse], error) {
+	return c.listLexica.CallUnary(ctx, req)
+}
+
 // QuestionSearcherHandler is an implementation of the wordsearcher.QuestionSearcher service.
 type QuestionSearcherHandler interface {
 	// Search takes in a search request and returns a search response.
 	// This response can be expanded or not, depending on the `expand` field
-	// in SearchRequest.
+	// in SearchRequest. Searches that aren't paginated fail with
+	// RESOURCE_EXHAUSTED if they match more words than the server allows in
+	// one response; anonymous callers are allowed fewer than signed-in ones.
 	Search(context.Context, *connect.Request[wordsearcher.SearchRequest]) (*connect.Response[wordsearcher.SearchResponse], error)
+	// SearchStream is like Search, but sends the results in batches as they
+	// are read from the database, with no limit on the number of results.
+	// page_size, if set, is the batch size. Every batch carries a
+	// next_page_token that can be passed to Search or SearchStream to resume
+	// after that batch.
+	SearchStream(context.Context, *connect.Request[wordsearcher.SearchRequest], *connect.ServerStream[wordsearcher.SearchResponse]) error
 	// Expand takes in an unexpanded search response and returns a
 	// search response (fully expanded). See expandedRepr above in
 	// the Alphagram field.
 	Expand(context.Context, *connect.Request[wordsearcher.SearchResponse]) (*connect.Response[wordsearcher.SearchResponse], error)
+	// SearchSummary counts the alphagrams and words a search matches, and
+	// optionally breaks the counts down by facet, without returning the
+	// matches themselves.
+	SearchSummary(context.Context, *connect.Request[wordsearcher.SearchSummaryRequest]) (*connect.Response[wordsearcher.SearchSummaryResponse], error)
+	// SearchByQuery parses a text query into a SearchRequest and runs it.
+	// Mistakes in the query are InvalidArgument errors that give the
+	// position of the mistake.
+	SearchByQuery(context.Context, *connect.Request[wordsearcher.SearchByQueryRequest]) (*connect.Response[wordsearcher.SearchResponse], error)
+	// ListLexica describes every lexicon the server has a database for.
+	ListLexica(context.Context, *connect.Request[wordsearcher.ListLexicaRequest]) (*connect.Response[wordsearcher.ListLexicaResponse], error)
 }
 
 // NewQuestionSearcherHandler builds an HTTP handler from the service implementation. It returns the
@@ -150,6 +264,13 @@ func NewQuestionSearcherHandler(svc QuestionSearcherHandler, opts ...connect.Han
 		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 		connect.WithHandlerOptions(opts...),
 	)
+	questionSearcherSearchStreamHandler := connect.NewServerStreamHandler(
+		QuestionSearcherSearchStreamProcedure,
+		svc.SearchStream,
+		connect.WithSchema(questionSearcherSearchStreamMethodDescriptor),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
 	questionSearcherExpandHandler := connect.NewUnaryHandler(
 		QuestionSearcherExpandProcedure,
 		svc.Expand,
@@ -157,12 +278,41 @@ func NewQuestionSearcherHandler(svc QuestionSearcherHandler, opts ...connect.Han
 		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 		connect.WithHandlerOptions(opts...),
 	)
+	questionSearcherSearchSummaryHandler := connect.NewUnaryHandler(
+		QuestionSearcherSearchSummaryProcedure,
+		svc.SearchSummary,
+		connect.WithSchema(questionSearcherSearchSummaryMethodDescriptor),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	questionSearcherSearchByQueryHandler := connect.NewUnaryHandler(
+		QuestionSearcherSearchByQueryProcedure,
+		svc.SearchByQuery,
+		connect.WithSchema(questionSearcherSearchByQueryMethodDescriptor),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	questionSearcherListLexicaHandler := connect.NewUnaryHandler(
+		QuestionSearcherListLexicaProcedure,
+		svc.ListLexica,
+		connect.WithSchema(questionSearcherListLexicaMethodDescriptor),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/wordsearcher.QuestionSearcher/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case QuestionSearcherSearchProcedure:
 			questionSearcherSearchHandler.ServeHTTP(w, r)
+		case QuestionSearcherSearchStreamProcedure:
+			questionSearcherSearchStreamHandler.ServeHTTP(w, r)
 		case QuestionSearcherExpandProcedure:
 			questionSearcherExpandHandler.ServeHTTP(w, r)
+		case QuestionSearcherSearchSummaryProcedure:
+			questionSearcherSearchSummaryHandler.ServeHTTP(w, r)
+		case QuestionSearcherSearchByQueryProcedure:
+			questionSearcherSearchByQueryHandler.ServeHTTP(w, r)
+		case QuestionSearcherListLexicaProcedure:
+			questionSearcherListLexicaHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -176,10 +326,26 @@ func (UnimplementedQuestionSearcherHandler) Search(context.Context, *connect.Req
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wordsearcher.QuestionSearcher.Search is not implemented"))
 }
 
+func (UnimplementedQuestionSearcherHandler) SearchStream(context.Context, *connect.Request[wordsearcher.SearchRequest], *connect.ServerStream[wordsearcher.SearchResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("wordsearcher.QuestionSearcher.SearchStream is not implemented"))
+}
+
 func (UnimplementedQuestionSearcherHandler) Expand(context.Context, *connect.Request[wordsearcher.SearchResponse]) (*connect.Response[wordsearcher.SearchResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wordsearcher.QuestionSearcher.Expand is not implemented"))
 }
 
+func (UnimplementedQuestionSearcherHandler) SearchSummary(context.Context, *connect.Request[wordsearcher.SearchSummaryRequest]) (*connect.Response[wordsearcher.SearchSummaryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wordsearcher.QuestionSearcher.SearchSummary is not implemented"))
+}
+
+func (UnimplementedQuestionSearcherHandler) SearchByQuery(context.Context, *connect.Request[wordsearcher.SearchByQueryRequest]) (*connect.Response[wordsearcher.SearchResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wordsearcher.QuestionSearcher.SearchByQuery is not implemented"))
+}
+
+func (UnimplementedQuestionSearcherHandler) ListLexica(context.Context, *connect.Request[wordsearcher.ListLexicaRequest]) (*connect.Response[wordsearcher.ListLexicaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wordsearcher.QuestionSearcher.ListLexica is not implemented"))
+}
+
 // AnagrammerClient is a client for the wordsearcher.Anagrammer service.
 type AnagrammerClient interface {
 	// Anagram does a simple anagram search; it can either be
@@ -189,6 +355,9 @@ type AnagrammerClient interface {
 	BlankChallengeCreator(context.Context, *connect.Request[wordsearcher.BlankChallengeCreateRequest]) (*connect.Response[wordsearcher.SearchResponse], error)
 	// BuildChallengeCreator creates build challenges for Aerolith.
 	BuildChallengeCreator(context.Context, *connect.Request[wordsearcher.BuildChallengeCreateRequest]) (*connect.Response[wordsearcher.SearchResponse], error)
+	// StemSearch finds every letter or pair of letters that can be added to
+	// stems to make words, and ranks the stems by productivity.
+	StemSearch(context.Context, *connect.Request[wordsearcher.StemRequest]) (*connect.Response[wordsearcher.StemResponse], error)
 }
 
 // NewAnagrammerClient constructs a client for the wordsearcher.Anagrammer service. By default, it
@@ -222,6 +391,13 @@ func NewAnagrammerClient(httpClient connect.HTTPClient, baseURL string, opts ...
 			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 			connect.WithClientOptions(opts...),
 		),
+		stemSearch: connect.NewClient[wordsearcher.StemRequest, wordsearcher.StemResponse](
+			httpClient,
+			baseURL+AnagrammerStemSearchProcedure,
+			connect.WithSchema(anagrammerStemSearchMethodDescriptor),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -230,6 +406,7 @@ type anagrammerClient struct {
 	anagram               *connect.Client[wordsearcher.AnagramRequest, wordsearcher.AnagramResponse]
 	blankChallengeCreator *connect.Client[wordsearcher.BlankChallengeCreateRequest, wordsearcher.SearchResponse]
 	buildChallengeCreator *connect.Client[wordsearcher.BuildChallengeCreateRequest, wordsearcher.SearchResponse]
+	stemSearch            *connect.Client[wordsearcher.StemRequest, wordsearcher.StemResponse]
 }
 
 // Anagram calls wordsearcher.Anagrammer.Anagram.
@@ -247,6 +424,11 @@ func (c *anagrammerClient) BuildChallengeCreator(ctx context.Context, req *conne
 	return c.buildChallengeCreator.CallUnary(ctx, req)
 }
 
+// StemSearch calls wordsearcher.Anagrammer.StemSearch.
+func (c *anagrammerClient) StemSearch(ctx context.Context, req *connect.Request[wordsearcher.StemRequest]) (*connect.Response[wordsearcher.StemResponse], error) {
+	return c.stemSearch.CallUnary(ctx, req)
+}
+
 // AnagrammerHandler is an implementation of the wordsearcher.Anagrammer service.
 type AnagrammerHandler interface {
 	// Anagram does a simple anagram search; it can either be
@@ -256,6 +438,9 @@ type AnagrammerHandler interface {
 	BlankChallengeCreator(context.Context, *connect.Request[wordsearcher.BlankChallengeCreateRequest]) (*connect.Response[wordsearcher.SearchResponse], error)
 	// BuildChallengeCreator creates build challenges for Aerolith.
 	BuildChallengeCreator(context.Context, *connect.Request[wordsearcher.BuildChallengeCreateRequest]) (*connect.Response[wordsearcher.SearchResponse], error)
+	// StemSearch finds every letter or pair of letters that can be added to
+	// stems to make words, and ranks the stems by productivity.
+	StemSearch(context.Context, *connect.Request[wordsearcher.StemRequest]) (*connect.Response[wordsearcher.StemResponse], error)
 }
 
 // NewAnagrammerHandler builds an HTTP handler from the service implementation. It returns the path
@@ -285,6 +470,13 @@ func NewAnagrammerHandler(svc AnagrammerHandler, opts ...connect.HandlerOption)
 		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 		connect.WithHandlerOptions(opts...),
 	)
+	anagrammerStemSearchHandler := connect.NewUnaryHandler(
+		AnagrammerStemSearchProcedure,
+		svc.StemSearch,
+		connect.WithSchema(anagrammerStemSearchMethodDescriptor),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/wordsearcher.Anagrammer/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AnagrammerAnagramProcedure:
@@ -293,6 +485,8 @@ func NewAnagrammerHandler(svc AnagrammerHandler, opts ...connect.HandlerOption)
 			anagrammerBlankChallengeCreatorHandler.ServeHTTP(w, r)
 		case AnagrammerBuildChallengeCreatorProcedure:
 			anagrammerBuildChallengeCreatorHandler.ServeHTTP(w, r)
+		case AnagrammerStemSearchProcedure:
+			anagrammerStemSearchHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -314,10 +508,19 @@ func (UnimplementedAnagrammerHandler) BuildChallengeCreator(context.Context, *co
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wordsearcher.Anagrammer.BuildChallengeCreator is not implemented"))
 }
 
+func (UnimplementedAnagrammerHandler) StemSearch(context.Context, *connect.Request[wordsearcher.StemRequest]) (*connect.Response[wordsearcher.StemResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wordsearcher.Anagrammer.StemSearch is not implemented"))
+}
+
 // WordSearcherClient is a client for the wordsearcher.WordSearcher service.
 type WordSearcherClient interface {
 	GetWordInformation(context.Context, *connect.Request[wordsearcher.DefineRequest]) (*connect.Response[wordsearcher.WordSearchResponse], error)
 	WordSearch(context.Context, *connect.Request[wordsearcher.WordSearchRequest]) (*connect.Response[wordsearcher.WordSearchResponse], error)
+	GetRelatedWords(context.Context, *connect.Request[wordsearcher.DefineRequest]) (*connect.Response[wordsearcher.RelatedWordsResponse], error)
+	// CheckWords rules on whether words are valid, e.g. to adjudicate a
+	// challenge. Validity comes from the lexicon's word graph, not its
+	// database.
+	CheckWords(context.Context, *connect.Request[wordsearcher.CheckWordsRequest]) (*connect.Response[wordsearcher.CheckWordsResponse], error)
 }
 
 // NewWordSearcherClient constructs a client for the wordsearcher.WordSearcher service. By default,
@@ -344,6 +547,20 @@ func NewWordSearcherClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 			connect.WithClientOptions(opts...),
 		),
+		getRelatedWords: connect.NewClient[wordsearcher.DefineRequest, wordsearcher.RelatedWordsResponse](
+			httpClient,
+			baseURL+WordSearcherGetRelatedWordsProcedure,
+			connect.WithSchema(wordSearcherGetRelatedWordsMethodDescriptor),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		checkWords: connect.NewClient[wordsearcher.CheckWordsRequest, wordsearcher.CheckWordsResponse](
+			httpClient,
+			baseURL+WordSearcherCheckWordsProcedure,
+			connect.WithSchema(wordSearcherCheckWordsMethodDescriptor),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -351,6 +568,8 @@ func NewWordSearcherClient(httpClient connect.HTTPClient, baseURL string, opts .
 type wordSearcherClient struct {
 	getWordInformation *connect.Client[wordsearcher.DefineRequest, wordsearcher.WordSearchResponse]
 	wordSearch         *connect.Client[wordsearcher.WordSearchRequest, wordsearcher.WordSearchResponse]
+	getRelatedWords    *connect.Client[wordsearcher.DefineRequest, wordsearcher.RelatedWordsResponse]
+	checkWords         *connect.Client[wordsearcher.CheckWordsRequest, wordsearcher.CheckWordsResponse]
 }
 
 // GetWordInformation calls wordsearcher.WordSearcher.GetWordInformation.
@@ -363,10 +582,25 @@ func (c *wordSearcherClient) WordSearch(ctx context.Context, req *connect.Reques
 	return c.wordSearch.CallUnary(ctx, req)
 }
 
+// GetRelatedWords calls wordsearcher.WordSearcher.GetRelatedWords.
+func (c *wordSearcherClient) GetRelatedWords(ctx context.Context, req *connect.Request[wordsearcher.DefineRequest]) (*connect.Response[wordsearcher.RelatedWordsResponse], error) {
+	return c.getRelatedWords.CallUnary(ctx, req)
+}
+
+// CheckWords calls wordsearcher.WordSearcher.CheckWords.
+func (c *wordSearcherClient) CheckWords(ctx context.Context, req *connect.Request[wordsearcher.CheckWordsRequest]) (*connect.Response[wordsearcher.CheckWordsResponse], error) {
+	return c.checkWords.CallUnary(ctx, req)
+}
+
 // WordSearcherHandler is an implementation of the wordsearcher.WordSearcher service.
 type WordSearcherHandler interface {
 	GetWordInformation(context.Context, *connect.Request[wordsearcher.DefineRequest]) (*connect.Response[wordsearcher.WordSearchResponse], error)
 	WordSearch(context.Context, *connect.Request[wordsearcher.WordSearchRequest]) (*connect.Response[wordsearcher.WordSearchResponse], error)
+	GetRelatedWords(context.Context, *connect.Request[wordsearcher.DefineRequest]) (*connect.Response[wordsearcher.RelatedWordsResponse], error)
+	// CheckWords rules on whether words are valid, e.g. to adjudicate a
+	// challenge. Validity comes from the lexicon's word graph, not its
+	// database.
+	CheckWords(context.Context, *connect.Request[wordsearcher.CheckWordsRequest]) (*connect.Response[wordsearcher.CheckWordsResponse], error)
 }
 
 // NewWordSearcherHandler builds an HTTP handler from the service implementation. It returns the
@@ -389,12 +623,30 @@ func NewWordSearcherHandler(svc WordSearcherHandler, opts ...connect.HandlerOpti
 		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 		connect.WithHandlerOptions(opts...),
 	)
+	wordSearcherGetRelatedWordsHandler := connect.NewUnaryHandler(
+		WordSearcherGetRelatedWordsProcedure,
+		svc.GetRelatedWords,
+		connect.WithSchema(wordSearcherGetRelatedWordsMethodDescriptor),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	wordSearcherCheckWordsHandler := connect.NewUnaryHandler(
+		WordSearcherCheckWordsProcedure,
+		svc.CheckWords,
+		connect.WithSchema(wordSearcherCheckWordsMethodDescriptor),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/wordsearcher.WordSearcher/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case WordSearcherGetWordInformationProcedure:
 			wordSearcherGetWordInformationHandler.ServeHTTP(w, r)
 		case WordSearcherWordSearchProcedure:
 			wordSearcherWordSearchHandler.ServeHTTP(w, r)
+		case WordSearcherGetRelatedWordsProcedure:
+			wordSearcherGetRelatedWordsHandler.ServeHTTP(w, r)
+		case WordSearcherCheckWordsProcedure:
+			wordSearcherCheckWordsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -411,3 +663,11 @@ func (UnimplementedWordSearcherHandler) GetWordInformation(context.Context, *con
 func (UnimplementedWordSearcherHandler) WordSearch(context.Context, *connect.Request[wordsearcher.WordSearchRequest]) (*connect.Response[wordsearcher.WordSearchResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wordsearcher.WordSearcher.WordSearch is not implemented"))
 }
+
+func (UnimplementedWordSearcherHandler) GetRelatedWords(context.Context, *connect.Request[wordsearcher.DefineRequest]) (*connect.Response[wordsearcher.RelatedWordsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wordsearcher.WordSearcher.GetRelatedWords is not implemented"))
+}
+
+func (UnimplementedWordSearcherHandler) CheckWords(context.Context, *connect.Request[wordsearcher.CheckWordsRequest]) (*connect.Response[wordsearcher.CheckWordsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wordsearcher.WordSearcher.CheckWords is not implemented"))
+}
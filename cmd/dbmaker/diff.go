@@ -0,0 +1,115 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/namsral/flag"
+	"github.com/rs/zerolog/log"
+
+	"github.com/domino14/word_db_server/dbmaker"
+)
+
+// diff reports what changed between two lexica:
+//
+//	dbmaker diff -from CSW21 -to CSW24 -format csv -out changes.csv
+//
+// Without -from, the lexicon before -to in its family is used.
+func diff(args []string) error {
+	fs := flag.NewFlagSet("dbmaker diff", flag.ContinueOnError)
+	from := fs.String("from", "", "The older lexicon (default: the one before -to)")
+	to := fs.String("to", "", "The newer lexicon")
+	format := fs.String("format", "json", "The report format: json or csv")
+	out := fs.String("out", "", "Where to write the report (default: standard output)")
+	store := fs.Bool("store", false,
+		"Also store the changes in the lexicon_changes table of <outputdir>/<to>.db, for CHANGED_SINCE searches")
+	outputDir := fs.String("outputdir", ".", "The directory with the lexicon databases")
+	dataPath := fs.String("datapath", os.Getenv("WDB_DATA_PATH"), "The data path")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *to == "" {
+		return errors.New("-to is required")
+	}
+	if *format != "json" && *format != "csv" {
+		return fmt.Errorf("unknown format %v", *format)
+	}
+
+	manifest, err := dbmaker.LoadManifest(*dataPath)
+	if err != nil {
+		return err
+	}
+	if *from == "" {
+		_, _, prior, ok := manifest.Lexicon(*to)
+		if !ok {
+			return fmt.Errorf("lexicon %v is not in the manifest", *to)
+		}
+		if prior == nil {
+			return fmt.Errorf("%v has no prior lexicon; use -from", *to)
+		}
+		*from = prior.Name
+	}
+	if err := manifest.CheckFiles(*dataPath, *from, *to); err != nil {
+		return err
+	}
+	lexiconMap, err := manifest.LexiconMap(*dataPath)
+	if err != nil {
+		return err
+	}
+	fromInfo, err := lexiconMap.GetLexiconInfo(*from)
+	if err != nil {
+		return fmt.Errorf("%v: %w", *from, err)
+	}
+	toInfo, err := lexiconMap.GetLexiconInfo(*to)
+	if err != nil {
+		return fmt.Errorf("%v: %w", *to, err)
+	}
+	fromInfo.Initialize()
+	toInfo.Initialize()
+
+	log.Info().Str("from", *from).Str("to", *to).Msg("diffing-lexica")
+	d, err := dbmaker.DiffLexica(fromInfo, toInfo)
+	if err != nil {
+		return err
+	}
+	log.Info().Interface("counts", d.Counts).Msg("diffed-lexica")
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if *format == "csv" {
+		err = d.WriteCSV(w)
+	} else {
+		err = d.WriteJSON(w)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *store {
+		dbName := filepath.Join(*outputDir, *to+".db")
+		if _, err := os.Stat(dbName); err != nil {
+			return err
+		}
+		db, err := sql.Open("sqlite3", dbName)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		if err := d.StoreChanges(db); err != nil {
+			return err
+		}
+		log.Info().Str("db", dbName).Int("changes", len(d.Changes)).Msg("stored-lexicon-changes")
+	}
+	return nil
+}
@@ -21,13 +21,14 @@ func stringInSlice(a string, list []string) bool {
 }
 
 type Config struct {
-	MigrateDB    string
-	DBs          string
-	ForceCreate  bool
-	FixDefsOn    string
-	FixSymbolsOn string
-	OutputDir    string
-	DataPath     string
+	MigrateDB     string
+	DBs           string
+	ForceCreate   bool
+	FixDefsOn     string
+	FixSymbolsOn  string
+	OutputDir     string
+	DataPath      string
+	CheckManifest bool
 }
 
 // Load loads the configs from the given arguments
@@ -48,11 +49,19 @@ func (c *Config) Load(args []string) error {
 		"Pass in lexicon name to fix lexicon symbols on. DB <lexiconname>.db must exist in this dir.")
 	fs.StringVar(&c.OutputDir, "outputdir", ".", "The output directory")
 	fs.StringVar(&c.DataPath, "datapath", os.Getenv("WDB_DATA_PATH"), "The data path")
+	fs.BoolVar(&c.CheckManifest, "checkmanifest", false,
+		"Check that the lexicon manifest is valid and all its files exist, then quit")
 	return fs.Parse(args)
 
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		if err := diff(os.Args[2:]); err != nil {
+			log.Fatal().Err(err).Msg("diff")
+		}
+		return
+	}
 
 	cfg := &Config{}
 	cfg.Load(os.Args[1:])
@@ -60,7 +69,22 @@ func main() {
 
 	// MkdirAll will make any intermediate dirs but fail gracefully if they exist.
 	os.MkdirAll(cfg.OutputDir, os.ModePerm)
-	lexiconMap := dbmaker.LexiconMappings(cfg.DataPath)
+	manifest, err := dbmaker.LoadManifest(cfg.DataPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("bad lexicon manifest")
+	}
+	log.Info().Str("manifest", manifest.Path).Msg("loaded-manifest")
+	if cfg.CheckManifest {
+		if err := manifest.CheckFiles(cfg.DataPath); err != nil {
+			log.Fatal().Err(err).Msg("missing lexicon files")
+		}
+		log.Info().Msg("manifest is ok")
+		return
+	}
+	lexiconMap, err := manifest.LexiconMap(cfg.DataPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("loading lexica")
+	}
 
 	if cfg.MigrateDB != "" {
 		info, err := lexiconMap.GetLexiconInfo(cfg.MigrateDB)
@@ -68,13 +92,13 @@ func main() {
 			log.Err(err).Msg("That lexicon is not supported")
 			return
 		}
-		dbmaker.MigrateLexiconDatabase(cfg.MigrateDB, info)
+		dbmaker.MigrateLexiconDatabase(cfg.MigrateDB, info, lexiconMap)
 	} else if cfg.FixDefsOn != "" {
 		fixDefinitions(cfg.FixDefsOn, lexiconMap)
 	} else if cfg.FixSymbolsOn != "" {
 		fixSymbols(cfg.FixSymbolsOn, lexiconMap)
 	} else {
-		makeDbs(cfg.DBs, lexiconMap, cfg.OutputDir, cfg.ForceCreate)
+		makeDbs(cfg.DBs, cfg.DataPath, manifest, lexiconMap, cfg.OutputDir, cfg.ForceCreate)
 	}
 }
 
@@ -91,7 +115,7 @@ func fixSymbols(dbToFixSymbols string, lexiconMap dbmaker.LexiconMap) {
 	dbmaker.FixLexiconSymbols(dbToFixSymbols, lexiconMap)
 }
 
-func makeDbs(dbsToMake string, lexiconMap dbmaker.LexiconMap,
+func makeDbs(dbsToMake, dataPath string, manifest *dbmaker.Manifest, lexiconMap dbmaker.LexiconMap,
 	outputDir string, forceCreation bool) {
 
 	dbs := []string{}
@@ -107,6 +131,10 @@ func makeDbs(dbsToMake string, lexiconMap dbmaker.LexiconMap,
 			log.Err(err).Msgf("%v was not in list of dbs, skipping...", db)
 			continue
 		}
+		if err := manifest.CheckFiles(dataPath, db); err != nil {
+			log.Err(err).Msgf("%v is missing files, skipping...", db)
+			continue
+		}
 		if info.KWG == nil || info.KWG.GetAlphabet() == nil {
 			log.Info().Msgf("%v was not supplied, skipping...", db)
 			continue
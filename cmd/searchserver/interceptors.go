@@ -3,10 +3,12 @@ package main
 import (
 	"context"
 	"errors"
+	"expvar"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/golang-jwt/jwt/v5"
@@ -34,6 +36,24 @@ func NewAuthInterceptor(secretKey []byte) connect.UnaryInterceptorFunc {
 	return connect.UnaryInterceptorFunc(interceptor)
 }
 
+// NewOptionalAuthInterceptor is like NewAuthInterceptor, but lets anonymous
+// requests through. Requests with a bad token are still turned away.
+func NewOptionalAuthInterceptor(secretKey []byte) connect.UnaryInterceptorFunc {
+	interceptor := func(next connect.UnaryFunc) connect.UnaryFunc {
+		return connect.UnaryFunc(func(
+			ctx context.Context,
+			req connect.AnyRequest,
+		) (connect.AnyResponse, error) {
+
+			if req.Header().Get("Authorization") != "" {
+				return jwtInterceptor(ctx, secretKey, req, next)
+			}
+			return next(ctx, req)
+		})
+	}
+	return connect.UnaryInterceptorFunc(interceptor)
+}
+
 func jwtInterceptor(ctx context.Context, secretKey []byte, req connect.AnyRequest, next connect.UnaryFunc) (
 	connect.AnyResponse, error) {
 
@@ -99,3 +119,114 @@ func authenticateJWT(ctx context.Context, reqHeader http.Header, secretKey []byt
 		return nil, errors.New("could not parse token claims")
 	}
 }
+
+var (
+	// How many RPCs were cancelled by the client, and how many ran out of
+	// time, by procedure. They're served at /debug/vars.
+	rpcsCanceled         = expvar.NewMap("rpcs_canceled")
+	rpcsDeadlineExceeded = expvar.NewMap("rpcs_deadline_exceeded")
+)
+
+// deadlineInterceptor gives every RPC handled by the server a deadline,
+// and reports RPCs that fail because they were cancelled or ran out of
+// time as CodeCanceled or CodeDeadlineExceeded, whatever the error the
+// handler gave up with.
+type deadlineInterceptor struct {
+	defaultTimeout time.Duration
+	// timeouts are keyed by Service.Method or just Method.
+	timeouts map[string]time.Duration
+}
+
+// NewDeadlineInterceptor makes an interceptor that gives each RPC the
+// timeout in timeouts for it, or defaultTimeout. A timeout of 0 means no
+// deadline.
+func NewDeadlineInterceptor(defaultTimeout time.Duration, timeouts map[string]time.Duration) connect.Interceptor {
+	return &deadlineInterceptor{defaultTimeout: defaultTimeout, timeouts: timeouts}
+}
+
+// parseRequestTimeouts parses a list like Search=10s,SearchStream=0.
+func parseRequestTimeouts(spec string) (map[string]time.Duration, error) {
+	timeouts := map[string]time.Duration{}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("request timeout %q should look like Search=10s", item)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("request timeout for %s: %w", name, err)
+		}
+		timeouts[strings.TrimSpace(name)] = timeout
+	}
+	return timeouts, nil
+}
+
+// timeout finds the timeout for a procedure like
+// /wordsearcher.QuestionSearcher/Search.
+func (d *deadlineInterceptor) timeout(procedure string) time.Duration {
+	service, method, _ := strings.Cut(strings.TrimPrefix(procedure, "/"), "/")
+	service = service[strings.LastIndex(service, ".")+1:]
+	if timeout, ok := d.timeouts[service+"."+method]; ok {
+		return timeout
+	}
+	if timeout, ok := d.timeouts[method]; ok {
+		return timeout
+	}
+	return d.defaultTimeout
+}
+
+func (d *deadlineInterceptor) withDeadline(ctx context.Context, procedure string) (
+	context.Context, context.CancelFunc) {
+
+	if timeout := d.timeout(procedure); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// contextError replaces the error an RPC failed with if the RPC's context
+// is done, since then that is why it failed.
+func contextError(ctx context.Context, procedure string, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+	code := connect.CodeCanceled
+	counter := rpcsCanceled
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		code = connect.CodeDeadlineExceeded
+		counter = rpcsDeadlineExceeded
+	}
+	counter.Add(procedure, 1)
+	log.Info().Err(err).Str("procedure", procedure).Str("code", code.String()).Msg("rpc-stopped")
+	return connect.NewError(code, ctx.Err())
+}
+
+func (d *deadlineInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+		procedure := req.Spec().Procedure
+		ctx, cancel := d.withDeadline(ctx, procedure)
+		defer cancel()
+		resp, err := next(ctx, req)
+		return resp, contextError(ctx, procedure, err)
+	}
+}
+
+func (d *deadlineInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (d *deadlineInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		procedure := conn.Spec().Procedure
+		ctx, cancel := d.withDeadline(ctx, procedure)
+		defer cancel()
+		return contextError(ctx, procedure, next(ctx, conn))
+	}
+}
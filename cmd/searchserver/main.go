@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"expvar"
 	"fmt"
 	"io"
 	"net/http"
@@ -84,15 +86,44 @@ func main() {
 		Config: cfg,
 	}
 	wordvaultServer := wordvault.NewServer(cfg, dbPool, queries, searchServer)
-	mux.Handle("/plainsearch", plainTextHandler(wordSearchServer, anagramServer))
+	mux.Handle("/plainsearch", plainTextHandler(searchServer, wordSearchServer, anagramServer, cfg.RequestTimeout))
+
+	lexica := searchserver.RegistryFor(cfg)
+	mux.Handle("/lexicon-status", lexiconStatusHandler(lexica))
+	mux.Handle("/debug/vars", expvar.Handler())
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	defer stopWatching()
+	if cfg.LexiconReloadEvery > 0 {
+		go lexica.Watch(watchCtx, cfg.LexiconReloadEvery)
+	} else if _, err := lexica.ReloadChanged(); err != nil {
+		// This just takes the baseline that SIGHUP compares against.
+		log.Err(err).Msg("reload-changed-lexica")
+	}
+	go func() {
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		for range hup {
+			reloaded, err := lexica.ReloadChanged()
+			log.Err(err).Strs("reloaded", reloaded).Msg("got-sighup")
+		}
+	}()
 
 	api := http.NewServeMux()
 
-	interceptors := connect.WithInterceptors(NewAuthInterceptor([]byte(cfg.SecretKey)))
-
-	api.Handle(wordsearcherconnect.NewAnagrammerHandler(anagramServer))
-	api.Handle(wordsearcherconnect.NewQuestionSearcherHandler(searchServer))
-	api.Handle(wordsearcherconnect.NewWordSearcherHandler(wordSearchServer))
+	timeouts, err := parseRequestTimeouts(cfg.RequestTimeouts)
+	if err != nil {
+		panic(err)
+	}
+	deadlineInterceptor := NewDeadlineInterceptor(cfg.RequestTimeout, timeouts)
+	deadlines := connect.WithInterceptors(deadlineInterceptor)
+	interceptors := connect.WithInterceptors(deadlineInterceptor, NewAuthInterceptor([]byte(cfg.SecretKey)))
+	// Signed-in users can run bigger searches.
+	optionalAuth := connect.WithInterceptors(deadlineInterceptor,
+		NewOptionalAuthInterceptor([]byte(cfg.SecretKey)))
+
+	api.Handle(wordsearcherconnect.NewAnagrammerHandler(anagramServer, deadlines))
+	api.Handle(wordsearcherconnect.NewQuestionSearcherHandler(searchServer, optionalAuth))
+	api.Handle(wordsearcherconnect.NewWordSearcherHandler(wordSearchServer, deadlines))
 	// Only this latter service requires user auth:
 	api.Handle(wordvaultconnect.NewWordVaultServiceHandler(wordvaultServer, interceptors))
 
@@ -137,6 +168,10 @@ func main() {
 			log.Error().Msgf("HTTP server Shutdown: %v", err)
 		}
 		cancel()
+		stopWatching()
+		if err := searchserver.CloseRegistries(); err != nil {
+			log.Error().Err(err).Msg("closing lexicon databases")
+		}
 		close(idleConnsClosed)
 	}()
 
@@ -147,6 +182,17 @@ func main() {
 	log.Info().Msg("server gracefully shutting down")
 }
 
+// lexiconStatusHandler shows the version and checksum of every lexicon
+// database being served.
+func lexiconStatusHandler(lexica *searchserver.DBRegistry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(lexica.Loaded()); err != nil {
+			log.Err(err).Msg("lexicon-status")
+		}
+	}
+}
+
 func importCardboxHandler(queries *models.Queries, searchServer *searchserver.Server, dbPool *pgxpool.Pool, secretKey []byte) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx, err := authenticateJWT(r.Context(), r.Header, secretKey)
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"sort"
 	"strings"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/domino14/word_db_server/api/rpc/wordsearcher"
@@ -16,6 +18,8 @@ import (
 
 const (
 	txtLimit = 375
+	// The most alphagrams a query search returns.
+	searchLimit = 100
 )
 
 func writeError(w http.ResponseWriter, err string) {
@@ -23,9 +27,15 @@ func writeError(w http.ResponseWriter, err string) {
 	w.Write([]byte(err))
 }
 
-func plainTextHandler(wordSearchServer *searchserver.WordSearchServer, anagramserver *anagramserver.Server) http.Handler {
+func plainTextHandler(searchServer *searchserver.Server, wordSearchServer *searchserver.WordSearchServer,
+	anagramserver *anagramserver.Server, timeout time.Duration) http.Handler {
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if timeout > 0 {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			r = r.WithContext(ctx)
+		}
 		method, ok := r.URL.Query()["method"]
 		if !ok || len(method[0]) < 1 {
 			writeError(w, "method required")
@@ -39,7 +49,9 @@ func plainTextHandler(wordSearchServer *searchserver.WordSearchServer, anagramse
 		case "pattern":
 			patternSearch(wordSearchServer, w, r)
 		case "related":
-			definitionSearch(wordSearchServer, w, r)
+			relatedWords(wordSearchServer, w, r)
+		case "search":
+			querySearch(searchServer, w, r)
 		default:
 			writeError(w, "method not found")
 		}
@@ -145,26 +157,82 @@ func patternSearch(wsServer *searchserver.WordSearchServer, w http.ResponseWrite
 	writeWords(w, res.Msg.Words)
 }
 
-func definitionSearch(wsServer *searchserver.WordSearchServer, w http.ResponseWriter, r *http.Request) {
-	pattern, ok := r.URL.Query()["pattern"]
-	if !ok || len(pattern[0]) < 1 {
-		writeError(w, "pattern required")
+func relatedWords(wsServer *searchserver.WordSearchServer, w http.ResponseWriter, r *http.Request) {
+	word, ok := r.URL.Query()["word"]
+	if !ok || len(word[0]) < 1 {
+		// The bot used to send its search as a pattern.
+		word, ok = r.URL.Query()["pattern"]
+	}
+	if !ok || len(word[0]) < 1 {
+		writeError(w, "word required")
 		return
 	}
 	lexicon, ok := r.URL.Query()["lexicon"]
 	if !ok || len(lexicon[0]) < 1 {
 		lexicon = []string{"CSW24"}
 	}
-	res, err := wsServer.WordSearch(r.Context(), connect.NewRequest(&wordsearcher.WordSearchRequest{
-		Lexicon: lexicon[0], Glob: "*" + pattern[0] + "*", AppliesTo: "definition",
+	res, err := wsServer.GetRelatedWords(r.Context(), connect.NewRequest(&wordsearcher.DefineRequest{
+		Lexicon: lexicon[0], Word: word[0],
 	}))
 	if err != nil {
+		if connect.CodeOf(err) == connect.CodeNotFound {
+			w.Write([]byte("word " + word[0] + " not found."))
+			return
+		}
 		writeError(w, err.Error())
 		return
 	}
-	if len(res.Msg.Words) == 0 {
+	if len(res.Msg.Inflections) == 0 && len(res.Msg.Variants) == 0 {
 		w.Write([]byte("no related words."))
 		return
 	}
-	writeWords(w, res.Msg.Words)
+	var s strings.Builder
+	writeList := func(label string, words []*wordsearcher.Word) {
+		if len(words) == 0 || s.Len() > txtLimit {
+			return
+		}
+		s.WriteString(label + ": ")
+		for _, w := range words {
+			s.WriteString(w.Word + w.LexiconSymbols + " ")
+			if s.Len() > txtLimit {
+				s.WriteString(" (...truncated)")
+				return
+			}
+		}
+	}
+	writeList("root", res.Msg.Roots)
+	writeList("inflections", res.Msg.Inflections)
+	writeList("variants", res.Msg.Variants)
+	w.Write([]byte(strings.TrimSpace(s.String())))
+}
+
+func querySearch(searchServer *searchserver.Server, w http.ResponseWriter, r *http.Request) {
+	query, ok := r.URL.Query()["q"]
+	if !ok || len(query[0]) < 1 {
+		writeError(w, "q required")
+		return
+	}
+	lexicon, ok := r.URL.Query()["lexicon"]
+	if !ok || len(lexicon[0]) < 1 {
+		lexicon = []string{"CSW24"}
+	}
+	res, err := searchServer.SearchByQuery(r.Context(), connect.NewRequest(&wordsearcher.SearchByQueryRequest{
+		Query: query[0], DefaultLexicon: lexicon[0], PageSize: searchLimit,
+	}))
+	if err != nil {
+		writeError(w, err.Error())
+		return
+	}
+	words := []*wordsearcher.Word{}
+	for _, a := range res.Msg.Alphagrams {
+		words = append(words, a.Words...)
+	}
+	if len(words) == 0 {
+		w.Write([]byte("no words match this search."))
+		return
+	}
+	writeWords(w, words)
+	if res.Msg.NextPageToken != "" {
+		w.Write([]byte(fmt.Sprintf(" (only the first %d alphagrams)", searchLimit)))
+	}
 }
@@ -1,19 +1,29 @@
 package config
 
 import (
+	"time"
+
 	"github.com/namsral/flag"
 )
 
 type Config struct {
-	DataPath             string
-	LogLevel             string
-	DBMigrationsPath     string
-	DBConnUri            string
-	SecretKey            string
-	MaxNonmemberCards    int
-	MaxCardsAdd          int
-	SmallJitterOnAddCard bool
-	MaxQueryResults      int
+	DataPath               string
+	LogLevel               string
+	DBMigrationsPath       string
+	DBConnUri              string
+	SecretKey              string
+	MaxNonmemberCards      int
+	MaxCardsAdd            int
+	SmallJitterOnAddCard   bool
+	MaxQueryResults        int
+	MaxSearchCost          int
+	MaxAnonymousSearchCost int
+	MaxLexiconConns        int
+	LexiconReloadEvery     time.Duration
+	RequestTimeout         time.Duration
+	RequestTimeouts        string
+	SearchCacheSize        int
+	SearchCacheTTL         time.Duration
 }
 
 // Load loads the configs from the given arguments
@@ -28,6 +38,14 @@ func (c *Config) Load(args []string) error {
 	fs.IntVar(&c.MaxNonmemberCards, "max-nonmember-cards", 10000, "maximum total cards for non-members")
 	fs.BoolVar(&c.SmallJitterOnAddCard, "jitter-on-addcard", true, "add small jitter in time due when first adding card")
 	fs.IntVar(&c.MaxQueryResults, "max-query-results", 150000, "maximum results from a single search query to prevent OOM")
+	fs.IntVar(&c.MaxSearchCost, "max-search-cost", 100000, "the most words an unpaginated search by a signed-in user can match (0 for no limit)")
+	fs.IntVar(&c.MaxAnonymousSearchCost, "max-anonymous-search-cost", 20000, "the most words an unpaginated search by an anonymous caller can match (0 for no limit); clients that make bigger searches have to ask for them with page_size")
+	fs.IntVar(&c.MaxLexiconConns, "max-lexicon-conns", 8, "maximum concurrent queries against a single lexicon database")
+	fs.DurationVar(&c.LexiconReloadEvery, "lexicon-reload-interval", 0, "how often to check for changed lexicon databases and KWGs (0 to only check on SIGHUP)")
+	fs.DurationVar(&c.RequestTimeout, "request-timeout", 30*time.Second, "the longest an RPC can run before it is cancelled (0 for no limit)")
+	fs.StringVar(&c.RequestTimeouts, "request-timeouts", "", "per-RPC overrides of request-timeout, e.g. Search=10s,SearchStream=0,WordVaultService.GetCards=5s")
+	fs.IntVar(&c.SearchCacheSize, "search-cache-size", 200000, "how many words of search results and expanded alphagrams to keep cached, each (0 to turn caching off)")
+	fs.DurationVar(&c.SearchCacheTTL, "search-cache-ttl", 10*time.Minute, "how long search results stay cached (0 for no limit)")
 	err := fs.Parse(args)
 	return err
 }
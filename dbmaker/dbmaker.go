@@ -89,7 +89,7 @@ type LexiconSymbolDefinition struct {
 	Symbol string // The corresponding lexicon symbol
 }
 
-const CurrentVersion = 6
+const CurrentVersion = 15
 
 func exitIfError(err error) {
 	if err != nil {
@@ -115,14 +115,41 @@ func createSqliteDb(outputDir string, lexiconName string, quitIfExists bool) (
 	CREATE TABLE alphagrams (probability int, alphagram varchar(20),
 	    length int, combinations int, num_anagrams int,
 		point_value int, num_vowels int, contains_word_uniq_to_lex_split int,
-		contains_update_to_lex int, difficulty int);
+		contains_update_to_lex int, difficulty int, playability int,
+		num_distinct_letters int, max_letter_repeats int);
 
 	CREATE TABLE words (word varchar(20), alphagram varchar(20),
 	    lexicon_symbols varchar(5), definition varchar(512),
 	    front_hooks varchar(26), back_hooks varchar(26),
-	    inner_front_hook int, inner_back_hook int);
+	    inner_front_hook int, inner_back_hook int,
+	    num_front_hooks int, num_back_hooks int, num_hooks int,
+	    front_extensions varchar(512), back_extensions varchar(512),
+	    num_front_extensions int, num_back_extensions int,
+	    first_lexicon varchar(20), last_lexicon varchar(20),
+	    valid_since_order int);
 
-	CREATE TABLE deletedwords (word varchar(20), length int);
+	CREATE TABLE deletedwords (word varchar(20), length int,
+		first_lexicon varchar(20), last_lexicon varchar(20));
+
+	CREATE TABLE family_lexica (lexicon_name varchar(20), family_order int);
+
+	CREATE TABLE definitions (word varchar(20), sense int,
+		part_of_speech varchar(20), gloss varchar(512),
+		inflections varchar(512), root_word varchar(20));
+
+	CREATE TABLE definition_links (word varchar(20), sense int,
+		linked_word varchar(20), part_of_speech varchar(20), variant int);
+
+	CREATE TABLE alphagram_letters (alphagram varchar(20), letter varchar(4),
+		count int);
+
+	CREATE TABLE stems (stem varchar(20), stem_length int, num_added int,
+		num_bingos int);
+
+	CREATE TABLE lexicon_info (lexicon_name varchar(20),
+		descriptive_name varchar(255), family varchar(20),
+		prior_lexicon varchar(20), letter_distribution varchar(50),
+		has_difficulty int, has_playability int);
 
 	CREATE INDEX alpha_index on alphagrams(alphagram);
 	CREATE INDEX prob_index on alphagrams(probability, length);
@@ -130,12 +157,30 @@ func createSqliteDb(outputDir string, lexiconName string, quitIfExists bool) (
 	CREATE INDEX alphagram_index on words(alphagram);
 	CREATE INDEX length_index on alphagrams(length);
 	CREATE INDEX difficulty_index on alphagrams(difficulty);
+	CREATE INDEX playability_index on alphagrams(playability);
 
 	CREATE INDEX num_anagrams_index on alphagrams(num_anagrams);
 	CREATE INDEX point_value_index on alphagrams(point_value);
 	CREATE INDEX num_vowels_index on alphagrams(num_vowels);
 	CREATE INDEX uniq_word_index on alphagrams(contains_word_uniq_to_lex_split);
 	CREATE INDEX update_word_index on alphagrams(contains_update_to_lex);
+	CREATE INDEX num_distinct_letters_index on alphagrams(num_distinct_letters);
+	CREATE INDEX max_letter_repeats_index on alphagrams(max_letter_repeats);
+	CREATE INDEX letter_count_index on alphagram_letters(letter, count);
+	CREATE INDEX letter_alphagram_index on alphagram_letters(alphagram);
+	CREATE INDEX num_front_hooks_index on words(num_front_hooks);
+	CREATE INDEX num_back_hooks_index on words(num_back_hooks);
+	CREATE INDEX num_hooks_index on words(num_hooks);
+	CREATE INDEX num_front_extensions_index on words(num_front_extensions);
+	CREATE INDEX num_back_extensions_index on words(num_back_extensions);
+	CREATE INDEX stem_index on stems(stem_length, num_added, num_bingos);
+	CREATE INDEX first_lexicon_index on words(first_lexicon);
+	CREATE INDEX valid_since_index on words(valid_since_order);
+	CREATE INDEX definition_word_index on definitions(word);
+	CREATE INDEX part_of_speech_index on definitions(part_of_speech);
+	CREATE INDEX root_word_index on definitions(root_word);
+	CREATE INDEX definition_link_word_index on definition_links(word);
+	CREATE INDEX linked_word_index on definition_links(linked_word);
 
 	CREATE TABLE db_version (version integer);
 	`
@@ -160,8 +205,9 @@ func CreateLexiconDatabase(lexiconName string, lexiconInfo *LexiconInfo, lexMap
 		return
 	}
 
-	definitions, alphagrams := populateAlphsDefs(lexiconInfo.LexiconFilename,
+	rawDefinitions, alphagrams := readWordList(lexiconInfo.LexiconFilename,
 		lexiconInfo.Combinations, lexiconInfo.LetterDistribution)
+	definitions := expandDefinitions(rawDefinitions)
 	log.Debug().Msg("Sorting by probability")
 	alphs := alphaMapValues(alphagrams)
 	sort.Sort(AlphByCombos(alphs))
@@ -171,12 +217,18 @@ func CreateLexiconDatabase(lexiconName string, lexiconInfo *LexiconInfo, lexMap
 	alphInsertQuery := `
 	INSERT INTO alphagrams(probability, alphagram, length, combinations,
 		num_anagrams, point_value, num_vowels, contains_word_uniq_to_lex_split,
-		contains_update_to_lex, difficulty)
-	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		contains_update_to_lex, difficulty, playability, num_distinct_letters,
+		max_letter_repeats)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 	wordInsertQuery := `
 	INSERT INTO words (word, alphagram, lexicon_symbols, definition,
-		front_hooks, back_hooks, inner_front_hook, inner_back_hook)
-	VALUES(?, ?, ?, ?, ?, ?, ?, ?)`
+		front_hooks, back_hooks, inner_front_hook, inner_back_hook,
+		num_front_hooks, num_back_hooks, num_hooks, front_extensions,
+		back_extensions, num_front_extensions, num_back_extensions)
+	VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	letterInsertQuery := `
+	INSERT INTO alphagram_letters (alphagram, letter, count)
+	VALUES(?, ?, ?)`
 
 	db, err := sql.Open("sqlite3", dbName)
 	exitIfError(err)
@@ -187,8 +239,11 @@ func CreateLexiconDatabase(lexiconName string, lexiconInfo *LexiconInfo, lexMap
 	exitIfError(err)
 	wordStmt, err := tx.Prepare(wordInsertQuery)
 	exitIfError(err)
+	letterStmt, err := tx.Prepare(letterInsertQuery)
+	exitIfError(err)
 	defer alphStmt.Close()
 	defer wordStmt.Close()
+	defer letterStmt.Close()
 	// lexKWG := lexiconInfo.KWG
 
 	lexFamily, err := lexMap.familyName(lexiconName)
@@ -196,8 +251,12 @@ func CreateLexiconDatabase(lexiconName string, lexiconInfo *LexiconInfo, lexMap
 
 	latestCSW := lexMap.newestInFamily(FamilyCSW)
 	latestTWL := lexMap.newestInFamily(FamilyTWL)
-	latestCSW.Initialize()
-	latestTWL.Initialize()
+	if latestCSW != nil {
+		latestCSW.Initialize()
+	}
+	if latestTWL != nil {
+		latestTWL.Initialize()
+	}
 
 	priorLex, err := lexMap.priorLexicon(lexFamily, lexiconName)
 	if err != nil {
@@ -238,22 +297,35 @@ func CreateLexiconDatabase(lexiconName string, lexiconInfo *LexiconInfo, lexMap
 			def := definitions[word]
 			alphagram := alph.alphagram
 			theseLexSymbols := findLexSymbols(word, latestCSW, latestTWL, lexFamily, priorLex)
+			hooks := findWordHooks(lexiconInfo.KWG, wordML, lexiconInfo.LetterDistribution.TileMapping())
 			wordStmt.Exec(word, alphagram, theseLexSymbols, def,
-				frontHooks, backHooks, frontInnerHook, backInnerHook)
+				frontHooks, backHooks, frontInnerHook, backInnerHook,
+				hooks.numFrontHooks, hooks.numBackHooks, hooks.numHooks(),
+				strings.Join(hooks.frontExtensions, " "), strings.Join(hooks.backExtensions, " "),
+				len(hooks.frontExtensions), len(hooks.backExtensions))
 			lexSymbolsList = append(lexSymbolsList, theseLexSymbols)
 		}
 
+		letterCounts := alph.letterCounts(lexiconInfo.LetterDistribution)
 		_, err = alphStmt.Exec(probs[wl], alph.alphagram, wl, alph.combinations,
 			len(alph.words), alph.pointValue(lexiconInfo.LetterDistribution),
 			alph.numVowels(lexiconInfo.LetterDistribution),
 			containsWordUniqueToLexSplit(lexSymbolsList),
 			containsUpdateToLex(lexSymbolsList),
-			alphagramDifficulty(alph.alphagram, lexiconInfo.Difficulties, containsUpdateToLex(lexSymbolsList) == uint8(1)))
+			alphagramDifficulty(alph.alphagram, lexiconInfo.Difficulties, containsUpdateToLex(lexSymbolsList) == uint8(1)),
+			alphagramPlayability(alph.alphagram, lexiconInfo.Playabilities),
+			len(letterCounts), maxLetterRepeats(letterCounts))
 		exitIfError(err)
+		insertLetterCounts(letterStmt, alph.alphagram, letterCounts)
 
 	}
 	tx.Commit()
 
+	loadStems(db, lexiconInfo.LetterDistribution)
+	loadDefinitions(db, rawDefinitions)
+	loadDefinitionLinks(db, rawDefinitions)
+	writeLexiconMetadata(db, lexiconName, lexiconInfo, lexMap)
+
 	deletedWords := []string{}
 	// Check for deletions.
 	if priorLex != nil {
@@ -288,6 +360,8 @@ func CreateLexiconDatabase(lexiconName string, lexiconInfo *LexiconInfo, lexMap
 		tx.Commit()
 		wordStmt.Close()
 	}
+	loadWordHistory(db, lexiconName, lexiconInfo, lexMap)
+	loadDefinitionSearch(db)
 
 	_, err = db.Exec("INSERT INTO db_version(version) VALUES(?)", CurrentVersion)
 	exitIfError(err)
@@ -323,8 +397,9 @@ func FixDefinitions(lexiconName string, lexMap LexiconMap) {
 	exitIfError(err)
 	lexiconInfo.Initialize()
 
-	definitions, _ := populateAlphsDefs(lexiconInfo.LexiconFilename,
+	rawDefinitions, _ := readWordList(lexiconInfo.LexiconFilename,
 		lexiconInfo.Combinations, lexiconInfo.LetterDistribution)
+	definitions := expandDefinitions(rawDefinitions)
 
 	definitionEditQuery := `
 	UPDATE words SET definition = ? WHERE word = ?
@@ -344,6 +419,18 @@ func FixDefinitions(lexiconName string, lexMap LexiconMap) {
 	tx.Commit()
 
 	defStmt.Close()
+	// Older databases don't have the definitions table until they're
+	// migrated, which fills it in anyway.
+	var version int
+	if err := db.QueryRow("SELECT version FROM db_version").Scan(&version); err == nil && version >= 13 {
+		loadDefinitions(db, rawDefinitions)
+		if version >= 14 {
+			loadDefinitionLinks(db, rawDefinitions)
+		}
+		if version >= 15 {
+			loadDefinitionSearch(db)
+		}
+	}
 	db.Close()
 
 }
@@ -435,7 +522,7 @@ func FixLexiconSymbols(lexiconName string, lexMap LexiconMap) {
 // CREATE INDEX alphagram_index on words(alphagram);
 // `
 // This function assumes the above schema.
-func MigrateLexiconDatabase(lexiconName string, lexiconInfo *LexiconInfo) {
+func MigrateLexiconDatabase(lexiconName string, lexiconInfo *LexiconInfo, lexMap LexiconMap) {
 	dbName := "./" + lexiconName + ".db"
 
 	db, err := sql.Open("sqlite3", dbName)
@@ -492,6 +579,42 @@ func MigrateLexiconDatabase(lexiconName string, lexiconInfo *LexiconInfo) {
 		log.Info().Msg("Migrating to version 6...")
 		migrateToV6(db)
 	}
+	if version == 6 {
+		log.Info().Msg("Migrating to version 7...")
+		migrateToV7(db, lexiconInfo)
+	}
+	if version == 7 {
+		log.Info().Msg("Migrating to version 8...")
+		migrateToV8(db, lexiconInfo.LetterDistribution)
+	}
+	if version == 8 {
+		log.Info().Msg("Migrating to version 9...")
+		migrateToV9(db, lexiconInfo)
+	}
+	if version == 9 {
+		log.Info().Msg("Migrating to version 10...")
+		migrateToV10(db, lexiconInfo.LetterDistribution)
+	}
+	if version == 10 {
+		log.Info().Msg("Migrating to version 11...")
+		migrateToV11(db, lexiconName, lexiconInfo, lexMap)
+	}
+	if version == 11 {
+		log.Info().Msg("Migrating to version 12...")
+		migrateToV12(db, lexiconName, lexiconInfo, lexMap)
+	}
+	if version == 12 {
+		log.Info().Msg("Migrating to version 13...")
+		migrateToV13(db, lexiconInfo)
+	}
+	if version == 13 {
+		log.Info().Msg("Migrating to version 14...")
+		migrateToV14(db, lexiconInfo)
+	}
+	if version == 14 {
+		log.Info().Msg("Migrating to version 15...")
+		migrateToV15(db)
+	}
 
 }
 
@@ -654,10 +777,8 @@ func migrateToV4(db *sql.DB) {
 
 func migrateToV5(db *sql.DB, lexiconInfo *LexiconInfo) {
 	_, err := db.Exec(`
-	-- ALTER TABLE alphagrams ADD COLUMN playability int;
 	ALTER TABLE alphagrams ADD COLUMN difficulty int;
 
-	-- CREATE INDEX playability_index on alphagrams(playability);
 	CREATE INDEX difficulty_index on alphagrams(difficulty);
 	`)
 	exitIfError(err)
@@ -682,6 +803,169 @@ func migrateToV6(db *sql.DB) {
 	exitIfError(err)
 }
 
+func migrateToV7(db *sql.DB, lexiconInfo *LexiconInfo) {
+	_, err := db.Exec(`
+	ALTER TABLE alphagrams ADD COLUMN playability int;
+
+	CREATE INDEX playability_index on alphagrams(playability);
+	`)
+	exitIfError(err)
+	log.Info().Msg("Created playability column and index")
+
+	loadPlayability(db, lexiconInfo)
+
+	_, err = db.Exec("UPDATE db_version SET version = ?", 7)
+	exitIfError(err)
+}
+
+func migrateToV8(db *sql.DB, dist *tilemapping.LetterDistribution) {
+	_, err := db.Exec(`
+	ALTER TABLE alphagrams ADD COLUMN num_distinct_letters int;
+	ALTER TABLE alphagrams ADD COLUMN max_letter_repeats int;
+
+	CREATE TABLE alphagram_letters (alphagram varchar(20), letter varchar(4),
+		count int);
+
+	CREATE INDEX num_distinct_letters_index on alphagrams(num_distinct_letters);
+	CREATE INDEX max_letter_repeats_index on alphagrams(max_letter_repeats);
+	CREATE INDEX letter_count_index on alphagram_letters(letter, count);
+	CREATE INDEX letter_alphagram_index on alphagram_letters(alphagram);
+	`)
+	exitIfError(err)
+	log.Info().Msg("Created letter count columns, table and indices")
+
+	loadLetterCounts(db, dist)
+
+	_, err = db.Exec("UPDATE db_version SET version = ?", 8)
+	exitIfError(err)
+}
+
+func migrateToV9(db *sql.DB, lexiconInfo *LexiconInfo) {
+	_, err := db.Exec(`
+	ALTER TABLE words ADD COLUMN num_front_hooks int;
+	ALTER TABLE words ADD COLUMN num_back_hooks int;
+	ALTER TABLE words ADD COLUMN num_hooks int;
+	ALTER TABLE words ADD COLUMN front_extensions varchar(512);
+	ALTER TABLE words ADD COLUMN back_extensions varchar(512);
+	ALTER TABLE words ADD COLUMN num_front_extensions int;
+	ALTER TABLE words ADD COLUMN num_back_extensions int;
+
+	CREATE INDEX num_front_hooks_index on words(num_front_hooks);
+	CREATE INDEX num_back_hooks_index on words(num_back_hooks);
+	CREATE INDEX num_hooks_index on words(num_hooks);
+	CREATE INDEX num_front_extensions_index on words(num_front_extensions);
+	CREATE INDEX num_back_extensions_index on words(num_back_extensions);
+	`)
+	exitIfError(err)
+	log.Info().Msg("Created hook count columns and indices")
+
+	loadWordHooks(db, lexiconInfo)
+
+	_, err = db.Exec("UPDATE db_version SET version = ?", 9)
+	exitIfError(err)
+}
+
+func migrateToV10(db *sql.DB, dist *tilemapping.LetterDistribution) {
+	_, err := db.Exec(`
+	CREATE TABLE stems (stem varchar(20), stem_length int, num_added int,
+		num_bingos int);
+
+	CREATE INDEX stem_index on stems(stem_length, num_added, num_bingos);
+	`)
+	exitIfError(err)
+	log.Info().Msg("Created stems table and index")
+
+	loadStems(db, dist)
+
+	_, err = db.Exec("UPDATE db_version SET version = ?", 10)
+	exitIfError(err)
+}
+
+func migrateToV11(db *sql.DB, lexiconName string, lexiconInfo *LexiconInfo, lexMap LexiconMap) {
+	_, err := db.Exec(`
+	CREATE TABLE lexicon_info (lexicon_name varchar(20),
+		descriptive_name varchar(255), family varchar(20),
+		prior_lexicon varchar(20), letter_distribution varchar(50),
+		has_difficulty int, has_playability int);
+	`)
+	exitIfError(err)
+	log.Info().Msg("Created lexicon info table")
+
+	writeLexiconMetadata(db, lexiconName, lexiconInfo, lexMap)
+
+	_, err = db.Exec("UPDATE db_version SET version = ?", 11)
+	exitIfError(err)
+}
+
+func migrateToV12(db *sql.DB, lexiconName string, lexiconInfo *LexiconInfo, lexMap LexiconMap) {
+	_, err := db.Exec(`
+	ALTER TABLE words ADD COLUMN first_lexicon varchar(20);
+	ALTER TABLE words ADD COLUMN last_lexicon varchar(20);
+	ALTER TABLE words ADD COLUMN valid_since_order int;
+	ALTER TABLE deletedwords ADD COLUMN first_lexicon varchar(20);
+	ALTER TABLE deletedwords ADD COLUMN last_lexicon varchar(20);
+
+	CREATE TABLE family_lexica (lexicon_name varchar(20), family_order int);
+
+	CREATE INDEX first_lexicon_index on words(first_lexicon);
+	CREATE INDEX valid_since_index on words(valid_since_order);
+	`)
+	exitIfError(err)
+	log.Info().Msg("Created word history columns, table and indices")
+
+	loadWordHistory(db, lexiconName, lexiconInfo, lexMap)
+
+	_, err = db.Exec("UPDATE db_version SET version = ?", 12)
+	exitIfError(err)
+}
+
+func migrateToV13(db *sql.DB, lexiconInfo *LexiconInfo) {
+	_, err := db.Exec(`
+	CREATE TABLE definitions (word varchar(20), sense int,
+		part_of_speech varchar(20), gloss varchar(512),
+		inflections varchar(512), root_word varchar(20));
+
+	CREATE INDEX definition_word_index on definitions(word);
+	CREATE INDEX part_of_speech_index on definitions(part_of_speech);
+	CREATE INDEX root_word_index on definitions(root_word);
+	`)
+	exitIfError(err)
+	log.Info().Msg("Created definitions table and indices")
+
+	definitions, _ := readWordList(lexiconInfo.LexiconFilename, lexiconInfo.Combinations,
+		lexiconInfo.LetterDistribution)
+	loadDefinitions(db, definitions)
+
+	_, err = db.Exec("UPDATE db_version SET version = ?", 13)
+	exitIfError(err)
+}
+
+func migrateToV14(db *sql.DB, lexiconInfo *LexiconInfo) {
+	_, err := db.Exec(`
+	CREATE TABLE definition_links (word varchar(20), sense int,
+		linked_word varchar(20), part_of_speech varchar(20), variant int);
+
+	CREATE INDEX definition_link_word_index on definition_links(word);
+	CREATE INDEX linked_word_index on definition_links(linked_word);
+	`)
+	exitIfError(err)
+	log.Info().Msg("Created definition_links table and indices")
+
+	definitions, _ := readWordList(lexiconInfo.LexiconFilename, lexiconInfo.Combinations,
+		lexiconInfo.LetterDistribution)
+	loadDefinitionLinks(db, definitions)
+
+	_, err = db.Exec("UPDATE db_version SET version = ?", 14)
+	exitIfError(err)
+}
+
+func migrateToV15(db *sql.DB) {
+	loadDefinitionSearch(db)
+
+	_, err := db.Exec("UPDATE db_version SET version = ?", 15)
+	exitIfError(err)
+}
+
 func findLexSymbols(word string, latestCSW, latestTWL *LexiconInfo, lexFamily FamilyName,
 	priorLex *LexiconInfo) string {
 
@@ -692,11 +976,11 @@ func findLexSymbols(word string, latestCSW, latestTWL *LexiconInfo, lexFamily Fa
 			symbols += LexiconUpdateSymbol
 		}
 	}
-	if lexFamily == FamilyCSW && !kwg.FindWord(latestTWL.KWG, word) &&
+	if lexFamily == FamilyCSW && latestTWL != nil && !kwg.FindWord(latestTWL.KWG, word) &&
 		!strings.Contains(symbols, CSWOnlySymbol) {
 		symbols += CSWOnlySymbol
 	}
-	if lexFamily == FamilyTWL && !kwg.FindWord(latestCSW.KWG, word) &&
+	if lexFamily == FamilyTWL && latestCSW != nil && !kwg.FindWord(latestCSW.KWG, word) &&
 		!strings.Contains(symbols, TWLOnlySymbol) {
 		symbols += TWLOnlySymbol
 	}
@@ -747,6 +1031,15 @@ func alphaMapValues(theMap map[string]Alphagram) []Alphagram {
 func populateAlphsDefs(filename string, combinations func(string, bool) uint64,
 	dist *tilemapping.LetterDistribution) (map[string]string, map[string]Alphagram) {
 
+	definitions, alphagrams := readWordList(filename, combinations, dist)
+	return expandDefinitions(definitions), alphagrams
+}
+
+// readWordList reads a word list file, returning the definitions as they
+// are in the file.
+func readWordList(filename string, combinations func(string, bool) uint64,
+	dist *tilemapping.LetterDistribution) (map[string]*FullDefinition, map[string]Alphagram) {
+
 	definitions := make(map[string]*FullDefinition)
 	alphagrams := make(map[string]Alphagram)
 	file, err := os.Open(filename)
@@ -787,7 +1080,5 @@ func populateAlphsDefs(filename string, combinations func(string, bool) uint64,
 	}
 	file.Close()
 
-	definitionMap := expandDefinitions(definitions)
-
-	return definitionMap, alphagrams
+	return definitions, alphagrams
 }
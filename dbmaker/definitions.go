@@ -1,8 +1,10 @@
 package dbmaker
 
 import (
+	"database/sql"
 	"html"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/rs/zerolog/log"
@@ -34,6 +36,7 @@ var linkRe = regexp.MustCompile("{([[:alpha:]]+)=([[:alpha:]]+)}")
 var htmlRe = regexp.MustCompile("{([[:alpha:]]+)}")
 var rootRe = regexp.MustCompile("<([[:alpha:]]+)=([[:alpha:]]+)>")
 var inflectionRe = regexp.MustCompile(`\[([[:alpha:]]+)(\s*[[:ascii:]]*)\]`)
+var declensionSepRe = regexp.MustCompile(`[\s,]+`)
 
 // Expand all the entities, links, etc from the definitions. Return a map
 // with just user-readable string definitions.
@@ -244,3 +247,143 @@ func findLinkText(link string, pospeech string, definitions map[string]*FullDefi
 
 	return ""
 }
+
+// A definitionSense is one sense of a word's definition, split into the
+// parts we store in the definitions table.
+type definitionSense struct {
+	word         string
+	sense        int
+	partOfSpeech string
+	// gloss is the expanded sense, without the part of speech and
+	// inflections.
+	gloss       string
+	inflections []string
+	// rootWord is the word this one is an inflection of, from a <root=pos>
+	// link.
+	rootWord string
+}
+
+// senses splits the definition into its senses. Links are expanded the
+// same way they are for the flattened definition.
+func (fd *FullDefinition) senses(definitions map[string]*FullDefinition) []definitionSense {
+	senses := []definitionSense{}
+	for idx, part := range fd.parts {
+		if strings.TrimSpace(part.raw) == "" {
+			continue
+		}
+		sense := definitionSense{
+			word:         fd.word,
+			sense:        idx,
+			partOfSpeech: part.partOfSpeech,
+			gloss: strings.TrimSpace(expandRaw(part.nopospeech, part.word, definitions,
+				make(map[string]bool), false)),
+			inflections: []string{},
+		}
+		for _, infl := range declensionSepRe.Split(part.declensions, -1) {
+			if infl != "" {
+				sense.inflections = append(sense.inflections, infl)
+			}
+		}
+		if root := rootRe.FindStringSubmatch(part.raw); len(root) > 1 {
+			sense.rootWord = strings.ToUpper(root[1])
+		}
+		senses = append(senses, sense)
+	}
+	return senses
+}
+
+// loadDefinitions replaces the contents of the definitions table with the
+// senses of every word.
+func loadDefinitions(db *sql.DB, definitions map[string]*FullDefinition) {
+	words := make([]string, 0, len(definitions))
+	for word := range definitions {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+
+	tx, err := db.Begin()
+	exitIfError(err)
+	_, err = tx.Exec("DELETE FROM definitions")
+	exitIfError(err)
+	stmt, err := tx.Prepare(`
+		INSERT INTO definitions (word, sense, part_of_speech, gloss, inflections,
+			root_word)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`)
+	exitIfError(err)
+	numSenses := 0
+	for _, word := range words {
+		for _, s := range definitions[word].senses(definitions) {
+			_, err := stmt.Exec(s.word, s.sense, s.partOfSpeech, s.gloss,
+				strings.Join(s.inflections, " "), s.rootWord)
+			exitIfError(err)
+			numSenses++
+		}
+	}
+	stmt.Close()
+	exitIfError(tx.Commit())
+	log.Info().Msgf("Stored %d senses for %d words", numSenses, len(words))
+}
+
+// A definitionLink is a {word=pos} cross-reference in a sense of a
+// definition.
+type definitionLink struct {
+	word         string
+	sense        int
+	linkedWord   string
+	partOfSpeech string
+	// variant is true if the link is the whole sense, as in EMEER's
+	// {emir=n} [n EMEERS]; the word is then another form of the linked word.
+	variant bool
+}
+
+func (fd *FullDefinition) links() []definitionLink {
+	links := []definitionLink{}
+	for idx, part := range fd.parts {
+		matches := linkRe.FindAllStringSubmatch(part.nopospeech, -1)
+		variant := len(matches) == 1 &&
+			strings.TrimSpace(linkRe.ReplaceAllString(part.nopospeech, "")) == ""
+		for _, m := range matches {
+			links = append(links, definitionLink{
+				word:         fd.word,
+				sense:        idx,
+				linkedWord:   strings.ToUpper(m[1]),
+				partOfSpeech: m[2],
+				variant:      variant,
+			})
+		}
+	}
+	return links
+}
+
+// loadDefinitionLinks replaces the contents of the definition_links table
+// with the cross-references in every definition.
+func loadDefinitionLinks(db *sql.DB, definitions map[string]*FullDefinition) {
+	words := make([]string, 0, len(definitions))
+	for word := range definitions {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+
+	tx, err := db.Begin()
+	exitIfError(err)
+	_, err = tx.Exec("DELETE FROM definition_links")
+	exitIfError(err)
+	stmt, err := tx.Prepare(`
+		INSERT INTO definition_links (word, sense, linked_word, part_of_speech,
+			variant)
+		VALUES (?, ?, ?, ?, ?)
+	`)
+	exitIfError(err)
+	numLinks := 0
+	for _, word := range words {
+		for _, l := range definitions[word].links() {
+			_, err := stmt.Exec(l.word, l.sense, l.linkedWord, l.partOfSpeech, l.variant)
+			exitIfError(err)
+			numLinks++
+		}
+	}
+	stmt.Close()
+	exitIfError(tx.Commit())
+	log.Info().Msgf("Stored %d definition links", numLinks)
+}
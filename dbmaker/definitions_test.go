@@ -1,8 +1,10 @@
 package dbmaker
 
 import (
+	"database/sql"
 	"testing"
 
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -240,3 +242,83 @@ func TestPaver(t *testing.T) {
 		"PAVE":  "to cover with material that forms a firm, level surface [v PAVED, PAVES, PAVING]",
 	}, userVisibleDefinitions)
 }
+
+func TestSenses(t *testing.T) {
+	definitions := map[string]*FullDefinition{}
+	addToDefinitions(
+		"OS",
+		`a bone [n OSSA] / an {esker=n} [n OSAR]`,
+		definitions)
+	addToDefinitions("ESKER", `a narrow ridge of gravel and sand [n ESKERS]`,
+		definitions)
+	addToDefinitions("OSSA", `<os=n> [n]`, definitions)
+	addToDefinitions("HEX", `to cast an evil spell upon [v HEXED, HEXES, HEXING]`,
+		definitions)
+
+	assert.Equal(t, []definitionSense{
+		{word: "OS", sense: 0, partOfSpeech: "n", gloss: "a bone",
+			inflections: []string{"OSSA"}},
+		{word: "OS", sense: 1, partOfSpeech: "n",
+			gloss:       "an esker (a narrow ridge of gravel and sand)",
+			inflections: []string{"OSAR"}},
+	}, definitions["OS"].senses(definitions))
+
+	assert.Equal(t, []definitionSense{
+		{word: "OSSA", sense: 0, partOfSpeech: "n", gloss: "OS, a bone",
+			inflections: []string{}, rootWord: "OS"},
+	}, definitions["OSSA"].senses(definitions))
+
+	assert.Equal(t, []string{"HEXED", "HEXES", "HEXING"},
+		definitions["HEX"].senses(definitions)[0].inflections)
+}
+
+func TestLoadDefinitions(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.Nil(t, err)
+	defer db.Close()
+	_, err = db.Exec(`CREATE TABLE definitions (word varchar(20), sense int,
+		part_of_speech varchar(20), gloss varchar(512),
+		inflections varchar(512), root_word varchar(20))`)
+	assert.Nil(t, err)
+
+	definitions := map[string]*FullDefinition{}
+	addToDefinitions("HEX", `to cast an evil spell upon [v HEXED, HEXES, HEXING] / a {hexagon=n} [n HEXES]`,
+		definitions)
+	addToDefinitions("HEXED", `<hex=v> [v]`, definitions)
+	// Loading again replaces what was there.
+	loadDefinitions(db, definitions)
+	loadDefinitions(db, definitions)
+
+	rows, err := db.Query(`SELECT word, sense, part_of_speech, inflections, root_word
+		FROM definitions ORDER BY word, sense`)
+	assert.Nil(t, err)
+	defer rows.Close()
+	got := [][]interface{}{}
+	for rows.Next() {
+		var word, pos, inflections, root string
+		var sense int
+		assert.Nil(t, rows.Scan(&word, &sense, &pos, &inflections, &root))
+		got = append(got, []interface{}{word, sense, pos, inflections, root})
+	}
+	assert.Equal(t, [][]interface{}{
+		{"HEX", 0, "v", "HEXED HEXES HEXING", ""},
+		{"HEX", 1, "n", "HEXES", ""},
+		{"HEXED", 0, "v", "", "HEX"},
+	}, got)
+}
+
+func TestDefinitionLinks(t *testing.T) {
+	definitions := map[string]*FullDefinition{}
+	addToDefinitions("EMEER", `{emir=n} [n EMEERS]`, definitions)
+	addToDefinitions("HIPNESS", `the state of being {hip=adj} [n HIPNESSES]`, definitions)
+	addToDefinitions("EMEERS", `<emeer=n> [n]`, definitions)
+
+	assert.Equal(t, []definitionLink{
+		{word: "EMEER", sense: 0, linkedWord: "EMIR", partOfSpeech: "n", variant: true},
+	}, definitions["EMEER"].links())
+	assert.Equal(t, []definitionLink{
+		{word: "HIPNESS", sense: 0, linkedWord: "HIP", partOfSpeech: "adj"},
+	}, definitions["HIPNESS"].links())
+	// Root links are stored with the senses, not here.
+	assert.Empty(t, definitions["EMEERS"].links())
+}
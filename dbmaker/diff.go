@@ -0,0 +1,243 @@
+package dbmaker
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/domino14/word-golib/kwg"
+	"github.com/domino14/word-golib/tilemapping"
+)
+
+// ChangeKind is the kind of difference between two lexica.
+type ChangeKind string
+
+const (
+	ChangeAdded      ChangeKind = "added"
+	ChangeDeleted    ChangeKind = "deleted"
+	ChangeAnagrams   ChangeKind = "anagrams"
+	ChangeHooks      ChangeKind = "hooks"
+	ChangeDefinition ChangeKind = "definition"
+)
+
+// changeOrder is the order the kinds of changes are reported in.
+var changeOrder = map[ChangeKind]int{
+	ChangeAdded: 0, ChangeDeleted: 1, ChangeAnagrams: 2, ChangeHooks: 3, ChangeDefinition: 4,
+}
+
+// A LexiconChange is one difference between two lexica. For anagram
+// changes, Word is the alphagram and Before and After are its words. For
+// hook changes, Before and After are the front and back hooks, separated
+// by a colon.
+type LexiconChange struct {
+	Kind      ChangeKind `json:"kind"`
+	Word      string     `json:"word"`
+	Alphagram string     `json:"alphagram"`
+	Length    int        `json:"length"`
+	Before    string     `json:"before,omitempty"`
+	After     string     `json:"after,omitempty"`
+}
+
+// A LexiconDiff is everything that changed from one lexicon to another.
+type LexiconDiff struct {
+	From    string          `json:"from"`
+	To      string          `json:"to"`
+	Counts  map[string]int  `json:"counts"`
+	Changes []LexiconChange `json:"changes"`
+}
+
+// DiffLexica compares two lexica that use the same letter distribution,
+// usually a lexicon and its prior one. Both must be initialized, and need
+// their KWGs for hook changes.
+func DiffLexica(from, to *LexiconInfo) (*LexiconDiff, error) {
+	if from.LetterDistribution.Name != to.LetterDistribution.Name {
+		return nil, fmt.Errorf("%v uses the %v distribution but %v uses %v",
+			from.LexiconName, from.LetterDistribution.Name,
+			to.LexiconName, to.LetterDistribution.Name)
+	}
+	if from.KWG == nil || to.KWG == nil {
+		return nil, fmt.Errorf("both %v and %v need a KWG", from.LexiconName, to.LexiconName)
+	}
+	fromDefs, fromAlphs := populateAlphsDefs(from.LexiconFilename, from.Combinations,
+		from.LetterDistribution)
+	toDefs, toAlphs := populateAlphsDefs(to.LexiconFilename, to.Combinations,
+		to.LetterDistribution)
+	tm := to.LetterDistribution.TileMapping()
+
+	diff := &LexiconDiff{From: from.LexiconName, To: to.LexiconName, Counts: map[string]int{}}
+	add := func(c LexiconChange) {
+		diff.Changes = append(diff.Changes, c)
+		diff.Counts[string(c.Kind)]++
+	}
+
+	wordAlphagrams := func(alphs map[string]Alphagram) map[string]string {
+		m := map[string]string{}
+		for alph, a := range alphs {
+			for _, w := range a.words {
+				m[w] = alph
+			}
+		}
+		return m
+	}
+	fromWords, toWords := wordAlphagrams(fromAlphs), wordAlphagrams(toAlphs)
+
+	for _, word := range sortedKeys(toWords) {
+		length, err := wordLength(word, tm)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := fromWords[word]; !ok {
+			add(LexiconChange{Kind: ChangeAdded, Word: word, Alphagram: toWords[word],
+				Length: length, After: toDefs[word]})
+			continue
+		}
+		hooksBefore, err := hookString(from.KWG, word, from.LetterDistribution.TileMapping())
+		if err != nil {
+			return nil, err
+		}
+		hooksAfter, err := hookString(to.KWG, word, tm)
+		if err != nil {
+			return nil, err
+		}
+		if hooksBefore != hooksAfter {
+			add(LexiconChange{Kind: ChangeHooks, Word: word, Alphagram: toWords[word],
+				Length: length, Before: hooksBefore, After: hooksAfter})
+		}
+		if fromDefs[word] != toDefs[word] {
+			add(LexiconChange{Kind: ChangeDefinition, Word: word, Alphagram: toWords[word],
+				Length: length, Before: fromDefs[word], After: toDefs[word]})
+		}
+	}
+	for _, word := range sortedKeys(fromWords) {
+		if _, ok := toWords[word]; ok {
+			continue
+		}
+		length, err := wordLength(word, tm)
+		if err != nil {
+			return nil, err
+		}
+		add(LexiconChange{Kind: ChangeDeleted, Word: word, Alphagram: fromWords[word],
+			Length: length, Before: fromDefs[word]})
+	}
+	// Alphagrams that are new or gone altogether are already covered by the
+	// added and deleted words; these are the ones that gained or lost an
+	// anagram.
+	for _, alph := range sortedKeys(toAlphs) {
+		fromAlph, ok := fromAlphs[alph]
+		if !ok {
+			continue
+		}
+		before, after := sortedWords(fromAlph.words), sortedWords(toAlphs[alph].words)
+		if before == after {
+			continue
+		}
+		length, err := wordLength(alph, tm)
+		if err != nil {
+			return nil, err
+		}
+		add(LexiconChange{Kind: ChangeAnagrams, Word: alph, Alphagram: alph,
+			Length: length, Before: before, After: after})
+	}
+	sort.SliceStable(diff.Changes, func(i, j int) bool {
+		a, b := diff.Changes[i], diff.Changes[j]
+		if a.Kind != b.Kind {
+			return changeOrder[a.Kind] < changeOrder[b.Kind]
+		}
+		return a.Length < b.Length
+	})
+	return diff, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedWords(words []string) string {
+	words = append([]string{}, words...)
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+func wordLength(word string, tm *tilemapping.TileMapping) (int, error) {
+	mls, err := tilemapping.ToMachineLetters(word, tm)
+	return len(mls), err
+}
+
+// hookString returns the front and back hooks of a word as FRONT:BACK.
+func hookString(g *kwg.KWG, word string, tm *tilemapping.TileMapping) (string, error) {
+	mls, err := tilemapping.ToMachineLetters(word, tm)
+	if err != nil {
+		return "", err
+	}
+	front := tilemapping.MachineWord(kwg.FindHooks(g, mls, kwg.FrontHooks)).UserVisible(tm)
+	back := tilemapping.MachineWord(kwg.FindHooks(g, mls, kwg.BackHooks)).UserVisible(tm)
+	return front + ":" + back, nil
+}
+
+func (d *LexiconDiff) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
+
+// WriteCSV writes one row per change, with a header.
+func (d *LexiconDiff) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"kind", "word", "alphagram", "length", "before", "after"})
+	for _, c := range d.Changes {
+		cw.Write([]string{string(c.Kind), c.Word, c.Alphagram, fmt.Sprint(c.Length),
+			c.Before, c.After})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// StoreChanges saves the diff in the lexicon_changes table of a lexicon
+// database, usually the newer lexicon's, where the search server's
+// CHANGED_SINCE condition looks for them. Changes from the same prior
+// lexicon that were stored before are replaced.
+func (d *LexiconDiff) StoreChanges(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec(`
+	CREATE TABLE IF NOT EXISTS lexicon_changes (prior_lexicon varchar(20),
+		change varchar(20), word varchar(20), alphagram varchar(20),
+		length int, before varchar(512), after varchar(512));
+	CREATE INDEX IF NOT EXISTS lexicon_changes_index
+		on lexicon_changes(prior_lexicon, change, length);
+	`)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec("DELETE FROM lexicon_changes WHERE prior_lexicon = ?", d.From); err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(`
+	INSERT INTO lexicon_changes (prior_lexicon, change, word, alphagram,
+		length, before, after)
+	VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, c := range d.Changes {
+		_, err := stmt.Exec(d.From, string(c.Kind), c.Word, c.Alphagram, c.Length,
+			c.Before, c.After)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
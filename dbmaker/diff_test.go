@@ -0,0 +1,105 @@
+package dbmaker
+
+import (
+	"bytes"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/domino14/word-golib/tilemapping"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domino14/word_db_server/internal/kwgtest"
+)
+
+func makeDiffLexicon(t *testing.T, ld *tilemapping.LetterDistribution, name string,
+	lines []string) *LexiconInfo {
+
+	fileName := filepath.Join(t.TempDir(), name+".txt")
+	assert.Nil(t, os.WriteFile(fileName, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+	words := make([]string, len(lines))
+	for i, l := range lines {
+		words[i] = strings.Fields(l)[0]
+	}
+	g, err := kwgtest.Build(words, ld.TileMapping())
+	assert.Nil(t, err)
+	info := &LexiconInfo{LexiconName: name, LexiconFilename: fileName, KWG: g,
+		LetterDistribution: ld}
+	info.Initialize()
+	return info
+}
+
+func TestDiffLexica(t *testing.T) {
+	ld, err := tilemapping.ScanLetterDistribution(strings.NewReader(miniDistribution))
+	assert.Nil(t, err)
+	ld.Name = "mini"
+	from := makeDiffLexicon(t, ld, "MINI1", []string{
+		"ACE a playing card", "CASE a container", "HOSE a flexible pipe", "SHOE a covering for the foot",
+	})
+	to := makeDiffLexicon(t, ld, "MINI2", []string{
+		"ACE a playing card, or an expert", "ACES ACE, a playing card", "CASE a container",
+		"HOSE a flexible pipe",
+	})
+
+	d, err := DiffLexica(from, to)
+	assert.Nil(t, err)
+	assert.Equal(t, "MINI1", d.From)
+	assert.Equal(t, "MINI2", d.To)
+	assert.Equal(t, map[string]int{"added": 1, "deleted": 1, "anagrams": 2, "hooks": 1,
+		"definition": 1}, d.Counts)
+
+	kinds := []string{}
+	for _, c := range d.Changes {
+		kinds = append(kinds, string(c.Kind)+" "+c.Word)
+	}
+	assert.Equal(t, []string{"added ACES", "deleted SHOE", "anagrams ACES", "anagrams EHOS",
+		"hooks ACE", "definition ACE"}, kinds)
+	assert.Equal(t, "CASE", d.Changes[2].Before)
+	assert.Equal(t, "ACES CASE", d.Changes[2].After)
+	assert.Equal(t, "HOSE SHOE", d.Changes[3].Before)
+	assert.Equal(t, "HOSE", d.Changes[3].After)
+	assert.Equal(t, ":", d.Changes[4].Before)
+	assert.Equal(t, ":S", d.Changes[4].After)
+	assert.Equal(t, 3, d.Changes[4].Length)
+
+	var buf bytes.Buffer
+	assert.Nil(t, d.WriteCSV(&buf))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 7, len(lines))
+	assert.Equal(t, "kind,word,alphagram,length,before,after", lines[0])
+	assert.Equal(t, "hooks,ACE,ACE,3,:,:S", lines[5])
+
+	buf.Reset()
+	assert.Nil(t, d.WriteJSON(&buf))
+	assert.Contains(t, buf.String(), `"kind": "deleted"`)
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.Nil(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	// Storing twice replaces the first lot.
+	assert.Nil(t, d.StoreChanges(db))
+	assert.Nil(t, d.StoreChanges(db))
+	var count int
+	assert.Nil(t, db.QueryRow(`SELECT count(*) FROM lexicon_changes
+		WHERE prior_lexicon = 'MINI1'`).Scan(&count))
+	assert.Equal(t, 6, count)
+	var word string
+	assert.Nil(t, db.QueryRow(`SELECT word FROM lexicon_changes
+		WHERE change = 'deleted'`).Scan(&word))
+	assert.Equal(t, "SHOE", word)
+}
+
+func TestDiffLexicaDistributions(t *testing.T) {
+	ld, err := tilemapping.ScanLetterDistribution(strings.NewReader(miniDistribution))
+	assert.Nil(t, err)
+	ld.Name = "mini"
+	other, err := tilemapping.ScanLetterDistribution(strings.NewReader(miniDistribution))
+	assert.Nil(t, err)
+	other.Name = "other"
+	_, err = DiffLexica(&LexiconInfo{LexiconName: "A", LetterDistribution: ld},
+		&LexiconInfo{LexiconName: "B", LetterDistribution: other})
+	assert.ErrorContains(t, err, "A uses the mini distribution but B uses other")
+}
@@ -0,0 +1,42 @@
+package dbmaker
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// FTS5 is only compiled into go-sqlite3 with the sqlite_fts5 build tag. A
+// dbmaker built without it still makes databases; they just don't have
+// the full-text index, and definition searches on them fall back to LIKE.
+
+// ftsUnavailable reports whether an error means SQLite was built without
+// FTS5.
+func ftsUnavailable(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "no such module: fts5")
+}
+
+// loadDefinitionSearch (re)builds the definitions_fts full-text index of the
+// definitions in the words table. Words are stemmed, so a search for
+// "fish" finds "fishes" and "fishing" too.
+func loadDefinitionSearch(db *sql.DB) {
+	_, err := db.Exec(`
+	DROP TABLE IF EXISTS definitions_fts;
+	CREATE VIRTUAL TABLE definitions_fts USING fts5(word UNINDEXED, definition,
+		tokenize = 'porter unicode61');
+	`)
+	if ftsUnavailable(err) {
+		log.Warn().Msg("SQLite was built without FTS5 (build with -tags sqlite_fts5); " +
+			"skipping the definition search index")
+		return
+	}
+	exitIfError(err)
+	_, err = db.Exec(`
+	INSERT INTO definitions_fts (word, definition)
+	SELECT word, definition FROM words WHERE definition != '';
+	INSERT INTO definitions_fts (definitions_fts) VALUES ('optimize');
+	`)
+	exitIfError(err)
+	log.Info().Msg("Built the definition search index")
+}
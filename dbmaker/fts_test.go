@@ -0,0 +1,45 @@
+package dbmaker
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadDefinitionSearch(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.Nil(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec(`
+		CREATE TABLE words (word varchar(20), definition varchar(512));
+		INSERT INTO words VALUES ('EEL', 'a snakelike fish [n EELS]'),
+			('FISHING', 'FISH, to catch fish [v]'), ('CAT', 'a small mammal [n CATS]'),
+			('QI', '');`)
+	assert.Nil(t, err)
+
+	// Loading twice rebuilds the index rather than adding to it.
+	loadDefinitionSearch(db)
+	loadDefinitionSearch(db)
+
+	_, err = db.Exec("SELECT 1 FROM definitions_fts LIMIT 1")
+	if err != nil {
+		// Built without FTS5, so there's no index, and that's fine.
+		assert.Contains(t, err.Error(), "no such table")
+		t.Skip("needs -tags sqlite_fts5")
+	}
+	rows, err := db.Query(`SELECT word FROM definitions_fts WHERE definitions_fts MATCH ?
+		ORDER BY rank`, "fishes")
+	assert.Nil(t, err)
+	defer rows.Close()
+	words := []string{}
+	for rows.Next() {
+		var w string
+		assert.Nil(t, rows.Scan(&w))
+		words = append(words, w)
+	}
+	// FISHING mentions fish twice, so it ranks first.
+	assert.Equal(t, []string{"FISHING", "EEL"}, words)
+}
@@ -0,0 +1,122 @@
+package dbmaker
+
+import (
+	"database/sql"
+
+	"github.com/domino14/word-golib/kwg"
+	"github.com/domino14/word-golib/tilemapping"
+	"github.com/rs/zerolog/log"
+)
+
+// wordHistory is when a word was valid within its lexicon family.
+type wordHistory struct {
+	firstLexicon string
+	lastLexicon  string
+	// validSince is the family order of the first lexicon of the unbroken
+	// run of lexica, ending with the current one, that have the word. It is
+	// -1 if the current lexicon doesn't have the word.
+	validSince int
+}
+
+// findWordHistory looks the word up in every lexicon of the family, oldest
+// first. Lexica without a KWG are skipped; they neither break a run nor
+// count as the first or last lexicon.
+func findWordHistory(mw tilemapping.MachineWord, family LexiconFamily, current int) wordHistory {
+	h := wordHistory{validSince: -1}
+	inLexicon := make([]bool, len(family))
+	for i, lex := range family {
+		if lex.KWG == nil {
+			continue
+		}
+		inLexicon[i] = kwg.FindMachineWord(lex.KWG, mw)
+		if !inLexicon[i] {
+			continue
+		}
+		if h.firstLexicon == "" {
+			h.firstLexicon = lex.LexiconName
+		}
+		h.lastLexicon = lex.LexiconName
+	}
+	if current < 0 || !inLexicon[current] {
+		return h
+	}
+	h.validSince = current
+	for i := current - 1; i >= 0; i-- {
+		if family[i].KWG == nil {
+			continue
+		}
+		if !inLexicon[i] {
+			break
+		}
+		h.validSince = i
+	}
+	return h
+}
+
+// loadWordHistory stores the history of every word and deleted word in the
+// lexicon, along with the order of the lexica in its family.
+func loadWordHistory(db *sql.DB, lexiconName string, lexiconInfo *LexiconInfo, lexMap LexiconMap) {
+	familyName, err := lexMap.familyName(lexiconName)
+	if err != nil {
+		log.Err(err).Str("lexicon", lexiconName).Msg("no family, skipping word history")
+		return
+	}
+	family := lexMap[familyName]
+	current := -1
+	for i, lex := range family {
+		if lex.LexiconName == lexiconName {
+			current = i
+		}
+		if lex.KWG == nil {
+			log.Warn().Str("lexicon", lex.LexiconName).Msg("no kwg, leaving it out of word history")
+		}
+	}
+
+	tx, err := db.Begin()
+	exitIfError(err)
+	_, err = tx.Exec("DELETE FROM family_lexica")
+	exitIfError(err)
+	for i, lex := range family {
+		_, err = tx.Exec("INSERT INTO family_lexica (lexicon_name, family_order) VALUES (?, ?)",
+			lex.LexiconName, i)
+		exitIfError(err)
+	}
+
+	for _, table := range []string{"words", "deletedwords"} {
+		rows, err := tx.Query("SELECT word FROM " + table)
+		exitIfError(err)
+		words := []string{}
+		for rows.Next() {
+			var word string
+			if err := rows.Scan(&word); err != nil {
+				log.Fatal().Err(err).Msg("")
+			}
+			words = append(words, word)
+		}
+		rows.Close()
+
+		query := `UPDATE words SET first_lexicon = ?, last_lexicon = ?,
+			valid_since_order = ? WHERE word = ?`
+		if table == "deletedwords" {
+			query = `UPDATE deletedwords SET first_lexicon = ?, last_lexicon = ?
+				WHERE word = ?`
+		}
+		stmt, err := tx.Prepare(query)
+		exitIfError(err)
+		for _, word := range words {
+			mw, err := tilemapping.ToMachineLetters(word, lexiconInfo.LetterDistribution.TileMapping())
+			exitIfError(err)
+			if table == "words" {
+				h := findWordHistory(mw, family, current)
+				_, err = stmt.Exec(h.firstLexicon, h.lastLexicon, h.validSince, word)
+			} else {
+				h := findWordHistory(mw, family, -1)
+				_, err = stmt.Exec(h.firstLexicon, h.lastLexicon, word)
+			}
+			exitIfError(err)
+		}
+		stmt.Close()
+		log.Info().Msgf("Stored the history of %d %v", len(words), table)
+	}
+	exitIfError(tx.Commit())
+}
@@ -0,0 +1,96 @@
+package dbmaker
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/domino14/word-golib/tilemapping"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domino14/word_db_server/internal/kwgtest"
+)
+
+func makeHistoryFamily(t *testing.T, ld *tilemapping.LetterDistribution,
+	wordLists map[string][]string, names ...string) LexiconFamily {
+
+	family := LexiconFamily{}
+	for _, name := range names {
+		info := &LexiconInfo{LexiconName: name, LetterDistribution: ld}
+		if words, ok := wordLists[name]; ok {
+			g, err := kwgtest.Build(words, ld.TileMapping())
+			assert.Nil(t, err)
+			info.KWG = g
+		}
+		family = append(family, info)
+	}
+	return family
+}
+
+func TestFindWordHistory(t *testing.T) {
+	ld, err := tilemapping.ScanLetterDistribution(strings.NewReader(miniDistribution))
+	assert.Nil(t, err)
+	tm := ld.TileMapping()
+	// MINI3 has no KWG, so it is skipped.
+	family := makeHistoryFamily(t, ld, map[string][]string{
+		"MINI1": {"ACE", "CASE", "HOSE"},
+		"MINI2": {"ACE", "HOSE", "SHOE"},
+		"MINI4": {"ACE", "CASE", "SHOE"},
+	}, "MINI1", "MINI2", "MINI3", "MINI4")
+
+	history := func(word string, current int) wordHistory {
+		mw, err := tilemapping.ToMachineLetters(word, tm)
+		assert.Nil(t, err)
+		return findWordHistory(mw, family, current)
+	}
+	assert.Equal(t, wordHistory{"MINI1", "MINI4", 0}, history("ACE", 3))
+	// CASE was dropped and brought back.
+	assert.Equal(t, wordHistory{"MINI1", "MINI4", 3}, history("CASE", 3))
+	assert.Equal(t, wordHistory{"MINI2", "MINI4", 1}, history("SHOE", 3))
+	assert.Equal(t, wordHistory{"MINI1", "MINI2", 0}, history("HOSE", 1))
+	// Deleted words have no run.
+	assert.Equal(t, wordHistory{"MINI1", "MINI2", -1}, history("HOSE", -1))
+	assert.Equal(t, wordHistory{"", "", -1}, history("ECHO", -1))
+}
+
+func TestLoadWordHistory(t *testing.T) {
+	ld, err := tilemapping.ScanLetterDistribution(strings.NewReader(miniDistribution))
+	assert.Nil(t, err)
+	family := makeHistoryFamily(t, ld, map[string][]string{
+		"MINI1": {"ACE", "HOSE"},
+		"MINI2": {"ACE", "CASE"},
+	}, "MINI1", "MINI2")
+	lexMap := LexiconMap{"MINI": family}
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.Nil(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec(`
+		CREATE TABLE words (word varchar(20), first_lexicon varchar(20),
+			last_lexicon varchar(20), valid_since_order int);
+		CREATE TABLE deletedwords (word varchar(20), length int,
+			first_lexicon varchar(20), last_lexicon varchar(20));
+		CREATE TABLE family_lexica (lexicon_name varchar(20), family_order int);
+		INSERT INTO words (word) VALUES ('ACE'), ('CASE');
+		INSERT INTO deletedwords (word, length) VALUES ('HOSE', 4);`)
+	assert.Nil(t, err)
+
+	loadWordHistory(db, "MINI2", family[1], lexMap)
+
+	var first, last string
+	var since int
+	assert.Nil(t, db.QueryRow(`SELECT first_lexicon, last_lexicon, valid_since_order
+		FROM words WHERE word = 'ACE'`).Scan(&first, &last, &since))
+	assert.Equal(t, []interface{}{"MINI1", "MINI2", 0}, []interface{}{first, last, since})
+	assert.Nil(t, db.QueryRow(`SELECT first_lexicon, last_lexicon, valid_since_order
+		FROM words WHERE word = 'CASE'`).Scan(&first, &last, &since))
+	assert.Equal(t, []interface{}{"MINI2", "MINI2", 1}, []interface{}{first, last, since})
+	assert.Nil(t, db.QueryRow(`SELECT first_lexicon, last_lexicon
+		FROM deletedwords WHERE word = 'HOSE'`).Scan(&first, &last))
+	assert.Equal(t, []interface{}{"MINI1", "MINI1"}, []interface{}{first, last})
+	var order int
+	assert.Nil(t, db.QueryRow(`SELECT family_order FROM family_lexica
+		WHERE lexicon_name = 'MINI2'`).Scan(&order))
+	assert.Equal(t, 1, order)
+}
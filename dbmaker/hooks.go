@@ -0,0 +1,132 @@
+package dbmaker
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/domino14/word-golib/kwg"
+	"github.com/domino14/word-golib/tilemapping"
+	"github.com/rs/zerolog/log"
+)
+
+// wordHooks holds the hook and extension counts we store for every word.
+type wordHooks struct {
+	numFrontHooks   int
+	numBackHooks    int
+	frontExtensions []string
+	backExtensions  []string
+}
+
+func (h wordHooks) numHooks() int {
+	return h.numFrontHooks + h.numBackHooks
+}
+
+func findWordHooks(g *kwg.KWG, word tilemapping.MachineWord, alph *tilemapping.TileMapping) wordHooks {
+	return wordHooks{
+		numFrontHooks:   len(kwg.FindHooks(g, word, kwg.FrontHooks)),
+		numBackHooks:    len(kwg.FindHooks(g, word, kwg.BackHooks)),
+		frontExtensions: extensionStrings(twoLetterExtensions(g, word, true), alph),
+		backExtensions:  extensionStrings(twoLetterExtensions(g, word, false), alph),
+	}
+}
+
+// followPath returns the arc index reached by following path from the
+// given node list, or 0 if the path isn't in the graph.
+func followPath(g *kwg.KWG, nodeIdx uint32, path tilemapping.MachineWord) uint32 {
+	for _, ml := range path {
+		if nodeIdx == 0 {
+			return 0
+		}
+		nodeIdx = g.NextNodeIdx(nodeIdx, ml)
+	}
+	return nodeIdx
+}
+
+// twoLetterExtensions finds the pairs of tiles that can go in front of
+// (or behind) a word to make a new word. Back extensions follow the word
+// in the DAWG; front extensions follow the reversed word in the GADDAG,
+// where a path like rev(word) + Y + X spells out the word XY + word.
+func twoLetterExtensions(g *kwg.KWG, word tilemapping.MachineWord, front bool) []tilemapping.MachineWord {
+	var nodeIdx uint32
+	if front {
+		rev := make(tilemapping.MachineWord, len(word))
+		for i, ml := range word {
+			rev[len(word)-1-i] = ml
+		}
+		nodeIdx = followPath(g, g.ArcIndex(1), rev)
+	} else {
+		nodeIdx = followPath(g, g.ArcIndex(0), word)
+	}
+	exts := []tilemapping.MachineWord{}
+	if nodeIdx == 0 {
+		return exts
+	}
+	for i := nodeIdx; ; i++ {
+		// Tile 0 is the GADDAG separator; skip it.
+		if first := tilemapping.MachineLetter(g.Tile(i)); first != 0 && g.ArcIndex(i) != 0 {
+			for j := g.ArcIndex(i); ; j++ {
+				second := tilemapping.MachineLetter(g.Tile(j))
+				if second != 0 && g.Accepts(j) {
+					if front {
+						exts = append(exts, tilemapping.MachineWord{second, first})
+					} else {
+						exts = append(exts, tilemapping.MachineWord{first, second})
+					}
+				}
+				if g.IsEnd(j) {
+					break
+				}
+			}
+		}
+		if g.IsEnd(i) {
+			break
+		}
+	}
+	return exts
+}
+
+func extensionStrings(exts []tilemapping.MachineWord, alph *tilemapping.TileMapping) []string {
+	strs := make([]string, len(exts))
+	for i, ext := range exts {
+		strs[i] = ext.UserVisible(alph)
+	}
+	return strs
+}
+
+func loadWordHooks(db *sql.DB, lexInfo *LexiconInfo) {
+	rows, err := db.Query(`SELECT word FROM words`)
+	exitIfError(err)
+	words := []string{}
+	for rows.Next() {
+		var word string
+		if err := rows.Scan(&word); err != nil {
+			log.Fatal().Err(err).Msg("")
+		}
+		words = append(words, word)
+	}
+	rows.Close()
+
+	tx, err := db.Begin()
+	exitIfError(err)
+	updateStmt, err := tx.Prepare(`
+		UPDATE words SET num_front_hooks = ?, num_back_hooks = ?, num_hooks = ?,
+			front_extensions = ?, back_extensions = ?,
+			num_front_extensions = ?, num_back_extensions = ?
+		WHERE word = ?
+	`)
+	exitIfError(err)
+	for i, word := range words {
+		mw, err := tilemapping.ToMachineLetters(word, lexInfo.LetterDistribution.TileMapping())
+		exitIfError(err)
+		h := findWordHooks(lexInfo.KWG, mw, lexInfo.LetterDistribution.TileMapping())
+		_, err = updateStmt.Exec(h.numFrontHooks, h.numBackHooks, h.numHooks(),
+			strings.Join(h.frontExtensions, " "), strings.Join(h.backExtensions, " "),
+			len(h.frontExtensions), len(h.backExtensions), word)
+		exitIfError(err)
+		if (i+1)%10000 == 0 {
+			log.Debug().Msgf("%d...", i+1)
+		}
+	}
+	updateStmt.Close()
+	exitIfError(tx.Commit())
+}
@@ -0,0 +1,40 @@
+package dbmaker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/domino14/word-golib/tilemapping"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domino14/word_db_server/internal/kwgtest"
+)
+
+func TestFindWordHooks(t *testing.T) {
+	ld, err := tilemapping.ScanLetterDistribution(strings.NewReader(miniDistribution))
+	assert.Nil(t, err)
+	tm := ld.TileMapping()
+	g, err := kwgtest.Build([]string{
+		"ACE", "ACES", "CACE", "CHACE", "SOCACE", "ACESO", "ACECH", "OCACE",
+		"ACHE", "ACHES",
+	}, tm)
+	assert.Nil(t, err)
+
+	mw, err := tilemapping.ToMachineLetters("ACE", tm)
+	assert.Nil(t, err)
+	h := findWordHooks(g, mw, tm)
+	// CH is a single tile, so CHACE and ACECH are hooks rather than
+	// extensions.
+	assert.Equal(t, 2, h.numFrontHooks)
+	assert.Equal(t, 2, h.numBackHooks)
+	assert.Equal(t, 4, h.numHooks())
+	assert.Equal(t, []string{"OC"}, h.frontExtensions)
+	assert.Equal(t, []string{"SO"}, h.backExtensions)
+
+	mw, err = tilemapping.ToMachineLetters("ACHES", tm)
+	assert.Nil(t, err)
+	h = findWordHooks(g, mw, tm)
+	assert.Equal(t, 0, h.numHooks())
+	assert.Empty(t, h.frontExtensions)
+	assert.Empty(t, h.backExtensions)
+}
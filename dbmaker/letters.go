@@ -0,0 +1,90 @@
+package dbmaker
+
+import (
+	"database/sql"
+
+	"github.com/domino14/word-golib/tilemapping"
+	"github.com/rs/zerolog/log"
+)
+
+// letterCount is how many times a tile shows up in an alphagram.
+type letterCount struct {
+	letter string
+	count  int
+}
+
+// letterCounts returns the count of every distinct tile in the alphagram,
+// in alphagram order. Tiles are written the way the letter distribution
+// writes them, so multi-character tiles are a single letter here.
+func (a *Alphagram) letterCounts(dist *tilemapping.LetterDistribution) []letterCount {
+	mls, err := tilemapping.ToMachineLetters(a.alphagram, dist.TileMapping())
+	if err != nil {
+		panic(err)
+	}
+	counts := []letterCount{}
+	idx := map[tilemapping.MachineLetter]int{}
+	for _, ml := range mls {
+		i, ok := idx[ml]
+		if !ok {
+			i = len(counts)
+			idx[ml] = i
+			counts = append(counts, letterCount{letter: dist.TileMapping().Letter(ml)})
+		}
+		counts[i].count++
+	}
+	return counts
+}
+
+func maxLetterRepeats(counts []letterCount) int {
+	mx := 0
+	for _, lc := range counts {
+		mx = max(mx, lc.count)
+	}
+	return mx
+}
+
+// insertLetterCounts writes one alphagram_letters row per distinct tile.
+func insertLetterCounts(stmt *sql.Stmt, alphagram string, counts []letterCount) {
+	for _, lc := range counts {
+		_, err := stmt.Exec(alphagram, lc.letter, lc.count)
+		exitIfError(err)
+	}
+}
+
+func loadLetterCounts(db *sql.DB, dist *tilemapping.LetterDistribution) {
+	rows, err := db.Query(`SELECT alphagram FROM alphagrams`)
+	exitIfError(err)
+	alphagrams := []Alphagram{}
+	for rows.Next() {
+		var alph string
+		if err := rows.Scan(&alph); err != nil {
+			log.Fatal().Err(err).Msg("")
+		}
+		alphagrams = append(alphagrams, Alphagram{alphagram: alph})
+	}
+	rows.Close()
+
+	tx, err := db.Begin()
+	exitIfError(err)
+	updateStmt, err := tx.Prepare(`
+		UPDATE alphagrams SET num_distinct_letters = ?, max_letter_repeats = ?
+		WHERE alphagram = ?
+	`)
+	exitIfError(err)
+	letterStmt, err := tx.Prepare(`
+		INSERT INTO alphagram_letters (alphagram, letter, count) VALUES (?, ?, ?)
+	`)
+	exitIfError(err)
+	for i, alph := range alphagrams {
+		counts := alph.letterCounts(dist)
+		_, err := updateStmt.Exec(len(counts), maxLetterRepeats(counts), alph.alphagram)
+		exitIfError(err)
+		insertLetterCounts(letterStmt, alph.alphagram, counts)
+		if (i+1)%10000 == 0 {
+			log.Debug().Msgf("%d...", i+1)
+		}
+	}
+	updateStmt.Close()
+	letterStmt.Close()
+	exitIfError(tx.Commit())
+}
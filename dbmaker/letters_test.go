@@ -0,0 +1,37 @@
+package dbmaker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/domino14/word-golib/tilemapping"
+	"github.com/stretchr/testify/assert"
+)
+
+// A tiny distribution with a multi-character tile.
+const miniDistribution = `?,2,0,0
+A,12,1,1
+C,4,3,0
+CH,1,5,0
+E,12,1,1
+H,2,4,0
+O,9,1,1
+S,6,1,0
+`
+
+func TestLetterCounts(t *testing.T) {
+	ld, err := tilemapping.ScanLetterDistribution(strings.NewReader(miniDistribution))
+	assert.Nil(t, err)
+
+	a := &Alphagram{alphagram: "ACHCHEOS"}
+	counts := a.letterCounts(ld)
+	assert.Equal(t, []letterCount{
+		{"A", 1}, {"CH", 2}, {"E", 1}, {"O", 1}, {"S", 1},
+	}, counts)
+	assert.Equal(t, 2, maxLetterRepeats(counts))
+
+	a = &Alphagram{alphagram: "ACEHO"}
+	counts = a.letterCounts(ld)
+	assert.Equal(t, 5, len(counts))
+	assert.Equal(t, 1, maxLetterRepeats(counts))
+}
@@ -64,7 +64,11 @@ func (m LexiconMap) familyName(lexiconName string) (FamilyName, error) {
 	return "", errors.New("not found")
 }
 
+// newestInFamily returns nil if the manifest has no such family.
 func (m LexiconMap) newestInFamily(family FamilyName) *LexiconInfo {
+	if len(m[family]) == 0 {
+		return nil
+	}
 	return m[family][len(m[family])-1]
 }
 
@@ -0,0 +1,261 @@
+package dbmaker
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/domino14/word-golib/config"
+	"github.com/domino14/word-golib/tilemapping"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultManifest lists the lexica that are used when the data path doesn't
+// have a manifest of its own.
+//
+//go:embed manifest.yaml
+var defaultManifest []byte
+
+// ManifestFilenames are the names LoadManifest looks for in the lexica
+// directory of the data path, in order. JSON is valid YAML, so the same
+// parser reads both.
+var ManifestFilenames = []string{"manifest.yaml", "manifest.yml", "manifest.json"}
+
+// A Manifest lists the lexica that can be built and served, grouped into
+// families.
+type Manifest struct {
+	Families []ManifestFamily `yaml:"families" json:"families"`
+	// Path is the file the manifest was read from, or empty for the
+	// built-in one.
+	Path string `yaml:"-" json:"-"`
+}
+
+// A ManifestFamily is a word list and its editions, oldest first.
+type ManifestFamily struct {
+	Name   string            `yaml:"name" json:"name"`
+	Lexica []ManifestLexicon `yaml:"lexica" json:"lexica"`
+}
+
+type ManifestLexicon struct {
+	Name string `yaml:"name" json:"name"`
+	// Filename is relative to the lexica directory. It defaults to
+	// <name>.txt.
+	Filename string `yaml:"filename" json:"filename"`
+	// KWG is the name of the graph in lexica/gaddag; it defaults to the
+	// lexicon name.
+	KWG                string `yaml:"kwg" json:"kwg"`
+	Index              uint8  `yaml:"index" json:"index"`
+	DescriptiveName    string `yaml:"descriptive_name" json:"descriptive_name"`
+	LetterDistribution string `yaml:"letter_distribution" json:"letter_distribution"`
+	Difficulty         bool   `yaml:"difficulty" json:"difficulty"`
+	Playability        bool   `yaml:"playability" json:"playability"`
+}
+
+func (l ManifestLexicon) filename() string {
+	if l.Filename != "" {
+		return l.Filename
+	}
+	return l.Name + ".txt"
+}
+
+// KWGName is the name of the lexicon's graph in lexica/gaddag.
+func (l ManifestLexicon) KWGName() string {
+	if l.KWG != "" {
+		return l.KWG
+	}
+	return l.Name
+}
+
+// ManifestPath returns the manifest file in the data path, or an empty
+// string if there isn't one.
+func ManifestPath(dataPath string) string {
+	for _, name := range ManifestFilenames {
+		path := filepath.Join(dataPath, "lexica", name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// LoadManifest reads the manifest in the data path, falling back to the
+// built-in one, and validates it.
+func LoadManifest(dataPath string) (*Manifest, error) {
+	contents := defaultManifest
+	path := ManifestPath(dataPath)
+	if path != "" {
+		var err error
+		contents, err = os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	m, err := ParseManifest(contents)
+	if err != nil {
+		if path != "" {
+			return nil, fmt.Errorf("%v: %w", path, err)
+		}
+		return nil, err
+	}
+	m.Path = path
+	return m, nil
+}
+
+// ParseManifest parses and validates a YAML or JSON manifest.
+func ParseManifest(contents []byte) (*Manifest, error) {
+	m := &Manifest{}
+	if err := yaml.Unmarshal(contents, m); err != nil {
+		return nil, err
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Validate checks that the manifest makes sense on its own: every lexicon
+// is listed once, in a family with a name, and shares its family's letter
+// distribution. It doesn't look at the data path; see CheckFiles for that.
+func (m *Manifest) Validate() error {
+	if len(m.Families) == 0 {
+		return errors.New("the manifest has no lexicon families")
+	}
+	var errs []error
+	families := map[string]bool{}
+	lexica := map[string]string{}
+	indexes := map[uint8]string{}
+	for _, f := range m.Families {
+		if f.Name == "" {
+			errs = append(errs, errors.New("a family has no name"))
+		} else if families[f.Name] {
+			errs = append(errs, fmt.Errorf("family %v is listed more than once", f.Name))
+		}
+		families[f.Name] = true
+		if len(f.Lexica) == 0 {
+			errs = append(errs, fmt.Errorf("family %v has no lexica", f.Name))
+			continue
+		}
+		for _, l := range f.Lexica {
+			if l.Name == "" {
+				errs = append(errs, fmt.Errorf("a lexicon in family %v has no name", f.Name))
+				continue
+			}
+			if strings.ContainsAny(l.Name, `/\`) {
+				errs = append(errs, fmt.Errorf("lexicon name %q can't contain a path separator", l.Name))
+			}
+			if other, ok := lexica[l.Name]; ok {
+				errs = append(errs, fmt.Errorf("lexicon %v is listed in both %v and %v",
+					l.Name, other, f.Name))
+			}
+			lexica[l.Name] = f.Name
+			if l.Index != 0 {
+				if other, ok := indexes[l.Index]; ok {
+					errs = append(errs, fmt.Errorf("lexica %v and %v both have index %d",
+						other, l.Name, l.Index))
+				}
+				indexes[l.Index] = l.Name
+			}
+			if l.LetterDistribution == "" {
+				errs = append(errs, fmt.Errorf("lexicon %v has no letter distribution", l.Name))
+			} else if l.LetterDistribution != f.Lexica[0].LetterDistribution {
+				// A prior lexicon with other tiles makes no sense.
+				errs = append(errs, fmt.Errorf("lexicon %v uses the %v distribution, but %v uses %v",
+					l.Name, l.LetterDistribution, f.Lexica[0].Name, f.Lexica[0].LetterDistribution))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Lexicon finds a lexicon in the manifest, and returns its family and the
+// lexicon before it in the family, if any.
+func (m *Manifest) Lexicon(name string) (lex *ManifestLexicon, family string, prior *ManifestLexicon, ok bool) {
+	for _, f := range m.Families {
+		for i := range f.Lexica {
+			if f.Lexica[i].Name != name {
+				continue
+			}
+			if i > 0 {
+				prior = &f.Lexica[i-1]
+			}
+			return &f.Lexica[i], f.Name, prior, true
+		}
+	}
+	return nil, "", nil, false
+}
+
+// CheckFiles makes sure the word list, KWG and letter distribution of each
+// of the named lexica exist in the data path. With no names, it checks every
+// lexicon in the manifest.
+func (m *Manifest) CheckFiles(dataPath string, names ...string) error {
+	if len(names) == 0 {
+		for _, f := range m.Families {
+			for _, l := range f.Lexica {
+				names = append(names, l.Name)
+			}
+		}
+	}
+	var errs []error
+	for _, name := range names {
+		l, _, _, ok := m.Lexicon(name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("lexicon %v is not in the manifest", name))
+			continue
+		}
+		for _, path := range []string{
+			filepath.Join(dataPath, "lexica", l.filename()),
+			filepath.Join(dataPath, "lexica", "gaddag", l.KWGName()+".kwg"),
+			filepath.Join(dataPath, "letterdistributions", l.LetterDistribution),
+		} {
+			if _, err := os.Stat(path); err != nil {
+				errs = append(errs, fmt.Errorf("lexicon %v: %w", name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LexiconMap loads the letter distributions, KWGs, and difficulty and
+// playability data for every lexicon in the manifest. A missing KWG is only
+// logged, so that lexica that aren't on this machine can be skipped.
+func (m *Manifest) LexiconMap(dataPath string) (LexiconMap, error) {
+	cfg := &config.Config{DataPath: dataPath}
+	lexiconPath := filepath.Join(dataPath, "lexica")
+	dists := map[string]*tilemapping.LetterDistribution{}
+
+	lexMap := LexiconMap{}
+	for _, f := range m.Families {
+		family := LexiconFamily{}
+		for _, l := range f.Lexica {
+			dist, ok := dists[l.LetterDistribution]
+			if !ok {
+				var err error
+				dist, err = tilemapping.NamedLetterDistribution(cfg, l.LetterDistribution)
+				if err != nil {
+					return nil, fmt.Errorf("lexicon %v: %w", l.Name, err)
+				}
+				dists[l.LetterDistribution] = dist
+			}
+			info := &LexiconInfo{
+				LexiconName:        l.Name,
+				LexiconFilename:    filepath.Join(lexiconPath, l.filename()),
+				KWG:                loadKWG(dataPath, l.KWGName()),
+				LexiconIndex:       l.Index,
+				DescriptiveName:    l.DescriptiveName,
+				LetterDistribution: dist,
+			}
+			if l.Difficulty {
+				info.Difficulties = createDifficultyMap(lexiconPath, l.Name)
+			}
+			if l.Playability {
+				info.Playabilities = createPlayabilityMap(lexiconPath, l.Name)
+			}
+			family = append(family, info)
+		}
+		lexMap[FamilyName(f.Name)] = family
+	}
+	return lexMap, nil
+}
@@ -0,0 +1,110 @@
+package dbmaker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultManifest(t *testing.T) {
+	m, err := ParseManifest(defaultManifest)
+	assert.Nil(t, err)
+
+	lex, family, prior, ok := m.Lexicon("CSW19")
+	assert.True(t, ok)
+	assert.Equal(t, "CSW", family)
+	assert.Equal(t, "CSW15", prior.Name)
+	assert.Equal(t, uint8(12), lex.Index)
+	assert.True(t, lex.Difficulty)
+	assert.Equal(t, "CSW19.txt", lex.filename())
+
+	lex, family, prior, ok = m.Lexicon("Deutsch")
+	assert.True(t, ok)
+	assert.Equal(t, "Deutsch", family)
+	assert.Nil(t, prior)
+	assert.Equal(t, "RD28", lex.KWGName())
+
+	_, _, _, ok = m.Lexicon("NOPE")
+	assert.False(t, ok)
+}
+
+func TestParseManifestErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		manifest string
+		err      string
+	}{
+		{"empty", `families: []`, "no lexicon families"},
+		{"no lexica", `families: [{name: CLUB}]`, "family CLUB has no lexica"},
+		{"twice", `
+families:
+  - name: A
+    lexica: [{name: X, letter_distribution: english}]
+  - name: B
+    lexica: [{name: X, letter_distribution: english}]`, "lexicon X is listed in both A and B"},
+		{"same index", `
+families:
+  - name: A
+    lexica:
+      - {name: X, index: 3, letter_distribution: english}
+      - {name: Y, index: 3, letter_distribution: english}`, "lexica X and Y both have index 3"},
+		{"mixed distributions", `
+families:
+  - name: A
+    lexica:
+      - {name: X, letter_distribution: english}
+      - {name: Y, letter_distribution: spanish}`, "lexicon Y uses the spanish distribution, but X uses english"},
+		{"path", `
+families:
+  - name: A
+    lexica: [{name: ../X, letter_distribution: english}]`, "can't contain a path separator"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseManifest([]byte(tc.manifest))
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestLoadManifest(t *testing.T) {
+	dataPath := t.TempDir()
+	m, err := LoadManifest(dataPath)
+	assert.Nil(t, err)
+	assert.Equal(t, "", m.Path)
+	_, _, _, ok := m.Lexicon("NWL23")
+	assert.True(t, ok)
+
+	lexDir := filepath.Join(dataPath, "lexica")
+	assert.Nil(t, os.MkdirAll(filepath.Join(lexDir, "gaddag"), 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(lexDir, "manifest.json"), []byte(`{
+		"families": [{"name": "CLUB", "lexica": [
+			{"name": "CLUB1", "letter_distribution": "english"},
+			{"name": "CLUB2", "filename": "club-2.txt", "letter_distribution": "english"}
+		]}]}`), 0644))
+	m, err = LoadManifest(dataPath)
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(lexDir, "manifest.json"), m.Path)
+	_, _, _, ok = m.Lexicon("NWL23")
+	assert.False(t, ok)
+	_, family, prior, ok := m.Lexicon("CLUB2")
+	assert.True(t, ok)
+	assert.Equal(t, "CLUB", family)
+	assert.Equal(t, "CLUB1", prior.Name)
+
+	assert.Nil(t, os.MkdirAll(filepath.Join(dataPath, "letterdistributions"), 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(dataPath, "letterdistributions", "english"), nil, 0644))
+	assert.Nil(t, os.WriteFile(filepath.Join(lexDir, "club-2.txt"), nil, 0644))
+	assert.Nil(t, os.WriteFile(filepath.Join(lexDir, "gaddag", "CLUB2.kwg"), nil, 0644))
+	assert.Nil(t, m.CheckFiles(dataPath, "CLUB2"))
+	err = m.CheckFiles(dataPath)
+	assert.ErrorContains(t, err, "CLUB1.txt")
+	assert.ErrorContains(t, err, "CLUB1.kwg")
+	assert.ErrorContains(t, m.CheckFiles(dataPath, "CLUB3"), "not in the manifest")
+
+	// A broken manifest is an error, not a reason to use the built-in one.
+	assert.Nil(t, os.WriteFile(filepath.Join(lexDir, "manifest.yaml"), []byte("families: [}"), 0644))
+	_, err = LoadManifest(dataPath)
+	assert.ErrorContains(t, err, "manifest.yaml")
+}
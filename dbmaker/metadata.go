@@ -0,0 +1,39 @@
+package dbmaker
+
+import (
+	"database/sql"
+)
+
+// writeLexiconMetadata stores what we know about a lexicon in its own
+// database, so that the server can describe it without building the whole
+// lexicon map.
+func writeLexiconMetadata(db *sql.DB, lexiconName string, info *LexiconInfo, lexMap LexiconMap) {
+	family, err := lexMap.familyName(lexiconName)
+	if err != nil {
+		family = ""
+	}
+	priorName := ""
+	if prior, err := lexMap.priorLexicon(family, lexiconName); err == nil {
+		priorName = prior.LexiconName
+	}
+	distName := ""
+	if info.LetterDistribution != nil {
+		distName = info.LetterDistribution.Name
+	}
+	hasDifficulty, hasPlayability := 0, 0
+	if len(info.Difficulties) > 0 {
+		hasDifficulty = 1
+	}
+	if len(info.Playabilities) > 0 {
+		hasPlayability = 1
+	}
+
+	_, err = db.Exec("DELETE FROM lexicon_info")
+	exitIfError(err)
+	_, err = db.Exec(`
+	INSERT INTO lexicon_info (lexicon_name, descriptive_name, family,
+		prior_lexicon, letter_distribution, has_difficulty, has_playability)
+	VALUES (?, ?, ?, ?, ?, ?, ?)`, lexiconName, info.DescriptiveName, string(family),
+		priorName, distName, hasDifficulty, hasPlayability)
+	exitIfError(err)
+}
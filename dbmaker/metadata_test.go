@@ -0,0 +1,50 @@
+package dbmaker
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/domino14/word-golib/tilemapping"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteLexiconMetadata(t *testing.T) {
+	ld, err := tilemapping.ScanLetterDistribution(strings.NewReader(miniDistribution))
+	assert.Nil(t, err)
+	ld.Name = "mini"
+	old := &LexiconInfo{LexiconName: "MINI1", LetterDistribution: ld}
+	cur := &LexiconInfo{LexiconName: "MINI2", DescriptiveName: "Mini Lexicon 2",
+		LetterDistribution: ld, Playabilities: map[string]int{"ACE": 1}}
+	lexMap := LexiconMap{"MINI": LexiconFamily{old, cur}}
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.Nil(t, err)
+	defer db.Close()
+	_, err = db.Exec(`CREATE TABLE lexicon_info (lexicon_name varchar(20),
+		descriptive_name varchar(255), family varchar(20),
+		prior_lexicon varchar(20), letter_distribution varchar(50),
+		has_difficulty int, has_playability int)`)
+	assert.Nil(t, err)
+
+	writeLexiconMetadata(db, "MINI2", cur, lexMap)
+	// Writing it again replaces the row.
+	writeLexiconMetadata(db, "MINI2", cur, lexMap)
+
+	var name, desc, family, prior, dist string
+	var hasDifficulty, hasPlayability int
+	var count int
+	assert.Nil(t, db.QueryRow("SELECT count(*) FROM lexicon_info").Scan(&count))
+	assert.Equal(t, 1, count)
+	err = db.QueryRow(`SELECT lexicon_name, descriptive_name, family, prior_lexicon,
+		letter_distribution, has_difficulty, has_playability FROM lexicon_info`).Scan(
+		&name, &desc, &family, &prior, &dist, &hasDifficulty, &hasPlayability)
+	assert.Nil(t, err)
+	assert.Equal(t, "MINI2", name)
+	assert.Equal(t, "Mini Lexicon 2", desc)
+	assert.Equal(t, "MINI", family)
+	assert.Equal(t, "MINI1", prior)
+	assert.Equal(t, "mini", dist)
+	assert.Equal(t, 0, hasDifficulty)
+	assert.Equal(t, 1, hasPlayability)
+}
@@ -0,0 +1,96 @@
+package dbmaker
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+)
+
+// createPlayabilityMap reads playability scores from
+// lexica/playability/<lexiconName>/<length>.csv. Like the difficulty files,
+// each CSV has a header row; we use the "Alphagram" and "playability"
+// columns. The playability is a non-negative integer score, where higher
+// means the alphagram shows up more often in real games.
+func createPlayabilityMap(lexiconPath string, lexiconName string) map[string]int {
+	playabilityPath := filepath.Join(lexiconPath, "playability",
+		lexiconName)
+	pm := map[string]int{}
+	for length := 2; length <= 15; length++ {
+		filename := filepath.Join(playabilityPath, strconv.Itoa(length)+".csv")
+		f, err := os.Open(filename)
+		if err != nil {
+			continue
+		}
+		log.Info().Msgf("using playability file: %v", filename)
+		lines, err := csv.NewReader(f).ReadAll()
+		f.Close()
+		exitIfError(err)
+		header := lines[0]
+		pidx := -1
+		aidx := -1
+		for i, h := range header {
+			if h == "Alphagram" {
+				aidx = i
+			}
+			if h == "playability" {
+				pidx = i
+			}
+		}
+		if pidx == -1 || aidx == -1 {
+			panic("alphagram or playability not found in file")
+		}
+		for _, line := range lines[1:] {
+			score, err := strconv.Atoi(line[pidx])
+			exitIfError(err)
+			pm[line[aidx]] = score
+		}
+	}
+	if len(pm) == 0 {
+		log.Info().Msgf("playability map creation: no files found in %v", playabilityPath)
+		return nil
+	}
+	log.Info().Int("map-size", len(pm)).Msg("created playability map")
+	return pm
+}
+
+func alphagramPlayability(alphagram string, playabilities map[string]int) int {
+	// Alphagrams we have no data for (including brand new words) get 0.
+	if playabilities == nil {
+		return 0
+	}
+	return playabilities[alphagram]
+}
+
+func loadPlayability(db *sql.DB, lexInfo *LexiconInfo) {
+	rows, err := db.Query(`SELECT alphagram FROM alphagrams`)
+	exitIfError(err)
+	alphagrams := []string{}
+	for rows.Next() {
+		var alph string
+		if err := rows.Scan(&alph); err != nil {
+			log.Fatal().Err(err).Msg("")
+		}
+		alphagrams = append(alphagrams, alph)
+	}
+	rows.Close()
+
+	tx, err := db.Begin()
+	exitIfError(err)
+	updateStmt, err := tx.Prepare(`
+		UPDATE alphagrams SET playability = ? WHERE alphagram = ?
+	`)
+	exitIfError(err)
+	for i, alph := range alphagrams {
+		_, err := updateStmt.Exec(alphagramPlayability(alph, lexInfo.Playabilities), alph)
+		exitIfError(err)
+		if (i+1)%10000 == 0 {
+			log.Debug().Msgf("%d...", i+1)
+		}
+	}
+	updateStmt.Close()
+	exitIfError(tx.Commit())
+}
@@ -0,0 +1,22 @@
+package dbmaker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePlayabilityMap(t *testing.T) {
+	pm := createPlayabilityMap("test_files", "MINI")
+	assert.Equal(t, 2, len(pm))
+	assert.Equal(t, 1234, pm["AEINRST"])
+	assert.Equal(t, 87, pm["AEINST"])
+
+	assert.Equal(t, 1234, alphagramPlayability("AEINRST", pm))
+	assert.Equal(t, 0, alphagramPlayability("QQQ", pm))
+	assert.Equal(t, 0, alphagramPlayability("AEINRST", nil))
+}
+
+func TestCreatePlayabilityMapMissing(t *testing.T) {
+	assert.Nil(t, createPlayabilityMap("test_files", "NOSUCHLEX"))
+}
@@ -0,0 +1,131 @@
+package dbmaker
+
+import (
+	"database/sql"
+	"sort"
+	"strings"
+
+	"github.com/domino14/word-golib/tilemapping"
+	"github.com/rs/zerolog/log"
+)
+
+// NumTopStems is how many stems we store for every kind of stem.
+const NumTopStems = 1000
+
+// stemKind is a stem length and how many letters get added to it.
+type stemKind struct {
+	stemLength int
+	numAdded   int
+}
+
+// The kinds of stems we precompute: 6-letter stems for 7s and 8s, and
+// 7-letter stems for 8s.
+var stemKinds = []stemKind{{6, 1}, {6, 2}, {7, 1}}
+
+type stemCount struct {
+	stem  string
+	count int
+}
+
+// removals returns every distinct way to take n tiles out of a sorted
+// machine word, keeping the rest sorted.
+func removals(mw tilemapping.MachineWord, n int) []tilemapping.MachineWord {
+	if n == 0 {
+		return []tilemapping.MachineWord{append(tilemapping.MachineWord{}, mw...)}
+	}
+	rest := []tilemapping.MachineWord{}
+	for i := range mw {
+		// Taking out any copy of a repeated tile gives the same result, so
+		// only take out the first.
+		if i > 0 && mw[i] == mw[i-1] {
+			continue
+		}
+		shorter := append(append(tilemapping.MachineWord{}, mw[:i]...), mw[i+1:]...)
+		for _, r := range removals(shorter[i:], n-1) {
+			rest = append(rest, append(append(tilemapping.MachineWord{}, shorter[:i]...), r...))
+		}
+	}
+	return rest
+}
+
+// topStems counts the words every stem of the given kind makes, given the
+// alphagrams of length stemLength + numAdded and how many words each has,
+// and returns the n most productive stems.
+func topStems(alphagrams map[string]int, kind stemKind, n int,
+	tm *tilemapping.TileMapping) ([]stemCount, error) {
+
+	counts := map[string]int{}
+	for alph, numWords := range alphagrams {
+		mw, err := tilemapping.ToMachineLetters(alph, tm)
+		if err != nil {
+			return nil, err
+		}
+		if len(mw) != kind.stemLength+kind.numAdded {
+			continue
+		}
+		sort.Slice(mw, func(i, j int) bool { return mw[i] < mw[j] })
+		for _, stem := range removals(mw, kind.numAdded) {
+			counts[stem.UserVisible(tm)] += numWords
+		}
+	}
+	stems := make([]stemCount, 0, len(counts))
+	for stem, count := range counts {
+		stems = append(stems, stemCount{stem, count})
+	}
+	sort.Slice(stems, func(i, j int) bool {
+		if stems[i].count != stems[j].count {
+			return stems[i].count > stems[j].count
+		}
+		return stems[i].stem < stems[j].stem
+	})
+	if len(stems) > n {
+		stems = stems[:n]
+	}
+	return stems, nil
+}
+
+func loadStems(db *sql.DB, dist *tilemapping.LetterDistribution) {
+	lengths := map[int]bool{}
+	for _, kind := range stemKinds {
+		lengths[kind.stemLength+kind.numAdded] = true
+	}
+	placeholders := []string{}
+	args := []interface{}{}
+	for l := range lengths {
+		placeholders = append(placeholders, "?")
+		args = append(args, l)
+	}
+	rows, err := db.Query(`SELECT alphagram, num_anagrams FROM alphagrams
+		WHERE length IN (`+strings.Join(placeholders, ",")+`)`, args...)
+	exitIfError(err)
+	alphagrams := map[string]int{}
+	for rows.Next() {
+		var alph string
+		var numAnagrams int
+		if err := rows.Scan(&alph, &numAnagrams); err != nil {
+			log.Fatal().Err(err).Msg("")
+		}
+		alphagrams[alph] = numAnagrams
+	}
+	rows.Close()
+
+	tx, err := db.Begin()
+	exitIfError(err)
+	stmt, err := tx.Prepare(`
+		INSERT INTO stems (stem, stem_length, num_added, num_bingos)
+		VALUES (?, ?, ?, ?)
+	`)
+	exitIfError(err)
+	for _, kind := range stemKinds {
+		stems, err := topStems(alphagrams, kind, NumTopStems, dist.TileMapping())
+		exitIfError(err)
+		for _, sc := range stems {
+			_, err := stmt.Exec(sc.stem, kind.stemLength, kind.numAdded, sc.count)
+			exitIfError(err)
+		}
+		log.Info().Msgf("Stored %d stems of length %d + %d", len(stems),
+			kind.stemLength, kind.numAdded)
+	}
+	stmt.Close()
+	exitIfError(tx.Commit())
+}
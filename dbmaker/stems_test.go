@@ -0,0 +1,48 @@
+package dbmaker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/domino14/word-golib/tilemapping"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemovals(t *testing.T) {
+	ld, err := tilemapping.ScanLetterDistribution(strings.NewReader(miniDistribution))
+	assert.Nil(t, err)
+	tm := ld.TileMapping()
+	mw, err := tilemapping.ToMachineLetters("AACE", tm)
+	assert.Nil(t, err)
+
+	stems := []string{}
+	for _, r := range removals(mw, 2) {
+		stems = append(stems, r.UserVisible(tm))
+	}
+	assert.Equal(t, []string{"CE", "AE", "AC", "AA"}, stems)
+
+	stems = []string{}
+	for _, r := range removals(mw, 1) {
+		stems = append(stems, r.UserVisible(tm))
+	}
+	assert.Equal(t, []string{"ACE", "AAE", "AAC"}, stems)
+}
+
+func TestTopStems(t *testing.T) {
+	ld, err := tilemapping.ScanLetterDistribution(strings.NewReader(miniDistribution))
+	assert.Nil(t, err)
+	alphagrams := map[string]int{
+		"ACE":   1,
+		"ACES":  2,
+		"ACHES": 1, // CH is a single tile.
+		"AEOS":  1,
+	}
+	stems, err := topStems(alphagrams, stemKind{3, 1}, 2, ld.TileMapping())
+	assert.Nil(t, err)
+	assert.Equal(t, []stemCount{{"AES", 4}, {"ACE", 2}}, stems)
+
+	stems, err = topStems(alphagrams, stemKind{2, 2}, 100, ld.TileMapping())
+	assert.Nil(t, err)
+	assert.Equal(t, 12, len(stems))
+	assert.Equal(t, stemCount{"AE", 4}, stems[0])
+}
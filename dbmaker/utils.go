@@ -4,11 +4,9 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
 
 	"github.com/domino14/word-golib/config"
 	"github.com/domino14/word-golib/kwg"
-	"github.com/domino14/word-golib/tilemapping"
 	"github.com/rs/zerolog/log"
 )
 
@@ -22,248 +20,17 @@ func loadKWG(dataPath, lexName string) *kwg.KWG {
 	return k
 }
 
+// LexiconMappings builds the lexicon map from the manifest in the data path,
+// or the built-in one if there isn't one there.
 func LexiconMappings(dataPath string) LexiconMap {
-	cfg := &config.Config{DataPath: dataPath}
-
-	englishLD, err := tilemapping.EnglishLetterDistribution(cfg)
-	if err != nil {
-		panic(err)
-	}
-	spanishLD, err := tilemapping.NamedLetterDistribution(cfg, "spanish")
-	if err != nil {
-		panic(err)
-	}
-	polishLD, err := tilemapping.NamedLetterDistribution(cfg, "polish")
+	manifest, err := LoadManifest(dataPath)
 	if err != nil {
 		panic(err)
 	}
-	germanLD, err := tilemapping.NamedLetterDistribution(cfg, "german")
+	lexiconMap, err := manifest.LexiconMap(dataPath)
 	if err != nil {
 		panic(err)
 	}
-	frenchLD, err := tilemapping.NamedLetterDistribution(cfg, "french")
-	if err != nil {
-		panic(err)
-	}
-
-	lexiconPath := filepath.Join(dataPath, "lexica")
-
-	cswFamily := []*LexiconInfo{
-		{
-			LexiconName:        "CSW12",
-			LexiconFilename:    filepath.Join(lexiconPath, "CSW12.txt"),
-			LexiconIndex:       6,
-			KWG:                loadKWG(dataPath, "CSW12"),
-			DescriptiveName:    "CSW12",
-			LetterDistribution: englishLD,
-		},
-		{
-			LexiconName:        "CSW15",
-			LexiconFilename:    filepath.Join(lexiconPath, "CSW15.txt"),
-			KWG:                loadKWG(dataPath, "CSW15"),
-			LexiconIndex:       1,
-			DescriptiveName:    "Collins 15",
-			LetterDistribution: englishLD,
-		},
-		{
-			LexiconName:        "CSW19",
-			LexiconFilename:    filepath.Join(lexiconPath, "CSW19.txt"),
-			KWG:                loadKWG(dataPath, "CSW19"),
-			LexiconIndex:       12,
-			DescriptiveName:    "Collins 2019",
-			LetterDistribution: englishLD,
-			Difficulties:       createDifficultyMap(lexiconPath, "CSW19"),
-		},
-		{
-			LexiconName:        "CSW21",
-			LexiconFilename:    filepath.Join(lexiconPath, "CSW21.txt"),
-			KWG:                loadKWG(dataPath, "CSW21"),
-			LexiconIndex:       18,
-			DescriptiveName:    "Collins 2021",
-			LetterDistribution: englishLD,
-			Difficulties:       createDifficultyMap(lexiconPath, "CSW21"),
-		},
-		{
-			LexiconName:        "CSW24",
-			LexiconFilename:    filepath.Join(lexiconPath, "CSW24.txt"),
-			KWG:                loadKWG(dataPath, "CSW24"),
-			LexiconIndex:       25,
-			DescriptiveName:    "Collins 2024",
-			LetterDistribution: englishLD,
-			Difficulties:       createDifficultyMap(lexiconPath, "CSW24"),
-		},
-	}
-
-	fiseFamily := []*LexiconInfo{
-		{
-			LexiconName:        "FISE09",
-			LexiconFilename:    filepath.Join(lexiconPath, "FISE09.txt"),
-			KWG:                loadKWG(dataPath, "FISE09"),
-			LexiconIndex:       8,
-			DescriptiveName:    "Federación Internacional de Scrabble en Español",
-			LetterDistribution: spanishLD,
-		},
-		{
-			LexiconName:        "FISE2",
-			LexiconFilename:    filepath.Join(lexiconPath, "FISE2.txt"),
-			KWG:                loadKWG(dataPath, "FISE2"),
-			LexiconIndex:       10,
-			DescriptiveName:    "Federación Internacional de Scrabble en Español, 2017 Edition",
-			LetterDistribution: spanishLD,
-		},
-	}
-
-	twlFamily := []*LexiconInfo{
-		{
-			LexiconName:        "OWL2",
-			LexiconFilename:    filepath.Join(lexiconPath, "OWL2.txt"),
-			KWG:                loadKWG(dataPath, "OWL2"),
-			LexiconIndex:       4,
-			DescriptiveName:    "OWL2",
-			LetterDistribution: englishLD,
-		},
-		{
-			LexiconName:        "America",
-			LexiconFilename:    filepath.Join(lexiconPath, "America.txt"),
-			KWG:                loadKWG(dataPath, "America"),
-			LexiconIndex:       7,
-			DescriptiveName:    "America",
-			LetterDistribution: englishLD,
-		},
-		{
-			LexiconName:        "NWL18",
-			LexiconFilename:    filepath.Join(lexiconPath, "NWL18.txt"),
-			KWG:                loadKWG(dataPath, "NWL18"),
-			LexiconIndex:       9,
-			DescriptiveName:    "NASPA Word List, 2020 Edition",
-			LetterDistribution: englishLD,
-			Difficulties:       createDifficultyMap(lexiconPath, "NWL18"),
-		},
-		{
-			LexiconName:        "NWL20",
-			LexiconFilename:    filepath.Join(lexiconPath, "NWL20.txt"),
-			KWG:                loadKWG(dataPath, "NWL20"),
-			LexiconIndex:       15,
-			DescriptiveName:    "NASPA Word List, 2020 Edition",
-			LetterDistribution: englishLD,
-			Difficulties:       createDifficultyMap(lexiconPath, "NWL20"),
-		},
-		{
-			LexiconName:        "NWL23",
-			LexiconFilename:    filepath.Join(lexiconPath, "NWL23.txt"),
-			KWG:                loadKWG(dataPath, "NWL23"),
-			LexiconIndex:       24,
-			DescriptiveName:    "NASPA Word List, 2023 Edition",
-			LetterDistribution: englishLD,
-			Difficulties:       createDifficultyMap(lexiconPath, "NWL23"),
-		},
-	}
-
-	ospsFamily := []*LexiconInfo{
-		{
-			LexiconName:        "OSPS42",
-			LexiconFilename:    filepath.Join(lexiconPath, "OSPS42.txt"),
-			KWG:                loadKWG(dataPath, "OSPS42"),
-			LexiconIndex:       14,
-			DescriptiveName:    "Polska Federacja Scrabble - Update 42",
-			LetterDistribution: polishLD,
-		},
-		{
-			LexiconName:        "OSPS44",
-			LexiconFilename:    filepath.Join(lexiconPath, "OSPS44.txt"),
-			KWG:                loadKWG(dataPath, "OSPS44"),
-			LexiconIndex:       16,
-			DescriptiveName:    "Polska Federacja Scrabble - Update 44",
-			LetterDistribution: polishLD,
-		},
-		{
-			LexiconName:        "OSPS46",
-			LexiconFilename:    filepath.Join(lexiconPath, "OSPS46.txt"),
-			KWG:                loadKWG(dataPath, "OSPS46"),
-			LexiconIndex:       20,
-			DescriptiveName:    "Polska Federacja Scrabble - Update 46",
-			LetterDistribution: polishLD,
-		},
-		{
-			LexiconName:        "OSPS48",
-			LexiconFilename:    filepath.Join(lexiconPath, "OSPS48.txt"),
-			KWG:                loadKWG(dataPath, "OSPS48"),
-			LexiconIndex:       21,
-			DescriptiveName:    "Polska Federacja Scrabble - Update 48",
-			LetterDistribution: polishLD,
-		},
-		{
-			LexiconName:        "OSPS49",
-			LexiconFilename:    filepath.Join(lexiconPath, "OSPS49.txt"),
-			KWG:                loadKWG(dataPath, "OSPS49"),
-			LexiconIndex:       22,
-			DescriptiveName:    "Polska Federacja Scrabble - Update 49",
-			LetterDistribution: polishLD,
-		},
-		{
-			LexiconName:        "OSPS50",
-			LexiconFilename:    filepath.Join(lexiconPath, "OSPS50.txt"),
-			KWG:                loadKWG(dataPath, "OSPS50"),
-			LexiconIndex:       26,
-			DescriptiveName:    "Polska Federacja Scrabble - Update 50",
-			LetterDistribution: polishLD,
-		},
-		{
-			LexiconName:        "OSPS51",
-			LexiconFilename:    filepath.Join(lexiconPath, "OSPS51.txt"),
-			KWG:                loadKWG(dataPath, "OSPS51"),
-			LexiconIndex:       28,
-			DescriptiveName:    "Polska Federacja Scrabble - Update 51",
-			LetterDistribution: polishLD,
-		},
-	}
-
-	deutschFamily := []*LexiconInfo{
-		{
-			LexiconName:        "Deutsch",
-			LexiconFilename:    filepath.Join(lexiconPath, "Deutsch.txt"),
-			KWG:                loadKWG(dataPath, "RD28"),
-			LexiconIndex:       17,
-			DescriptiveName:    "Scrabble®-Turnierliste - based on Duden 28th edition",
-			LetterDistribution: germanLD,
-		},
-		{
-			LexiconName:        "RD29",
-			LexiconFilename:    filepath.Join(lexiconPath, "RD29.txt"),
-			KWG:                loadKWG(dataPath, "RD29"),
-			LexiconIndex:       27,
-			DescriptiveName:    "Scrabble®-Turnierliste - based on Duden 29th edition",
-			LetterDistribution: germanLD,
-		},
-	}
-
-	frenchFamily := []*LexiconInfo{
-		{
-			LexiconName:        "FRA20",
-			LexiconFilename:    filepath.Join(lexiconPath, "FRA20.txt"),
-			KWG:                loadKWG(dataPath, "FRA20"),
-			DescriptiveName:    "French 2020 lexicon",
-			LetterDistribution: frenchLD,
-		},
-		{
-			LexiconName:        "FRA24",
-			LexiconFilename:    filepath.Join(lexiconPath, "FRA24.txt"),
-			KWG:                loadKWG(dataPath, "FRA24"),
-			DescriptiveName:    "French 2024 lexicon",
-			LetterDistribution: frenchLD,
-			LexiconIndex:       23,
-		},
-	}
-
-	lexiconMap := LexiconMap{
-		FamilyCSW:     cswFamily,
-		FamilyFISE:    fiseFamily,
-		FamilyTWL:     twlFamily,
-		FamilyOSPS:    ospsFamily,
-		FamilyDeutsch: deutschFamily,
-		FamilyFrench:  frenchFamily,
-	}
-
 	return lexiconMap
 }
 
@@ -6,6 +6,7 @@
 package anagrammer
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"unicode/utf8"
@@ -27,6 +28,9 @@ type AnagramStruct struct {
 	answerList []string
 	mode       AnagramMode
 	numLetters int
+	// The search stops early if ctx is done, with its error in err.
+	ctx context.Context
+	err error
 }
 
 type rangeBlank struct {
@@ -102,6 +106,14 @@ func makeRack(letters string, alph *tilemapping.TileMapping) (*RackWrapper, erro
 }
 
 func Anagram(letters string, d *kwg.KWG, mode AnagramMode) []string {
+	answers, _ := AnagramContext(context.Background(), letters, d, mode)
+	return answers
+}
+
+// AnagramContext is like Anagram, but gives up and returns the context's
+// error if it is done before the search is.
+func AnagramContext(ctx context.Context, letters string, d *kwg.KWG, mode AnagramMode) (
+	[]string, error) {
 
 	letters = strings.ToUpper(letters)
 	answerList := []string{}
@@ -110,13 +122,14 @@ func Anagram(letters string, d *kwg.KWG, mode AnagramMode) []string {
 	rw, err := makeRack(letters, alph)
 	if err != nil {
 		log.Error().Msgf("Anagram error: %v", err)
-		return []string{}
+		return []string{}, nil
 	}
 
 	ahs := &AnagramStruct{
 		answerList: answerList,
 		mode:       mode,
 		numLetters: rw.numLetters,
+		ctx:        ctx,
 	}
 	stopChan := make(chan struct{})
 
@@ -126,8 +139,11 @@ func Anagram(letters string, d *kwg.KWG, mode AnagramMode) []string {
 		close(stopChan)
 	}()
 	<-stopChan
+	if ahs.err != nil {
+		return nil, ahs.err
+	}
 
-	return dedupeAndTransformAnswers(ahs.answerList, alph)
+	return dedupeAndTransformAnswers(ahs.answerList, alph), nil
 	//return ahs.answerList
 }
 
@@ -184,6 +200,15 @@ func anagramHelper(letter tilemapping.MachineLetter, d *kwg.KWG,
 func anagram(ahs *AnagramStruct, d *kwg.KWG, nodeIdx uint32,
 	answerSoFar string, rw *RackWrapper) {
 
+	if ahs.err != nil {
+		return
+	}
+	select {
+	case <-ahs.ctx.Done():
+		ahs.err = ahs.ctx.Err()
+		return
+	default:
+	}
 	for idx, val := range rw.rack.LetArr {
 		if val == 0 {
 			continue
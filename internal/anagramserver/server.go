@@ -75,7 +75,11 @@ func (s *Server) Anagram(ctx context.Context, req *connect.Request[pb.AnagramReq
 		if req.Msg.Mode == pb.AnagramRequest_SUPER {
 			return nil, errors.New("cannot use super-anagram mode with range queries")
 		}
-		sols = anagrammer.Anagram(req.Msg.Letters, dawg, anagrammer.AnagramMode(req.Msg.Mode))
+		sols, err = anagrammer.AnagramContext(ctx, req.Msg.Letters, dawg,
+			anagrammer.AnagramMode(req.Msg.Mode))
+		if err != nil {
+			return nil, err
+		}
 	} else {
 
 		da := kwg.DaPool.Get().(*kwg.KWGAnagrammer)
@@ -100,10 +104,13 @@ func (s *Server) Anagram(ctx context.Context, req *connect.Request[pb.AnagramReq
 			return nil, err
 		}
 
-		anagFunc(dawg, func(word tilemapping.MachineWord) error {
+		err = anagFunc(dawg, func(word tilemapping.MachineWord) error {
 			sols = append(sols, word.UserVisible(alph))
-			return nil
+			return ctx.Err()
 		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var words []*pb.Word
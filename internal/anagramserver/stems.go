@@ -0,0 +1,166 @@
+package anagramserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/domino14/word-golib/kwg"
+	"github.com/domino14/word-golib/tilemapping"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+	"github.com/domino14/word_db_server/internal/searchserver"
+)
+
+const (
+	// DefaultStemLimit is how many stems to read from the database if the
+	// request doesn't say.
+	DefaultStemLimit = 100
+	// MaxStems is the most stems a single request can look up.
+	MaxStems = 500
+)
+
+// FindStem finds all the words made by adding numAdded letters to the
+// stem, grouped by the letters added.
+func FindStem(dawg *kwg.KWG, stem string, numAdded int) (*pb.Stem, error) {
+	alph := dawg.GetAlphabet()
+	stemML, err := tilemapping.ToMachineLetters(strings.ToUpper(stem), alph)
+	if err != nil {
+		return nil, err
+	}
+	for _, ml := range stemML {
+		if ml == 0 {
+			return nil, errors.New("stems cannot have blanks")
+		}
+	}
+
+	// Anagramming the stem with a blank for every added letter finds every
+	// word; what the blanks stood for is what's left over once the stem's
+	// tiles are taken out.
+	rack := append(tilemapping.MachineWord{}, stemML...)
+	rack = append(rack, make(tilemapping.MachineWord, numAdded)...)
+	da := kwg.DaPool.Get().(*kwg.KWGAnagrammer)
+	defer kwg.DaPool.Put(da)
+	if err := da.InitForMachineWord(dawg, rack); err != nil {
+		return nil, err
+	}
+
+	byAdded := map[string]*pb.StemAddition{}
+	addedOrder := map[string]tilemapping.MachineWord{}
+	numBingos := 0
+	err = da.Anagram(dawg, func(word tilemapping.MachineWord) error {
+		added := leftover(word, stemML)
+		key := added.UserVisible(alph)
+		sa, ok := byAdded[key]
+		if !ok {
+			sa = &pb.StemAddition{Letters: key}
+			byAdded[key] = sa
+			addedOrder[key] = added
+		}
+		sa.Words = append(sa.Words, &pb.Word{Word: word.UserVisible(alph)})
+		numBingos++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	additions := make([]*pb.StemAddition, 0, len(byAdded))
+	for _, sa := range byAdded {
+		additions = append(additions, sa)
+	}
+	// Sort in tile order rather than by string, so multi-character tiles
+	// end up where they belong.
+	sort.Slice(additions, func(i, j int) bool {
+		a, b := addedOrder[additions[i].Letters], addedOrder[additions[j].Letters]
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return &pb.Stem{
+		Stem:      tilemapping.MachineWord(stemML).UserVisible(alph),
+		NumBingos: int32(numBingos),
+		Additions: additions,
+	}, nil
+}
+
+// leftover returns the tiles of word that aren't in stem, sorted.
+func leftover(word, stem tilemapping.MachineWord) tilemapping.MachineWord {
+	counts := map[tilemapping.MachineLetter]int{}
+	for _, ml := range stem {
+		counts[ml]++
+	}
+	left := tilemapping.MachineWord{}
+	for _, ml := range word {
+		if counts[ml] > 0 {
+			counts[ml]--
+			continue
+		}
+		left = append(left, ml)
+	}
+	sort.Slice(left, func(i, j int) bool { return left[i] < left[j] })
+	return left
+}
+
+func (s *Server) StemSearch(ctx context.Context, req *connect.Request[pb.StemRequest]) (
+	*connect.Response[pb.StemResponse], error) {
+	defer timeTrack(time.Now(), "stemsearch")
+
+	numAdded := int(req.Msg.NumAdded)
+	if numAdded != 1 && numAdded != 2 {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			errors.New("num_added must be 1 or 2"))
+	}
+	dawg, err := kwg.GetKWG(s.Config, req.Msg.Lexicon)
+	if err != nil {
+		return nil, err
+	}
+
+	stems := req.Msg.Stems
+	if len(stems) == 0 {
+		limit := int(req.Msg.Limit)
+		if limit <= 0 {
+			limit = DefaultStemLimit
+		}
+		ss := &searchserver.Server{Config: s.WDBConfig}
+		stems, err = ss.TopStems(ctx, req.Msg.Lexicon, int(req.Msg.StemLength), numAdded,
+			min(limit, MaxStems))
+		if err != nil {
+			return nil, err
+		}
+		if len(stems) == 0 {
+			return nil, connect.NewError(connect.CodeNotFound,
+				fmt.Errorf("no stems of length %d + %d are stored for %v",
+					req.Msg.StemLength, numAdded, req.Msg.Lexicon))
+		}
+	} else if len(stems) > MaxStems {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("too many stems; the limit is %d", MaxStems))
+	}
+
+	results := make([]*pb.Stem, 0, len(stems))
+	for _, stem := range stems {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		result, err := FindStem(dawg, stem, numAdded)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument,
+				fmt.Errorf("stem %v: %w", stem, err))
+		}
+		results = append(results, result)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].NumBingos > results[j].NumBingos
+	})
+	return connect.NewResponse(&pb.StemResponse{Stems: results}), nil
+}
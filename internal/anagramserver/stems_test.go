@@ -0,0 +1,44 @@
+package anagramserver
+
+import (
+	"testing"
+
+	"github.com/domino14/word-golib/tilemapping"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeftover(t *testing.T) {
+	word := tilemapping.MachineWord{5, 1, 19, 5, 2}
+	stem := tilemapping.MachineWord{1, 2, 5}
+	assert.Equal(t, tilemapping.MachineWord{5, 19}, leftover(word, stem))
+}
+
+func TestFindStem(t *testing.T) {
+	eng, err := loadKWG("America")
+	assert.Nil(t, err)
+
+	stem, err := FindStem(eng, "satire", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, "SATIRE", stem.Stem)
+
+	total := 0
+	var sAddition []string
+	for _, a := range stem.Additions {
+		assert.Equal(t, 1, len(a.Letters))
+		total += len(a.Words)
+		if a.Letters == "S" {
+			for _, w := range a.Words {
+				sAddition = append(sAddition, w.Word)
+			}
+		}
+	}
+	assert.Equal(t, int(stem.NumBingos), total)
+	assert.Contains(t, sAddition, "SATIRES")
+
+	two, err := FindStem(eng, "SATIRE", 2)
+	assert.Nil(t, err)
+	assert.Greater(t, two.NumBingos, stem.NumBingos)
+
+	_, err = FindStem(eng, "SAT?RE", 1)
+	assert.NotNil(t, err)
+}
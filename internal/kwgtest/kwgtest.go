@@ -0,0 +1,107 @@
+// Package kwgtest builds small KWGs from word lists, for tests that can't
+// rely on the lexicon files in WDB_DATA_PATH.
+package kwgtest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"github.com/domino14/word-golib/kwg"
+	"github.com/domino14/word-golib/tilemapping"
+)
+
+type trieNode struct {
+	children map[tilemapping.MachineLetter]*trieNode
+	accepts  bool
+}
+
+func newTrieNode() *trieNode {
+	return &trieNode{children: map[tilemapping.MachineLetter]*trieNode{}}
+}
+
+func (n *trieNode) insert(path tilemapping.MachineWord) {
+	for _, ml := range path {
+		c, ok := n.children[ml]
+		if !ok {
+			c = newTrieNode()
+			n.children[ml] = c
+		}
+		n = c
+	}
+	n.accepts = true
+}
+
+// Build returns a KWG with both a DAWG and a GADDAG for the words. Nodes
+// aren't shared the way they are in a real KWG, so it is only suitable for
+// small word lists.
+func Build(words []string, tm *tilemapping.TileMapping) (*kwg.KWG, error) {
+	bts, err := Encode(words, tm)
+	if err != nil {
+		return nil, err
+	}
+	return kwg.ScanKWG(bytes.NewReader(bts), len(bts))
+}
+
+// Encode is Build, but returns the KWG the way it's stored in a .kwg file,
+// for tests that load lexica from a data path.
+func Encode(words []string, tm *tilemapping.TileMapping) ([]byte, error) {
+	dawg := newTrieNode()
+	gaddag := newTrieNode()
+	for _, w := range words {
+		mw, err := tilemapping.ToMachineLetters(w, tm)
+		if err != nil {
+			return nil, err
+		}
+		dawg.insert(mw)
+		// The GADDAG has the whole word reversed, and for every split point,
+		// the reversed prefix, a separator (tile 0), and the suffix.
+		rev := make(tilemapping.MachineWord, len(mw))
+		for i, ml := range mw {
+			rev[len(mw)-1-i] = ml
+		}
+		gaddag.insert(rev)
+		for i := 1; i < len(mw); i++ {
+			path := append(tilemapping.MachineWord{}, rev[len(mw)-i:]...)
+			path = append(path, 0)
+			path = append(path, mw[i:]...)
+			gaddag.insert(path)
+		}
+	}
+
+	// Nodes 0 and 1 point to the DAWG and GADDAG roots.
+	nodes := []uint32{0, 0}
+	var place func(n *trieNode) uint32
+	place = func(n *trieNode) uint32 {
+		if len(n.children) == 0 {
+			return 0
+		}
+		tiles := []tilemapping.MachineLetter{}
+		for ml := range n.children {
+			tiles = append(tiles, ml)
+		}
+		sort.Slice(tiles, func(i, j int) bool { return tiles[i] < tiles[j] })
+		start := uint32(len(nodes))
+		nodes = append(nodes, make([]uint32, len(tiles))...)
+		for i, ml := range tiles {
+			c := n.children[ml]
+			node := uint32(ml)<<24 | place(c)
+			if c.accepts {
+				node |= 0x800000
+			}
+			if i == len(tiles)-1 {
+				node |= 0x400000
+			}
+			nodes[start+uint32(i)] = node
+		}
+		return start
+	}
+	nodes[0] = place(dawg) | 0x400000
+	nodes[1] = place(gaddag) | 0x400000
+
+	var buf bytes.Buffer
+	if err := binary.Write(&buf, binary.LittleEndian, nodes); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
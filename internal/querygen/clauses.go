@@ -1,6 +1,8 @@
 package querygen
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -116,6 +118,9 @@ type WhereInClause struct {
 	table           string
 	column          string
 	numItems        int
+	// jsonArray binds the whole list as a single JSON array parameter
+	// instead of one parameter per item. See useJSONArray.
+	jsonArray bool
 }
 
 func NewWhereInClause(table string, column string,
@@ -165,6 +170,15 @@ func (w *WhereInClause) Render() (string, []interface{}, error) {
 			t)
 	}
 
+	if w.jsonArray {
+		encoded, err := json.Marshal(bindParams)
+		if err != nil {
+			return "", nil, err
+		}
+		return whereClauseRender(w.table, w.column, `IN (SELECT value FROM json_each(?))`),
+			[]interface{}{string(encoded)}, nil
+	}
+
 	if w.numItems == 1 {
 		conditionTemplate = `= ?`
 	} else {
@@ -176,6 +190,14 @@ func (w *WhereInClause) Render() (string, []interface{}, error) {
 	return whereClauseRender(w.table, w.column, conditionTemplate), bindParams, nil
 }
 
+// useJSONArray makes the clause render as a json_each subquery with a single
+// bind parameter. This is for lists that can't be split up into several
+// queries (for example, inside an OR or NOT group) and may be too long to
+// bind item by item.
+func (w *WhereInClause) useJSONArray() {
+	w.jsonArray = true
+}
+
 // conditionSubRange returns a contiguous subset of this clause's
 // list of values and formats it as a new search param. This function
 // is used to split up a possibly gigantic list of "where .. in" values.
@@ -194,7 +216,7 @@ func (w *WhereInClause) conditionSubRange(min int, max int) *wordsearcher.Search
 		}
 		return &wordsearcher.SearchRequest_SearchParam{
 			Conditionparam: &wordsearcher.SearchRequest_SearchParam_Numberarray{
-				&wordsearcher.SearchRequest_NumberArray{
+				Numberarray: &wordsearcher.SearchRequest_NumberArray{
 					Values: vals[min:max]}}}
 
 	case *wordsearcher.SearchRequest_SearchParam_Stringarray:
@@ -208,7 +230,7 @@ func (w *WhereInClause) conditionSubRange(min int, max int) *wordsearcher.Search
 		}
 		return &wordsearcher.SearchRequest_SearchParam{
 			Conditionparam: &wordsearcher.SearchRequest_SearchParam_Stringarray{
-				&wordsearcher.SearchRequest_StringArray{
+				Stringarray: &wordsearcher.SearchRequest_StringArray{
 					Values: vals[min:max]}}}
 
 	}
@@ -237,6 +259,13 @@ func (lc *LimitOffsetClause) Render() (string, []interface{}, error) {
 	return "LIMIT ? OFFSET ?", []interface{}{limit, offset}, nil
 }
 
+func (lc *LimitOffsetClause) window() window {
+	return window{
+		limit:  int(lc.conditionParams.GetMax() - lc.conditionParams.GetMin() + 1),
+		offset: int(lc.conditionParams.GetMin() - 1),
+	}
+}
+
 // WhereHooksClause handles front_hooks and back_hooks searches
 type WhereHooksClause struct {
 	column       string
@@ -291,17 +320,248 @@ func (w *WhereInnerHooksClause) Render() (string, []interface{}, error) {
 	}
 }
 
-// WhereDefinitionContainsClause handles definition searches
+// WhereDefinitionContainsClause handles definition searches. With fullText,
+// the search term is matched against the definitions_fts index (see
+// FullTextQuery); otherwise it is a substring of the definition.
 type WhereDefinitionContainsClause struct {
 	searchTerm string
+	fullText   bool
 }
 
 func (w *WhereDefinitionContainsClause) Render() (string, []interface{}, error) {
+	if w.fullText {
+		return "word IN (SELECT word FROM definitions_fts WHERE definitions_fts MATCH ?)",
+			[]interface{}{FullTextQuery(w.searchTerm)}, nil
+	}
 	condition := "definition LIKE ? COLLATE NOCASE"
 	bindParams := []interface{}{"%" + w.searchTerm + "%"}
 	return condition, bindParams, nil
 }
 
+// WhereGroupClause combines a number of clauses with a boolean operator.
+// A NOT group negates the AND of its clauses.
+type WhereGroupClause struct {
+	operator wordsearcher.SearchRequest_GroupOperator
+	clauses  []Clause
+}
+
+func NewWhereGroupClause(operator wordsearcher.SearchRequest_GroupOperator,
+	clauses []Clause) *WhereGroupClause {
+	return &WhereGroupClause{
+		operator: operator,
+		clauses:  clauses,
+	}
+}
+
+func (w *WhereGroupClause) Render() (string, []interface{}, error) {
+	if len(w.clauses) == 0 {
+		return "", nil, errors.New("cannot render an empty condition group")
+	}
+	rendered := make([]string, len(w.clauses))
+	bindParams := []interface{}{}
+	for i, c := range w.clauses {
+		r, bp, err := c.Render()
+		if err != nil {
+			return "", nil, err
+		}
+		rendered[i] = r
+		bindParams = append(bindParams, bp...)
+	}
+
+	switch w.operator {
+	case wordsearcher.SearchRequest_AND:
+		return "(" + strings.Join(rendered, " AND ") + ")", bindParams, nil
+	case wordsearcher.SearchRequest_OR:
+		return "(" + strings.Join(rendered, " OR ") + ")", bindParams, nil
+	case wordsearcher.SearchRequest_NOT:
+		return "NOT (" + strings.Join(rendered, " AND ") + ")", bindParams, nil
+	}
+	return "", nil, fmt.Errorf("unsupported group operator: %v", w.operator)
+}
+
+// LetterCount is a letter and a number of times it occurs.
+type LetterCount struct {
+	Letter string
+	Count  int
+}
+
+// WhereLetterCountClause matches alphagrams by their letter content, using
+// the alphagram_letters table. By default every letter must occur at least
+// Count times in the alphagram; if exclude is set, none of the letters may
+// occur at all and the counts are ignored.
+type WhereLetterCountClause struct {
+	table   string
+	letters []LetterCount
+	exclude bool
+}
+
+func NewWhereLetterCountClause(table string, letters []LetterCount,
+	exclude bool) *WhereLetterCountClause {
+	return &WhereLetterCountClause{
+		table:   table,
+		letters: letters,
+		exclude: exclude,
+	}
+}
+
+func (w *WhereLetterCountClause) Render() (string, []interface{}, error) {
+	if len(w.letters) == 0 {
+		return "", nil, errors.New("no letters provided")
+	}
+	bindParams := []interface{}{}
+	if w.exclude {
+		for _, lc := range w.letters {
+			bindParams = append(bindParams, lc.Letter)
+		}
+		markers := strings.Repeat("?,", len(w.letters))
+		return whereClauseRender(w.table, "alphagram",
+			"NOT IN (SELECT alphagram FROM alphagram_letters WHERE letter IN ("+
+				markers[:len(markers)-1]+"))"), bindParams, nil
+	}
+	conditions := make([]string, len(w.letters))
+	for i, lc := range w.letters {
+		conditions[i] = whereClauseRender(w.table, "alphagram",
+			"IN (SELECT alphagram FROM alphagram_letters WHERE letter = ? AND count >= ?)")
+		bindParams = append(bindParams, lc.Letter, lc.Count)
+	}
+	if len(conditions) == 1 {
+		return conditions[0], bindParams, nil
+	}
+	return "(" + strings.Join(conditions, " AND ") + ")", bindParams, nil
+}
+
+// WhereCrossLexiconClause checks a column against other lexica with one of
+// the LexiconFuncs SQL functions.
+type WhereCrossLexiconClause struct {
+	table    string
+	column   string
+	function string
+	in       []string
+	notIn    []string
+}
+
+func NewWhereCrossLexiconClause(table string, column string, function string,
+	in []string, notIn []string) *WhereCrossLexiconClause {
+	return &WhereCrossLexiconClause{
+		table:    table,
+		column:   column,
+		function: function,
+		in:       in,
+		notIn:    notIn,
+	}
+}
+
+func (w *WhereCrossLexiconClause) Render() (string, []interface{}, error) {
+	if len(w.in)+len(w.notIn) == 0 {
+		return "", nil, errors.New("no lexica provided")
+	}
+	call := fmt.Sprintf("%s(?, %s.%s)", w.function, w.table, w.column)
+	conditions := []string{}
+	bindParams := []interface{}{}
+	for _, lex := range w.in {
+		conditions = append(conditions, call)
+		bindParams = append(bindParams, lex)
+	}
+	for _, lex := range w.notIn {
+		conditions = append(conditions, "NOT "+call)
+		bindParams = append(bindParams, lex)
+	}
+	if len(conditions) == 1 {
+		return conditions[0], bindParams, nil
+	}
+	return "(" + strings.Join(conditions, " AND ") + ")", bindParams, nil
+}
+
+// WhereLexiconSymbolsClause matches words whose lexicon symbols include
+// all of the given symbols, or that have no symbols if none are given.
+type WhereLexiconSymbolsClause struct {
+	table   string
+	symbols string
+}
+
+func (w *WhereLexiconSymbolsClause) Render() (string, []interface{}, error) {
+	if w.symbols == "" {
+		return whereClauseRender(w.table, "lexicon_symbols", "= ?"), []interface{}{""}, nil
+	}
+	conditions := []string{}
+	bindParams := []interface{}{}
+	for _, symbol := range w.symbols {
+		conditions = append(conditions, whereClauseRender(w.table, "lexicon_symbols", "LIKE ?"))
+		bindParams = append(bindParams, fmt.Sprintf("%%%c%%", symbol))
+	}
+	if len(conditions) == 1 {
+		return conditions[0], bindParams, nil
+	}
+	return "(" + strings.Join(conditions, " AND ") + ")", bindParams, nil
+}
+
+// WhereValidSinceClause matches words that have been in every lexicon of
+// the family from since up to the searched lexicon. It matches nothing if
+// since isn't in the family, or comes after the searched lexicon.
+type WhereValidSinceClause struct {
+	table   string
+	since   string
+	current string
+}
+
+func (w *WhereValidSinceClause) Render() (string, []interface{}, error) {
+	if w.since == "" {
+		return "", nil, errors.New("no lexicon given for in every lexicon since")
+	}
+	return whereClauseRender(w.table, "valid_since_order",
+		"<= (SELECT family_order FROM family_lexica WHERE lexicon_name = ? AND "+
+			"family_order <= (SELECT family_order FROM family_lexica WHERE lexicon_name = ?))"),
+		[]interface{}{w.since, w.current}, nil
+}
+
+// WhereDefinitionsClause matches words with at least one sense in the
+// definitions table whose column has the given value.
+type WhereDefinitionsClause struct {
+	table  string
+	column string
+	value  string
+}
+
+func (w *WhereDefinitionsClause) Render() (string, []interface{}, error) {
+	if w.value == "" {
+		return "", nil, fmt.Errorf("no value given for %v", w.column)
+	}
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM definitions d WHERE d.word = %s.word AND d.%s = ?)",
+		w.table, w.column), []interface{}{w.value}, nil
+}
+
+// changeKinds are the kinds of change dbmaker stores in lexicon_changes.
+var changeKinds = map[string]bool{
+	"added": true, "deleted": true, "anagrams": true, "hooks": true, "definition": true,
+}
+
+// WhereChangedSinceClause matches alphagrams with a change stored in the
+// lexicon_changes table against a prior lexicon, optionally only of the
+// given kinds.
+type WhereChangedSinceClause struct {
+	table string
+	prior string
+	kinds []string
+}
+
+func (w *WhereChangedSinceClause) Render() (string, []interface{}, error) {
+	if w.prior == "" {
+		return "", nil, errors.New("no prior lexicon given for changed since")
+	}
+	sql := "SELECT alphagram FROM lexicon_changes WHERE prior_lexicon = ?"
+	bindParams := []interface{}{w.prior}
+	if len(w.kinds) > 0 {
+		for _, kind := range w.kinds {
+			if !changeKinds[kind] {
+				return "", nil, fmt.Errorf("unknown kind of change %v", kind)
+			}
+			bindParams = append(bindParams, kind)
+		}
+		sql += " AND change IN (" + strings.Repeat("?, ", len(w.kinds)-1) + "?)"
+	}
+	return whereClauseRender(w.table, "alphagram", "IN ("+sql+")"), bindParams, nil
+}
+
 func isListClause(clause Clause) bool {
 	// try to cast to a WhereIn clause.
 	_, ok := clause.(*WhereInClause)
@@ -1,9 +1,11 @@
 package querygen
 
 import (
+	"database/sql"
 	"testing"
 
 	"github.com/domino14/word_db_server/api/rpc/wordsearcher"
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -153,3 +155,199 @@ func TestEmptyWhereClause(t *testing.T) {
 	assert.Contains(t, q.Rendered(), "WHERE 1=1")
 	assert.NotContains(t, q.Rendered(), "WHERE ORDER")
 }
+
+func TestWhereInClauseJSONArray(t *testing.T) {
+	sp := &wordsearcher.SearchRequest_SearchParam{
+		Conditionparam: &wordsearcher.SearchRequest_SearchParam_Stringarray{
+			Stringarray: &wordsearcher.SearchRequest_StringArray{
+				Values: []string{"AEINRST", "AEINST"},
+			}}}
+
+	c := NewWhereInClause("test_table", "foo_column", sp)
+	c.useJSONArray()
+	res, params, _ := c.Render()
+	assert.Equal(t, "test_table.foo_column IN (SELECT value FROM json_each(?))", res)
+	assert.Equal(t, []interface{}{`["AEINRST","AEINST"]`}, params)
+}
+
+func TestWhereGroupClause(t *testing.T) {
+	sevens := NewWhereGroupClause(wordsearcher.SearchRequest_AND, []Clause{
+		NewWhereBetweenClause("alphagrams", "length",
+			&wordsearcher.SearchRequest_MinMax{Min: 7, Max: 7}),
+		NewWhereBetweenClause("alphagrams", "probability",
+			&wordsearcher.SearchRequest_MinMax{Min: 1, Max: 2000}),
+	})
+	eights := NewWhereGroupClause(wordsearcher.SearchRequest_AND, []Clause{
+		NewWhereBetweenClause("alphagrams", "length",
+			&wordsearcher.SearchRequest_MinMax{Min: 8, Max: 8}),
+		NewWhereBetweenClause("alphagrams", "difficulty",
+			&wordsearcher.SearchRequest_MinMax{Min: 80, Max: 100}),
+	})
+	c := NewWhereGroupClause(wordsearcher.SearchRequest_OR, []Clause{sevens, eights})
+	res, params, err := c.Render()
+	assert.Nil(t, err)
+	assert.Equal(t, "((alphagrams.length = ? AND alphagrams.probability BETWEEN ? and ?) OR "+
+		"(alphagrams.length = ? AND alphagrams.difficulty BETWEEN ? and ?))", res)
+	assert.Equal(t, []interface{}{int32(7), int32(1), int32(2000), int32(8),
+		int32(80), int32(100)}, params)
+}
+
+func TestWhereGroupClauseNot(t *testing.T) {
+	c := NewWhereGroupClause(wordsearcher.SearchRequest_NOT, []Clause{
+		&WhereHooksClause{column: "back_hooks", hooks: "S"},
+	})
+	res, params, err := c.Render()
+	assert.Nil(t, err)
+	assert.Equal(t, "NOT ((back_hooks LIKE ?))", res)
+	assert.Equal(t, []interface{}{"%S%"}, params)
+}
+
+func TestWhereGroupClauseEmpty(t *testing.T) {
+	c := NewWhereGroupClause(wordsearcher.SearchRequest_OR, nil)
+	_, _, err := c.Render()
+	assert.NotNil(t, err)
+}
+
+func TestWhereLetterCountClause(t *testing.T) {
+	c := NewWhereLetterCountClause("alphagrams", []LetterCount{{"Q", 1}, {"E", 2}}, false)
+	res, params, err := c.Render()
+	assert.Nil(t, err)
+	assert.Equal(t, "(alphagrams.alphagram IN (SELECT alphagram FROM alphagram_letters "+
+		"WHERE letter = ? AND count >= ?) AND alphagrams.alphagram IN (SELECT alphagram "+
+		"FROM alphagram_letters WHERE letter = ? AND count >= ?))", res)
+	assert.Equal(t, []interface{}{"Q", 1, "E", 2}, params)
+}
+
+func TestWhereLetterCountClauseExclude(t *testing.T) {
+	c := NewWhereLetterCountClause("a2", []LetterCount{{"U", 1}, {"CH", 1}}, true)
+	res, params, err := c.Render()
+	assert.Nil(t, err)
+	assert.Equal(t, "a2.alphagram NOT IN (SELECT alphagram FROM alphagram_letters "+
+		"WHERE letter IN (?,?))", res)
+	assert.Equal(t, []interface{}{"U", "CH"}, params)
+}
+
+func TestWhereCrossLexiconClause(t *testing.T) {
+	c := NewWhereCrossLexiconClause("w2", "word", InLexiconFunc, []string{"CSW21"},
+		[]string{"NWL20", "NWL23"})
+	res, params, err := c.Render()
+	assert.Nil(t, err)
+	assert.Equal(t, "(wdb_in_lexicon(?, w2.word) AND NOT wdb_in_lexicon(?, w2.word) "+
+		"AND NOT wdb_in_lexicon(?, w2.word))", res)
+	assert.Equal(t, []interface{}{"CSW21", "NWL20", "NWL23"}, params)
+
+	c = NewWhereCrossLexiconClause("a2", "alphagram", AlphagramInLexiconFunc, nil, nil)
+	_, _, err = c.Render()
+	assert.NotNil(t, err)
+}
+
+func TestWhereLexiconSymbolsClause(t *testing.T) {
+	c := &WhereLexiconSymbolsClause{table: "w2", symbols: "#+"}
+	res, params, err := c.Render()
+	assert.Nil(t, err)
+	assert.Equal(t, "(w2.lexicon_symbols LIKE ? AND w2.lexicon_symbols LIKE ?)", res)
+	assert.Equal(t, []interface{}{"%#%", "%+%"}, params)
+
+	c = &WhereLexiconSymbolsClause{table: "w2"}
+	res, params, err = c.Render()
+	assert.Nil(t, err)
+	assert.Equal(t, "w2.lexicon_symbols = ?", res)
+	assert.Equal(t, []interface{}{""}, params)
+}
+
+func TestWhereValidSinceClause(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.Nil(t, err)
+	defer db.Close()
+	_, err = db.Exec(`
+		CREATE TABLE family_lexica (lexicon_name varchar(20), family_order int);
+		INSERT INTO family_lexica VALUES ('OWL2', 0), ('America', 1), ('NWL18', 2), ('NWL20', 3);
+		CREATE TABLE words (word varchar(20), valid_since_order int);
+		INSERT INTO words VALUES ('OLD', 0), ('NEWER', 1), ('NEWEST', 2);`)
+	assert.Nil(t, err)
+
+	find := func(since string) []string {
+		c := &WhereValidSinceClause{table: "w2", since: since, current: "NWL18"}
+		res, params, err := c.Render()
+		assert.Nil(t, err)
+		rows, err := db.Query("SELECT word FROM words w2 WHERE "+res+" ORDER BY word", params...)
+		assert.Nil(t, err)
+		defer rows.Close()
+		words := []string{}
+		for rows.Next() {
+			var w string
+			assert.Nil(t, rows.Scan(&w))
+			words = append(words, w)
+		}
+		return words
+	}
+	assert.Equal(t, []string{"OLD"}, find("OWL2"))
+	assert.Equal(t, []string{"NEWER", "OLD"}, find("America"))
+	assert.Equal(t, []string{"NEWER", "NEWEST", "OLD"}, find("NWL18"))
+	// Lexica after the one searched, or not in the family, match nothing.
+	assert.Empty(t, find("NWL20"))
+	assert.Empty(t, find("CSW21"))
+
+	_, _, err = (&WhereValidSinceClause{table: "w2", current: "NWL18"}).Render()
+	assert.NotNil(t, err)
+}
+
+func TestWhereDefinitionsClause(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.Nil(t, err)
+	defer db.Close()
+	_, err = db.Exec(`
+		CREATE TABLE words (word varchar(20));
+		INSERT INTO words VALUES ('HEX'), ('HEXED'), ('HEXES'), ('HIC');
+		CREATE TABLE definitions (word varchar(20), sense int,
+			part_of_speech varchar(20), root_word varchar(20));
+		INSERT INTO definitions VALUES ('HEX', 0, 'v', ''), ('HEX', 1, 'n', ''),
+			('HEXED', 0, 'v', 'HEX'), ('HEXES', 0, 'v', 'HEX'), ('HEXES', 1, 'n', 'HEX'),
+			('HIC', 0, 'interj', '');`)
+	assert.Nil(t, err)
+
+	find := func(column, value string) []string {
+		c := &WhereDefinitionsClause{table: "w2", column: column, value: value}
+		res, params, err := c.Render()
+		assert.Nil(t, err)
+		rows, err := db.Query("SELECT word FROM words w2 WHERE "+res+" ORDER BY word", params...)
+		assert.Nil(t, err)
+		defer rows.Close()
+		words := []string{}
+		for rows.Next() {
+			var w string
+			assert.Nil(t, rows.Scan(&w))
+			words = append(words, w)
+		}
+		return words
+	}
+	assert.Equal(t, []string{"HEX", "HEXES"}, find("part_of_speech", "n"))
+	assert.Equal(t, []string{"HIC"}, find("part_of_speech", "interj"))
+	assert.Equal(t, []string{"HEXED", "HEXES"}, find("root_word", "HEX"))
+	assert.Empty(t, find("root_word", "HIC"))
+
+	_, _, err = (&WhereDefinitionsClause{table: "w2", column: "root_word"}).Render()
+	assert.NotNil(t, err)
+}
+
+func TestWhereChangedSinceClause(t *testing.T) {
+	c := &WhereChangedSinceClause{table: "alphagrams", prior: "NWL20"}
+	res, params, err := c.Render()
+	assert.Nil(t, err)
+	assert.Equal(t, "alphagrams.alphagram IN (SELECT alphagram FROM lexicon_changes "+
+		"WHERE prior_lexicon = ?)", res)
+	assert.Equal(t, []interface{}{"NWL20"}, params)
+
+	c.kinds = []string{"added", "hooks"}
+	res, params, err = c.Render()
+	assert.Nil(t, err)
+	assert.Equal(t, "alphagrams.alphagram IN (SELECT alphagram FROM lexicon_changes "+
+		"WHERE prior_lexicon = ? AND change IN (?, ?))", res)
+	assert.Equal(t, []interface{}{"NWL20", "added", "hooks"}, params)
+
+	c.kinds = []string{"renamed"}
+	_, _, err = c.Render()
+	assert.NotNil(t, err)
+	_, _, err = (&WhereChangedSinceClause{table: "alphagrams"}).Render()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,52 @@
+package querygen
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// fullTextTokenRe splits a search into "quoted phrases" (optionally
+	// followed by a * for a prefix search) and everything else, by spaces.
+	// A quote without a partner is dropped.
+	fullTextTokenRe = regexp.MustCompile(`"[^"]*"\*?|[^\s"]+`)
+	// fullTextBarewordRe is what FTS5 accepts as a term without quotes.
+	fullTextBarewordRe = regexp.MustCompile(`^[\p{L}\p{N}_]+$`)
+)
+
+// FullTextQuery turns a definition search into an FTS5 query. Phrases in
+// double quotes, prefix* terms, AND, OR, NOT and parentheses pass through;
+// other terms with punctuation in them (x-ray, one's) are quoted so FTS5
+// doesn't take them for syntax. Leading *s, as in the *fish* globs that
+// substring searches took, are dropped.
+func FullTextQuery(search string) string {
+	terms := []string{}
+	for _, tok := range fullTextTokenRe.FindAllString(search, -1) {
+		if strings.HasPrefix(tok, `"`) {
+			terms = append(terms, tok)
+			continue
+		}
+		core := strings.TrimLeft(tok, "(")
+		opens := tok[:len(tok)-len(core)]
+		trimmed := strings.TrimRight(core, ")")
+		closes := core[len(trimmed):]
+		core = strings.TrimLeft(trimmed, "*")
+		suffix := ""
+		if strings.HasSuffix(core, "*") {
+			core = strings.TrimRight(core, "*")
+			suffix = "*"
+		}
+		switch {
+		case core == "":
+			suffix = ""
+		case core == "AND" || core == "OR" || core == "NOT":
+		case fullTextBarewordRe.MatchString(core):
+		default:
+			core = `"` + strings.ReplaceAll(core, `"`, `""`) + `"`
+		}
+		if term := opens + core + suffix + closes; term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return strings.Join(terms, " ")
+}
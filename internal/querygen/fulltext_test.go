@@ -0,0 +1,68 @@
+package querygen
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFullTextQuery(t *testing.T) {
+	for _, tc := range []struct{ in, out string }{
+		{"fish", "fish"},
+		{"  type of fish ", "type of fish"},
+		{"*fish*", "fish*"},
+		{`"type of fish"`, `"type of fish"`},
+		{`"type of"* fish`, `"type of"* fish`},
+		{"(eel OR ray) NOT electric", "(eel OR ray) NOT electric"},
+		{"x-ray one's", `"x-ray" "one's"`},
+		{"x-ray*", `"x-ray"*`},
+		{"* ( )", "( )"},
+		{`say "hi`, `say hi`},
+	} {
+		assert.Equal(t, tc.out, FullTextQuery(tc.in), tc.in)
+	}
+}
+
+func TestDefinitionContainsFullText(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.Nil(t, err)
+	defer db.Close()
+	_, err = db.Exec(`CREATE VIRTUAL TABLE definitions_fts USING fts5(word UNINDEXED,
+		definition, tokenize = 'porter unicode61')`)
+	if err != nil && strings.Contains(err.Error(), "no such module: fts5") {
+		t.Skip("needs -tags sqlite_fts5")
+	}
+	assert.Nil(t, err)
+	_, err = db.Exec(`
+		CREATE TABLE words (word varchar(20), definition varchar(512));
+		INSERT INTO words VALUES ('EEL', 'a snakelike fish [n EELS]'),
+			('RAY', 'a flat fish [n RAYS]'), ('FISHES', 'FISH, to catch fishes [v]'),
+			('XRAY', 'to examine with x-rays [v]'), ('CAT', 'a small mammal [n CATS]');
+		INSERT INTO definitions_fts SELECT word, definition FROM words;`)
+	assert.Nil(t, err)
+
+	find := func(search string) []string {
+		c := &WhereDefinitionContainsClause{searchTerm: search, fullText: true}
+		res, params, err := c.Render()
+		assert.Nil(t, err)
+		rows, err := db.Query("SELECT word FROM words WHERE "+res+" ORDER BY word", params...)
+		assert.Nil(t, err)
+		defer rows.Close()
+		words := []string{}
+		for rows.Next() {
+			var w string
+			assert.Nil(t, rows.Scan(&w))
+			words = append(words, w)
+		}
+		return words
+	}
+	assert.Equal(t, []string{"EEL", "FISHES", "RAY"}, find("fish"))
+	assert.Equal(t, []string{"EEL", "FISHES", "RAY"}, find("*fish*"))
+	assert.Equal(t, []string{"RAY"}, find(`"flat fish"`))
+	assert.Equal(t, []string{"EEL", "RAY"}, find("fish NOT catch"))
+	assert.Equal(t, []string{"CAT", "EEL"}, find("snake* OR mammal"))
+	assert.Equal(t, []string{"XRAY"}, find("x-rays"))
+}
@@ -1,6 +1,7 @@
 package querygen
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -15,107 +16,200 @@ import (
 	"github.com/domino14/word_db_server/config"
 	anagrammer "github.com/domino14/word_db_server/internal/anagramserver/legacyanagrammer"
 	"github.com/domino14/word_db_server/internal/common"
+	"github.com/domino14/word_db_server/internal/wordpattern"
 )
 
+// All of the alphagram queries below pick the matching alphagrams in a
+// subquery, sort them (see orderByClause), and apply any LIMIT/OFFSET
+// there. The three template slots are the WHERE condition, the ORDER BY
+// expression, and the LIMIT/OFFSET clause. Joining the words to the
+// alphagrams doesn't keep the subquery's order, so the subquery numbers
+// the alphagrams in that order, and the words are sorted by the numbers.
+
 // UnexpandedQuery just selects word and alphagram. We save bandwidth and
 // speed by just selecting what we need.
 const UnexpandedQuery = `
 SELECT word, alphagram FROM (
-	SELECT alphagrams.alphagram
+	SELECT alphagrams.alphagram,
+		ROW_NUMBER() OVER (ORDER BY %[2]s) AS position
 	FROM alphagrams
-	WHERE %s
-	ORDER BY alphagrams.probability
-	%s) q
+	WHERE %[1]s
+	ORDER BY %[2]s
+	%[3]s) q
 INNER JOIN words w using (alphagram)
+ORDER BY q.position
 `
 
+// definitionsColumn selects the senses of the definition of each word, in
+// order, as a JSON array of [part of speech, gloss, inflections, root word]
+// arrays.
+const definitionsColumn = `(SELECT json_group_array(json_array(part_of_speech, gloss, inflections, root_word))
+	FROM (SELECT * FROM definitions d WHERE d.word = w.word ORDER BY d.sense))`
+
+// wordsDefinitionsColumn is definitionsColumn for queries on the words
+// table without an alias.
+const wordsDefinitionsColumn = `(SELECT json_group_array(json_array(part_of_speech, gloss, inflections, root_word))
+	FROM (SELECT * FROM definitions d WHERE d.word = words.word ORDER BY d.sense))`
+
+const (
+	// PlayabilityVersion is the first lexicon database version whose
+	// alphagrams have the playability column.
+	PlayabilityVersion = 7
+	// HistoryVersion is the first version whose words have the
+	// first_lexicon and last_lexicon columns.
+	HistoryVersion = 12
+	// DefinitionsVersion is the first version with the definitions table.
+	DefinitionsVersion = 13
+)
+
+// ForVersion adapts one of the word queries here to a lexicon database of
+// an older version, selecting NULL for the columns it doesn't have yet.
+// The other columns stay where they are, so the rows are read the same
+// way.
+func ForVersion(template string, version int) string {
+	if version < DefinitionsVersion {
+		template = strings.ReplaceAll(template, definitionsColumn, "NULL")
+		template = strings.ReplaceAll(template, wordsDefinitionsColumn, "NULL")
+	}
+	if version < HistoryVersion {
+		template = strings.ReplaceAll(template, "first_lexicon, last_lexicon", "NULL, NULL")
+	}
+	if version < PlayabilityVersion {
+		template = strings.ReplaceAll(template, "alphagrams.playability,", "NULL AS playability,")
+		template = strings.ReplaceAll(template, "difficulty, playability FROM", "difficulty, NULL FROM")
+	}
+	return template
+}
+
 // FullQuery selects all the words and alphagram details
 const FullQuery = `
 SELECT word, alphagram, lexicon_symbols, definition, front_hooks, back_hooks,
 inner_front_hook, inner_back_hook, probability,
-combinations, difficulty FROM (
+combinations, difficulty, playability, first_lexicon, last_lexicon,
+` + definitionsColumn + ` FROM (
 	SELECT alphagrams.probability, alphagrams.combinations,
-		alphagrams.alphagram, alphagrams.difficulty
+		alphagrams.alphagram, alphagrams.difficulty, alphagrams.playability,
+		ROW_NUMBER() OVER (ORDER BY %[2]s) AS position
 	FROM alphagrams
-	WHERE %s
-	ORDER BY alphagrams.probability
-	%s) q
+	WHERE %[1]s
+	ORDER BY %[2]s
+	%[3]s) q
 INNER JOIN words w using (alphagram)
+ORDER BY q.position
 `
 
 // AlphagramOnlyQuery is used to select only alphagrams with their info
 const AlphagramOnlyQuery = `
-SELECT alphagram, probability, combinations, difficulty FROM alphagrams
+SELECT alphagram, probability, combinations, difficulty, playability FROM alphagrams
 WHERE %s
+ORDER BY %s
 %s
 `
 
 // WordInfoQuery is used to select words with their info
 const WordInfoQuery = `
 SELECT word, alphagram, lexicon_symbols, definition, front_hooks,
-	back_hooks, inner_front_hook, inner_back_hook
+	back_hooks, inner_front_hook, inner_back_hook, first_lexicon, last_lexicon,
+	` + wordsDefinitionsColumn + `
 FROM words WHERE %s
+ORDER BY %s
+%s
+`
+
+// DefinitionSearchQuery is a full-text search of the definitions, most
+// relevant first. It selects the same columns as WordInfoQuery, and then a
+// snippet of the definition. The bind parameters are the start and end of
+// the highlighting, and the search; the template slot is the LIMIT clause.
+const DefinitionSearchQuery = `
+SELECT word, alphagram, lexicon_symbols, words.definition, front_hooks,
+	back_hooks, inner_front_hook, inner_back_hook, first_lexicon, last_lexicon,
+	` + wordsDefinitionsColumn + `,
+	snippet(definitions_fts, 1, ?, ?, '...', 16)
+FROM definitions_fts INNER JOIN words USING (word)
+WHERE definitions_fts MATCH ?
+ORDER BY rank
 %s
-ORDER BY word
 `
 
 const DeletedWordQuery = `
 SELECT word
 FROM deletedwords WHERE %s
+ORDER BY %s
 %s
-ORDER BY word
 `
 
 // WordFilteredUnexpandedQuery finds alphagrams with matching words, then returns all words for those alphagrams
 const WordFilteredUnexpandedQuery = `
-SELECT w.word, w.alphagram FROM words w
-WHERE w.alphagram IN (
-	SELECT DISTINCT w2.alphagram FROM words w2 WHERE %s
-)
-ORDER BY w.alphagram
-%s
+SELECT word, alphagram FROM (
+	SELECT alphagrams.alphagram,
+		ROW_NUMBER() OVER (ORDER BY %[2]s) AS position
+	FROM alphagrams
+	WHERE alphagrams.alphagram IN (
+		SELECT DISTINCT w2.alphagram FROM words w2 WHERE %[1]s
+	)
+	ORDER BY %[2]s
+	%[3]s) q
+INNER JOIN words w using (alphagram)
+ORDER BY q.position
 `
 
 // WordFilteredFullQuery finds alphagrams with matching words, then returns all words with full info
 const WordFilteredFullQuery = `
-SELECT w.word, w.alphagram, w.lexicon_symbols, w.definition, w.front_hooks, w.back_hooks,
-w.inner_front_hook, w.inner_back_hook, a.probability, a.combinations, a.difficulty
-FROM words w
-INNER JOIN alphagrams a ON w.alphagram = a.alphagram
-WHERE w.alphagram IN (
-	SELECT DISTINCT w2.alphagram FROM words w2 WHERE %s
-)
-ORDER BY a.probability, w.alphagram
-%s
+SELECT word, alphagram, lexicon_symbols, definition, front_hooks, back_hooks,
+inner_front_hook, inner_back_hook, probability,
+combinations, difficulty, playability, first_lexicon, last_lexicon,
+` + definitionsColumn + ` FROM (
+	SELECT alphagrams.probability, alphagrams.combinations,
+		alphagrams.alphagram, alphagrams.difficulty, alphagrams.playability,
+		ROW_NUMBER() OVER (ORDER BY %[2]s) AS position
+	FROM alphagrams
+	WHERE alphagrams.alphagram IN (
+		SELECT DISTINCT w2.alphagram FROM words w2 WHERE %[1]s
+	)
+	ORDER BY %[2]s
+	%[3]s) q
+INNER JOIN words w using (alphagram)
+ORDER BY q.position
 `
 
 // WordFilteredUnexpandedQueryWithAlphagrams finds alphagrams with matching words (with alphagram table access)
 const WordFilteredUnexpandedQueryWithAlphagrams = `
-SELECT w.word, w.alphagram FROM words w
-WHERE w.alphagram IN (
-	SELECT DISTINCT w2.alphagram
-	FROM words w2
-	INNER JOIN alphagrams a2 ON w2.alphagram = a2.alphagram
-	WHERE %s
-)
-ORDER BY w.alphagram
-%s
+SELECT word, alphagram FROM (
+	SELECT alphagrams.alphagram,
+		ROW_NUMBER() OVER (ORDER BY %[2]s) AS position
+	FROM alphagrams
+	WHERE alphagrams.alphagram IN (
+		SELECT DISTINCT w2.alphagram
+		FROM words w2
+		INNER JOIN alphagrams a2 ON w2.alphagram = a2.alphagram
+		WHERE %[1]s
+	)
+	ORDER BY %[2]s
+	%[3]s) q
+INNER JOIN words w using (alphagram)
+ORDER BY q.position
 `
 
 // WordFilteredFullQueryWithAlphagrams finds alphagrams with matching words (with alphagram table access)
 const WordFilteredFullQueryWithAlphagrams = `
-SELECT w.word, w.alphagram, w.lexicon_symbols, w.definition, w.front_hooks, w.back_hooks,
-w.inner_front_hook, w.inner_back_hook, a.probability, a.combinations, a.difficulty
-FROM words w
-INNER JOIN alphagrams a ON w.alphagram = a.alphagram
-WHERE w.alphagram IN (
-	SELECT DISTINCT w2.alphagram
-	FROM words w2
-	INNER JOIN alphagrams a2 ON w2.alphagram = a2.alphagram
-	WHERE %s
-)
-ORDER BY a.probability, w.alphagram
-%s
+SELECT word, alphagram, lexicon_symbols, definition, front_hooks, back_hooks,
+inner_front_hook, inner_back_hook, probability,
+combinations, difficulty, playability, first_lexicon, last_lexicon,
+` + definitionsColumn + ` FROM (
+	SELECT alphagrams.probability, alphagrams.combinations,
+		alphagrams.alphagram, alphagrams.difficulty, alphagrams.playability,
+		ROW_NUMBER() OVER (ORDER BY %[2]s) AS position
+	FROM alphagrams
+	WHERE alphagrams.alphagram IN (
+		SELECT DISTINCT w2.alphagram
+		FROM words w2
+		INNER JOIN alphagrams a2 ON w2.alphagram = a2.alphagram
+		WHERE %[1]s
+	)
+	ORDER BY %[2]s
+	%[3]s) q
+INNER JOIN words w using (alphagram)
+ORDER BY q.position
 `
 
 type QueryType uint8
@@ -139,6 +233,20 @@ type Query struct {
 	rendered     string
 	expandedForm bool
 	qtype        QueryType
+	orderBy      string
+	orderParams  []interface{}
+
+	// The where clauses and their bind params are kept around so that the
+	// query can be re-rendered with a different limit/offset window.
+	whereClauses []string
+	whereParams  []interface{}
+	window       *window
+}
+
+// window is a LIMIT/OFFSET restriction on the alphagrams a query returns.
+type window struct {
+	limit  int
+	offset int
 }
 
 func (q *Query) String() string {
@@ -177,9 +285,11 @@ func NewQuery(bp []interface{}, qt QueryType) *Query {
 
 	return &Query{
 		bindParams:   bp,
+		whereParams:  bp,
 		template:     template,
 		expandedForm: expandedForm,
 		qtype:        qt,
+		orderBy:      defaultOrder(qt),
 	}
 }
 
@@ -222,7 +332,58 @@ func (q *Query) Render(whereClauses []string, limitOffsetClause string) {
 		// This can happen when only LEXICON condition is provided (which doesn't generate SQL)
 		where = "1=1"
 	}
-	q.rendered = fmt.Sprintf(q.template, where, limitOffsetClause)
+	q.whereClauses = whereClauses
+	q.rendered = fmt.Sprintf(q.template, where, q.orderBy, limitOffsetClause)
+	q.bindParams = q.whereAndOrderParams()
+}
+
+// whereAndOrderParams puts the where params together with those of the
+// ORDER BY expression, in the order the template uses them. The templates
+// that number alphagrams sort them before the WHERE as well as after it.
+func (q *Query) whereAndOrderParams() []interface{} {
+	if len(q.orderParams) == 0 {
+		return q.whereParams
+	}
+	bp := make([]interface{}, 0, len(q.whereParams)+2*len(q.orderParams))
+	if i := strings.Index(q.template, "%[2]s"); i >= 0 && i < strings.Index(q.template, "%[1]s") {
+		bp = append(bp, q.orderParams...)
+	}
+	bp = append(bp, q.whereParams...)
+	return append(bp, q.orderParams...)
+}
+
+// restrict re-renders the query so that it only returns the alphagrams in
+// the given window.
+func (q *Query) restrict(w window) {
+	q.window = &w
+	q.Render(q.whereClauses, "LIMIT ? OFFSET ?")
+	bp := make([]interface{}, 0, len(q.bindParams)+2)
+	bp = append(bp, q.bindParams...)
+	q.bindParams = append(bp, w.limit, w.offset)
+}
+
+// Page returns a copy of this query that returns at most `limit` of its
+// alphagrams, skipping the first `offset`. A negative limit returns all of
+// the alphagrams after the offset. If the query already has a limit (from a
+// PROBABILITY_LIMIT condition), the page is taken from within that limit and
+// never extends past it.
+func (q *Query) Page(offset, limit int) *Query {
+	w := window{limit: limit, offset: offset}
+	if q.window != nil {
+		remaining := max(q.window.limit-offset, 0)
+		w.offset = q.window.offset + offset
+		w.limit = remaining
+		if limit >= 0 {
+			w.limit = min(limit, remaining)
+		}
+	}
+	page := NewQuery(q.whereParams, q.qtype)
+	page.orderBy = q.orderBy
+	page.orderParams = q.orderParams
+	page.template = q.template
+	page.whereClauses = q.whereClauses
+	page.restrict(w)
+	return page
 }
 
 // QueryGen is a query generator.
@@ -232,6 +393,18 @@ type QueryGen struct {
 	searchParams []*wordsearcher.SearchRequest_SearchParam
 	maxChunkSize int
 	config       *wglconfig.Config
+	sort         *wordsearcher.SearchRequest_SortSpec
+	// fullTextDefinitions is whether the lexicon database has the
+	// definitions_fts index.
+	fullTextDefinitions bool
+	// lexiconChanges is whether the lexicon database has a lexicon_changes
+	// table.
+	lexiconChanges bool
+	// dbVersion is the version of the lexicon database, if known.
+	dbVersion int
+	// singleQuery is whether Generate must not split the search into
+	// several queries.
+	singleQuery bool
 }
 
 // NewQueryGen generates a new query generator with the given parameters.
@@ -244,11 +417,94 @@ func NewQueryGen(lexiconName string, queryType QueryType,
 		DataPath: cfg.DataPath,
 	}
 
-	return &QueryGen{lexiconName, queryType, searchParams, maxChunkSize, qgenConfig}
+	return &QueryGen{
+		lexiconName:  lexiconName,
+		queryType:    queryType,
+		searchParams: searchParams,
+		maxChunkSize: maxChunkSize,
+		config:       qgenConfig,
+	}
+}
+
+// SetSort sets the order of the generated queries' results. A nil spec
+// means the default order for the query type. A search with a sort is
+// always generated as a single query (see SetSingleQuery), so that all of
+// its results are in that order.
+func (qg *QueryGen) SetSort(spec *wordsearcher.SearchRequest_SortSpec) {
+	qg.sort = spec
+}
+
+// SetSingleQuery sets whether Generate has to return a single query however
+// long the lists in the search are. Long lists are then bound as a single
+// JSON array, as they are inside condition groups. Otherwise they are split
+// across several queries, each of which is ordered on its own.
+func (qg *QueryGen) SetSingleQuery(on bool) {
+	qg.singleQuery = on
+}
+
+// SetFullTextDefinitions sets whether DEFINITION_CONTAINS conditions can
+// use the full-text index of the definitions.
+func (qg *QueryGen) SetFullTextDefinitions(on bool) {
+	qg.fullTextDefinitions = on
+}
+
+// SetLexiconChanges sets whether CHANGED_SINCE conditions can be searched,
+// which they can once dbmaker has stored a diff in the lexicon database.
+func (qg *QueryGen) SetLexiconChanges(on bool) {
+	qg.lexiconChanges = on
+}
+
+// SetDBVersion sets the version of the lexicon database the queries are
+// for, so that they only use the columns it has, and conditions it can't
+// search are rejected.
+func (qg *QueryGen) SetDBVersion(version int) {
+	qg.dbVersion = version
+}
+
+// newQuery creates a query of this generator's type and order.
+func (qg *QueryGen) newQuery(bp []interface{}) (*Query, error) {
+	orderBy, orderParams, err := orderByClause(qg.queryType, qg.sort)
+	if err != nil {
+		return nil, err
+	}
+	if qg.sort.GetField() == wordsearcher.SearchRequest_SortSpec_PLAYABILITY &&
+		qg.dbVersion > 0 && qg.dbVersion < PlayabilityVersion {
+		return nil, fmt.Errorf("the %v database is version %d; sorting by playability needs version %d",
+			qg.lexiconName, qg.dbVersion, PlayabilityVersion)
+	}
+	q := NewQuery(bp, qg.queryType)
+	q.orderBy = orderBy
+	q.orderParams = orderParams
+	if qg.dbVersion > 0 {
+		q.template = ForVersion(q.template, qg.dbVersion)
+	}
+	return q, nil
+}
+
+// conditionVersions are the lexicon database versions that conditions
+// need, for those that need more than the oldest supported one.
+var conditionVersions = map[wordsearcher.SearchRequest_Condition]int{
+	wordsearcher.SearchRequest_PLAYABILITY_RANGE: PlayabilityVersion,
+	wordsearcher.SearchRequest_PART_OF_SPEECH:    DefinitionsVersion,
+	wordsearcher.SearchRequest_INFLECTION_OF:     DefinitionsVersion,
 }
 
-func (qg *QueryGen) generateWhereClause(sp *wordsearcher.SearchRequest_SearchParam) (Clause, error) {
+// needHistory makes sure the database stores which lexica each word was
+// in, which databases only do from HistoryVersion on.
+func (qg *QueryGen) needHistory(condition wordsearcher.SearchRequest_Condition) error {
+	if qg.dbVersion > 0 && qg.dbVersion < HistoryVersion {
+		return fmt.Errorf("no lexicon history is stored in the %v database (version %d); %v needs version %d",
+			qg.lexiconName, qg.dbVersion, condition, HistoryVersion)
+	}
+	return nil
+}
+
+func (qg *QueryGen) generateWhereClause(ctx context.Context, sp *wordsearcher.SearchRequest_SearchParam) (Clause, error) {
 	condition := sp.GetCondition()
+	if need := conditionVersions[condition]; qg.dbVersion > 0 && qg.dbVersion < need {
+		return nil, fmt.Errorf("the %v database is version %d; %v needs version %d",
+			qg.lexiconName, qg.dbVersion, condition, need)
+	}
 
 	// Determine the correct table alias for alphagrams based on query type
 	alphagramsTable := "alphagrams"
@@ -288,6 +544,13 @@ func (qg *QueryGen) generateWhereClause(sp *wordsearcher.SearchRequest_SearchPar
 		}
 		return NewWhereBetweenClause(alphagramsTable, "difficulty", minmax), nil
 
+	case wordsearcher.SearchRequest_PLAYABILITY_RANGE:
+		minmax := sp.GetMinmax()
+		if minmax == nil {
+			return nil, errors.New("minmax not provided for playability range request")
+		}
+		return NewWhereBetweenClause(alphagramsTable, "playability", minmax), nil
+
 	case wordsearcher.SearchRequest_NUMBER_OF_VOWELS:
 		minmax := sp.GetMinmax()
 		if minmax == nil {
@@ -302,6 +565,37 @@ func (qg *QueryGen) generateWhereClause(sp *wordsearcher.SearchRequest_SearchPar
 		}
 		return NewWhereBetweenClause(alphagramsTable, "point_value", minmax), nil
 
+	case wordsearcher.SearchRequest_NUM_DISTINCT_LETTERS:
+		minmax := sp.GetMinmax()
+		if minmax == nil {
+			return nil, errors.New("minmax not provided for num distinct letters request")
+		}
+		return NewWhereBetweenClause(alphagramsTable, "num_distinct_letters", minmax), nil
+
+	case wordsearcher.SearchRequest_MAX_LETTER_REPEATS:
+		minmax := sp.GetMinmax()
+		if minmax == nil {
+			return nil, errors.New("minmax not provided for max letter repeats request")
+		}
+		return NewWhereBetweenClause(alphagramsTable, "max_letter_repeats", minmax), nil
+
+	case wordsearcher.SearchRequest_CONTAINS_LETTERS,
+		wordsearcher.SearchRequest_EXCLUDES_LETTERS:
+		desc := sp.GetStringvalue()
+		if desc == nil {
+			return nil, errors.New("stringvalue not provided for letter content request")
+		}
+		dist, err := tilemapping.ProbableLetterDistribution(qg.config, qg.lexiconName)
+		if err != nil {
+			return nil, err
+		}
+		letters, err := parseLetterCounts(desc.GetValue(), dist)
+		if err != nil {
+			return nil, err
+		}
+		return NewWhereLetterCountClause(alphagramsTable, letters,
+			condition == wordsearcher.SearchRequest_EXCLUDES_LETTERS), nil
+
 	case wordsearcher.SearchRequest_NOT_IN_LEXICON:
 		desc := sp.GetNumbervalue()
 		var column string
@@ -335,7 +629,10 @@ func (qg *QueryGen) generateWhereClause(sp *wordsearcher.SearchRequest_SearchPar
 		var words []string
 		if strings.Contains(letters, "(") {
 			// defer to the legacy anagrammer. This is a "range" query.
-			words = anagrammer.Anagram(letters, dawg, anagrammer.ModeExact)
+			words, err = anagrammer.AnagramContext(ctx, letters, dawg, anagrammer.ModeExact)
+			if err != nil {
+				return nil, err
+			}
 		} else {
 			da := kwg.DaPool.Get().(*kwg.KWGAnagrammer)
 			defer kwg.DaPool.Put(da)
@@ -343,10 +640,13 @@ func (qg *QueryGen) generateWhereClause(sp *wordsearcher.SearchRequest_SearchPar
 			if err != nil {
 				return nil, err
 			}
-			da.Anagram(dawg, func(word tilemapping.MachineWord) error {
+			err = da.Anagram(dawg, func(word tilemapping.MachineWord) error {
 				words = append(words, word.UserVisible(alph))
-				return nil
+				return ctx.Err()
 			})
+			if err != nil {
+				return nil, err
+			}
 		}
 		if len(words) == 0 {
 			return nil, errors.New("no words matched this anagram search")
@@ -359,6 +659,33 @@ func (qg *QueryGen) generateWhereClause(sp *wordsearcher.SearchRequest_SearchPar
 
 		return NewWhereInClause(alphagramsTable, "alphagram", newSp), nil
 
+	case wordsearcher.SearchRequest_WORD_PATTERN:
+		dawg, err := kwg.GetKWG(qg.config, qg.lexiconName)
+		if err != nil {
+			return nil, err
+		}
+		dist, err := tilemapping.ProbableLetterDistribution(qg.config, qg.lexiconName)
+		if err != nil {
+			return nil, err
+		}
+		pattern, err := wordpattern.FromParam(sp.GetPattern(), dawg.GetAlphabet())
+		if err != nil {
+			return nil, err
+		}
+		words, err := pattern.WordsContext(ctx, dawg)
+		if err != nil {
+			return nil, err
+		}
+		if len(words) == 0 {
+			return nil, errors.New("no words matched this pattern")
+		}
+		newSp := &wordsearcher.SearchRequest_SearchParam{
+			Conditionparam: &wordsearcher.SearchRequest_SearchParam_Stringarray{
+				Stringarray: &wordsearcher.SearchRequest_StringArray{
+					Values: alphasFromWordList(words, dist)}}}
+
+		return NewWhereInClause(alphagramsTable, "alphagram", newSp), nil
+
 	case wordsearcher.SearchRequest_UPLOADED_WORD_OR_ALPHAGRAM_LIST:
 		words := sp.GetStringarray()
 		if words == nil || len(words.Values) == 0 {
@@ -405,6 +732,102 @@ func (qg *QueryGen) generateWhereClause(sp *wordsearcher.SearchRequest_SearchPar
 		}
 		return qg.generateHooksClause(hooksParam)
 
+	case wordsearcher.SearchRequest_NUM_FRONT_HOOKS,
+		wordsearcher.SearchRequest_NUM_BACK_HOOKS,
+		wordsearcher.SearchRequest_NUM_HOOKS,
+		wordsearcher.SearchRequest_NUM_FRONT_EXTENSIONS,
+		wordsearcher.SearchRequest_NUM_BACK_EXTENSIONS:
+		minmax := sp.GetMinmax()
+		if minmax == nil {
+			return nil, fmt.Errorf("minmax not provided for %v request", condition)
+		}
+		return NewWhereBetweenClause("w2", hookCountColumns[condition], minmax), nil
+
+	case wordsearcher.SearchRequest_CROSS_LEXICON:
+		param := sp.GetCrosslexicon()
+		if param == nil {
+			return nil, errors.New("crosslexicon not provided for cross lexicon request")
+		}
+		return qg.generateCrossLexiconClause(param, alphagramsTable)
+
+	case wordsearcher.SearchRequest_LEXICON_SYMBOLS:
+		stringValue := sp.GetStringvalue()
+		if stringValue == nil {
+			return nil, errors.New("stringvalue not provided for lexicon symbols request")
+		}
+		return &WhereLexiconSymbolsClause{
+			table:   "w2",
+			symbols: strings.TrimSpace(stringValue.GetValue()),
+		}, nil
+
+	case wordsearcher.SearchRequest_ADDED_IN_LEXICON:
+		stringValue := sp.GetStringvalue()
+		if stringValue == nil {
+			return nil, errors.New("stringvalue not provided for added in lexicon request")
+		}
+		if err := qg.needHistory(condition); err != nil {
+			return nil, err
+		}
+		return NewWhereEqualsClause("w2", "first_lexicon", stringValue), nil
+
+	case wordsearcher.SearchRequest_IN_EVERY_LEXICON_SINCE:
+		stringValue := sp.GetStringvalue()
+		if stringValue == nil {
+			return nil, errors.New("stringvalue not provided for in every lexicon since request")
+		}
+		if err := qg.needHistory(condition); err != nil {
+			return nil, err
+		}
+		return &WhereValidSinceClause{
+			table:   "w2",
+			since:   strings.TrimSpace(stringValue.GetValue()),
+			current: qg.lexiconName,
+		}, nil
+
+	case wordsearcher.SearchRequest_PART_OF_SPEECH:
+		stringValue := sp.GetStringvalue()
+		if stringValue == nil {
+			return nil, errors.New("stringvalue not provided for part of speech request")
+		}
+		return &WhereDefinitionsClause{
+			table:  "w2",
+			column: "part_of_speech",
+			value:  strings.ToLower(strings.TrimSpace(stringValue.GetValue())),
+		}, nil
+
+	case wordsearcher.SearchRequest_INFLECTION_OF:
+		stringValue := sp.GetStringvalue()
+		if stringValue == nil {
+			return nil, errors.New("stringvalue not provided for inflection of request")
+		}
+		return &WhereDefinitionsClause{
+			table:  "w2",
+			column: "root_word",
+			value:  strings.ToUpper(strings.TrimSpace(stringValue.GetValue())),
+		}, nil
+
+	case wordsearcher.SearchRequest_HOOKLESS:
+		return NewWhereEqualsNumberClause("w2", "num_hooks", 0), nil
+
+	case wordsearcher.SearchRequest_CHANGED_SINCE:
+		stringArray := sp.GetStringarray()
+		if stringArray == nil || len(stringArray.GetValues()) == 0 {
+			return nil, errors.New("stringarray not provided for changed since request")
+		}
+		if !qg.lexiconChanges {
+			return nil, fmt.Errorf("no lexicon changes are stored in the %v database", qg.lexiconName)
+		}
+		values := stringArray.GetValues()
+		kinds := []string{}
+		for _, v := range values[1:] {
+			kinds = append(kinds, strings.ToLower(strings.TrimSpace(v)))
+		}
+		return &WhereChangedSinceClause{
+			table: alphagramsTable,
+			prior: strings.TrimSpace(values[0]),
+			kinds: kinds,
+		}, nil
+
 	case wordsearcher.SearchRequest_DEFINITION_CONTAINS:
 		stringValue := sp.GetStringvalue()
 		if stringValue == nil {
@@ -412,12 +835,48 @@ func (qg *QueryGen) generateWhereClause(sp *wordsearcher.SearchRequest_SearchPar
 		}
 		return qg.generateDefinitionContainsClause(stringValue.GetValue())
 
+	case wordsearcher.SearchRequest_CONDITION_GROUP:
+		group := sp.GetGroup()
+		if group == nil {
+			return nil, errors.New("group not provided for condition group request")
+		}
+		return qg.generateGroupClause(ctx, group)
+
 	default:
 		return nil, fmt.Errorf("unhandled search request condition: %v", condition)
 
 	}
 }
 
+// hookCountColumns are the words table columns for the hook count
+// conditions.
+var hookCountColumns = map[wordsearcher.SearchRequest_Condition]string{
+	wordsearcher.SearchRequest_NUM_FRONT_HOOKS:      "num_front_hooks",
+	wordsearcher.SearchRequest_NUM_BACK_HOOKS:       "num_back_hooks",
+	wordsearcher.SearchRequest_NUM_HOOKS:            "num_hooks",
+	wordsearcher.SearchRequest_NUM_FRONT_EXTENSIONS: "num_front_extensions",
+	wordsearcher.SearchRequest_NUM_BACK_EXTENSIONS:  "num_back_extensions",
+}
+
+// generateCrossLexiconClause checks words or alphagrams against other
+// lexica. The lexica are loaded here first, so that an unknown lexicon is
+// reported as such instead of failing inside the query.
+func (qg *QueryGen) generateCrossLexiconClause(param *wordsearcher.SearchRequest_CrossLexiconParam,
+	alphagramsTable string) (Clause, error) {
+
+	for _, lex := range append(param.GetInLexica(), param.GetNotInLexica()...) {
+		if _, err := kwg.GetKWG(qg.config, lex); err != nil {
+			return nil, fmt.Errorf("lexicon %v is not supported: %w", lex, err)
+		}
+	}
+	if param.GetWordLevel() {
+		return NewWhereCrossLexiconClause("w2", "word", InLexiconFunc,
+			param.GetInLexica(), param.GetNotInLexica()), nil
+	}
+	return NewWhereCrossLexiconClause(alphagramsTable, "alphagram", AlphagramInLexiconFunc,
+		param.GetInLexica(), param.GetNotInLexica()), nil
+}
+
 // generateHooksClause creates a clause for searching words by hooks
 func (qg *QueryGen) generateHooksClause(hooksParam *wordsearcher.SearchRequest_HooksParam) (Clause, error) {
 	hookType := hooksParam.GetHookType()
@@ -449,6 +908,33 @@ func (qg *QueryGen) generateHooksClause(hooksParam *wordsearcher.SearchRequest_H
 	}
 }
 
+// generateGroupClause creates a clause for a nested group of conditions.
+// List conditions inside a group can't be chunked into several queries
+// (splitting up an IN list is only correct when it is ANDed with everything
+// else), so long lists are bound as a single JSON array instead.
+func (qg *QueryGen) generateGroupClause(ctx context.Context, group *wordsearcher.SearchRequest_ConditionGroup) (Clause, error) {
+	clauses := []Clause{}
+	for _, param := range group.GetParams() {
+		clause, err := qg.generateWhereClause(ctx, param)
+		if err != nil {
+			return nil, err
+		}
+		if clause == nil {
+			continue
+		}
+		if lc, ok := clause.(*WhereInClause); ok {
+			if lc.numItems == 0 {
+				return nil, errors.New("list condition in group has no items")
+			}
+			if lc.numItems > qg.maxChunkSize {
+				lc.useJSONArray()
+			}
+		}
+		clauses = append(clauses, clause)
+	}
+	return NewWhereGroupClause(group.GetOperator(), clauses), nil
+}
+
 // generateDefinitionContainsClause creates a clause for searching words by definition content
 func (qg *QueryGen) generateDefinitionContainsClause(searchTerm string) (Clause, error) {
 	searchTerm = strings.TrimSpace(searchTerm)
@@ -456,7 +942,8 @@ func (qg *QueryGen) generateDefinitionContainsClause(searchTerm string) (Clause,
 		return nil, errors.New("definition search term cannot be empty")
 	}
 
-	return &WhereDefinitionContainsClause{searchTerm: searchTerm}, nil
+	return &WhereDefinitionContainsClause{searchTerm: searchTerm,
+		fullText: qg.fullTextDefinitions}, nil
 }
 
 func isMutexCondition(condition wordsearcher.SearchRequest_Condition) bool {
@@ -469,7 +956,8 @@ func isMutexCondition(condition wordsearcher.SearchRequest_Condition) bool {
 	case wordsearcher.SearchRequest_PROBABILITY_LIST,
 		wordsearcher.SearchRequest_ALPHAGRAM_LIST,
 		wordsearcher.SearchRequest_PROBABILITY_LIMIT,
-		wordsearcher.SearchRequest_MATCHING_ANAGRAM:
+		wordsearcher.SearchRequest_MATCHING_ANAGRAM,
+		wordsearcher.SearchRequest_WORD_PATTERN:
 
 		return true
 
@@ -477,6 +965,30 @@ func isMutexCondition(condition wordsearcher.SearchRequest_Condition) bool {
 	return false
 }
 
+// validateGroup returns an error if a condition group, or any group nested
+// within it, is empty or contains conditions that only make sense at the
+// top level.
+func validateGroup(group *wordsearcher.SearchRequest_ConditionGroup) error {
+	if group == nil || len(group.GetParams()) == 0 {
+		return errors.New("condition groups must not be empty")
+	}
+	for _, param := range group.GetParams() {
+		switch param.Condition {
+		case wordsearcher.SearchRequest_LEXICON,
+			wordsearcher.SearchRequest_PROBABILITY_LIMIT,
+			wordsearcher.SearchRequest_WORD_LIST,
+			wordsearcher.SearchRequest_DELETED_WORD:
+			return fmt.Errorf("%v condition is not allowed inside a condition group",
+				param.Condition)
+		case wordsearcher.SearchRequest_CONDITION_GROUP:
+			if err := validateGroup(param.GetGroup()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // Validate returns an error if the query is invalid.
 func (qg *QueryGen) Validate() error {
 	numMutexDescriptions := 0
@@ -496,6 +1008,11 @@ func (qg *QueryGen) Validate() error {
 		if param.Condition == wordsearcher.SearchRequest_LENGTH {
 			lengthCondition = true
 		}
+		if param.Condition == wordsearcher.SearchRequest_CONDITION_GROUP {
+			if err := validateGroup(param.GetGroup()); err != nil {
+				return err
+			}
+		}
 	}
 	if deletedWordCondition {
 		// deleted_word, and at most one other condition, and it must be length
@@ -515,6 +1032,9 @@ func (qg *QueryGen) Validate() error {
 		return errors.New("any condition with a list of alphagrams or " +
 			"probabilities must be last in the list")
 	}
+	if _, _, err := orderByClause(qg.queryType, qg.sort); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -526,12 +1046,13 @@ func (qg *QueryGen) maybeChunk(clauses []Clause) (bool, []string,
 	bindParams := []interface{}{}
 	queries := []*Query{}
 
+	chunkLists := !qg.singleQuery && qg.sort == nil
 	for _, clause := range clauses {
-		if isListClause(clause) {
+		if isListClause(clause) && clause.(*WhereInClause).numItems == 0 {
+			return false, nil, nil, nil, errors.New("query returns no results")
+		}
+		if isListClause(clause) && chunkLists {
 			lc := clause.(*WhereInClause)
-			if lc.numItems == 0 {
-				return false, nil, nil, nil, errors.New("query returns no results")
-			}
 			idx := 0
 			for idx < lc.numItems {
 				newWhereClause := NewWhereInClause(lc.table, lc.column,
@@ -542,13 +1063,19 @@ func (qg *QueryGen) maybeChunk(clauses []Clause) (bool, []string,
 					return false, nil, nil, nil, err
 				}
 				newRenderedWhereClauses := append(renderedWhereClauses, r)
-				query := NewQuery(append(bindParams, bp...), qg.queryType)
+				query, err := qg.newQuery(append(bindParams, bp...))
+				if err != nil {
+					return false, nil, nil, nil, err
+				}
 				query.Render(newRenderedWhereClauses, "")
 				queries = append(queries, query)
 				multipleQueriesGenerated = true
 				idx += qg.maxChunkSize
 			}
 		} else {
+			if lc, ok := clause.(*WhereInClause); ok && lc.numItems > qg.maxChunkSize {
+				lc.useJSONArray()
+			}
 			r, bp, err := clause.Render()
 			if err != nil {
 				return false, nil, nil, nil, err
@@ -563,13 +1090,14 @@ func (qg *QueryGen) maybeChunk(clauses []Clause) (bool, []string,
 }
 
 // Generate returns a list of *Query objects. Each query must be individually
-// executed.
-func (qg *QueryGen) Generate() ([]*Query, error) {
+// executed. Conditions that search the word graph (anagrams and patterns)
+// stop early if ctx is done.
+func (qg *QueryGen) Generate(ctx context.Context) ([]*Query, error) {
 	clauses := []Clause{}
 
-	var loffClause Clause
+	var loffClause *LimitOffsetClause
 	for _, param := range qg.searchParams {
-		clause, err := qg.generateWhereClause(param)
+		clause, err := qg.generateWhereClause(ctx, param)
 		log.Debug().Msgf("For param %v generated clause %v (err %v)", param, clause, err)
 		if err != nil {
 			return nil, err
@@ -598,22 +1126,16 @@ func (qg *QueryGen) Generate() ([]*Query, error) {
 				"a simpler query (remove probability limit)")
 		}
 	} else {
-		var renderedLOClause string
-		var err error
-		var bp []interface{}
+		log.Debug().Interface("bindParams", bindParams).Interface("rwc", rwc).
+			Msg("bd")
+		query, err := qg.newQuery(bindParams)
+		if err != nil {
+			return nil, err
+		}
+		query.Render(rwc, "")
 		if loffClause != nil {
-			renderedLOClause, bp, err = loffClause.Render()
-			if err != nil {
-				return nil, err
-			}
-			bindParams = append(bindParams, bp...)
-		} else {
-			renderedLOClause = ""
+			query.restrict(loffClause.window())
 		}
-		log.Debug().Interface("bindParams", bindParams).Interface("rwc", rwc).Interface("renderedLOClause", renderedLOClause).
-			Msg("bd")
-		query := NewQuery(bindParams, qg.queryType)
-		query.Render(rwc, renderedLOClause)
 		queries = append(queries, query)
 
 	}
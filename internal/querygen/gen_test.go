@@ -0,0 +1,230 @@
+package querygen
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domino14/word_db_server/api/rpc/wordsearcher"
+	"github.com/domino14/word_db_server/config"
+)
+
+func minMaxSP(c wordsearcher.SearchRequest_Condition, min, max int32) *wordsearcher.SearchRequest_SearchParam {
+	return &wordsearcher.SearchRequest_SearchParam{
+		Condition: c,
+		Conditionparam: &wordsearcher.SearchRequest_SearchParam_Minmax{
+			Minmax: &wordsearcher.SearchRequest_MinMax{Min: min, Max: max}}}
+}
+
+func alphagramListSP(alphas []string) *wordsearcher.SearchRequest_SearchParam {
+	return &wordsearcher.SearchRequest_SearchParam{
+		Condition: wordsearcher.SearchRequest_ALPHAGRAM_LIST,
+		Conditionparam: &wordsearcher.SearchRequest_SearchParam_Stringarray{
+			Stringarray: &wordsearcher.SearchRequest_StringArray{Values: alphas}}}
+}
+
+func groupSP(op wordsearcher.SearchRequest_GroupOperator,
+	params ...*wordsearcher.SearchRequest_SearchParam) *wordsearcher.SearchRequest_SearchParam {
+	return &wordsearcher.SearchRequest_SearchParam{
+		Condition: wordsearcher.SearchRequest_CONDITION_GROUP,
+		Conditionparam: &wordsearcher.SearchRequest_SearchParam_Group{
+			Group: &wordsearcher.SearchRequest_ConditionGroup{
+				Operator: op,
+				Params:   params,
+			}}}
+}
+
+func fakeAlphagrams(n int) []string {
+	alphas := make([]string, n)
+	for i := range alphas {
+		alphas[i] = fmt.Sprintf("A%d", i)
+	}
+	return alphas
+}
+
+func TestGenerateOrGroup(t *testing.T) {
+	params := []*wordsearcher.SearchRequest_SearchParam{
+		groupSP(wordsearcher.SearchRequest_OR,
+			groupSP(wordsearcher.SearchRequest_AND,
+				minMaxSP(wordsearcher.SearchRequest_LENGTH, 7, 7),
+				minMaxSP(wordsearcher.SearchRequest_PROBABILITY_RANGE, 1, 2000)),
+			groupSP(wordsearcher.SearchRequest_AND,
+				minMaxSP(wordsearcher.SearchRequest_LENGTH, 8, 8),
+				minMaxSP(wordsearcher.SearchRequest_DIFFICULTY_RANGE, 80, 100))),
+	}
+	qg := NewQueryGen("NWL23", AlphagramsAndWords, params, 3, &config.Config{})
+	assert.Nil(t, qg.Validate())
+	queries, err := qg.Generate(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(queries))
+	assert.Contains(t, queries[0].Rendered(),
+		"WHERE ((alphagrams.length = ? AND alphagrams.probability BETWEEN ? and ?) OR "+
+			"(alphagrams.length = ? AND alphagrams.difficulty BETWEEN ? and ?))")
+	assert.Equal(t, []interface{}{int32(7), int32(1), int32(2000), int32(8),
+		int32(80), int32(100)}, queries[0].BindParams())
+}
+
+func TestGenerateLongListInGroup(t *testing.T) {
+	// A list inside a group can't be chunked, so it is bound as JSON.
+	alphas := fakeAlphagrams(5)
+	params := []*wordsearcher.SearchRequest_SearchParam{
+		minMaxSP(wordsearcher.SearchRequest_LENGTH, 7, 7),
+		groupSP(wordsearcher.SearchRequest_NOT, alphagramListSP(alphas)),
+	}
+	qg := NewQueryGen("NWL23", AlphagramsAndWords, params, 3, &config.Config{})
+	assert.Nil(t, qg.Validate())
+	queries, err := qg.Generate(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(queries))
+	assert.Contains(t, queries[0].Rendered(),
+		"WHERE alphagrams.length = ? AND "+
+			"NOT (alphagrams.alphagram IN (SELECT value FROM json_each(?)))")
+	assert.Equal(t, []interface{}{int32(7), `["A0","A1","A2","A3","A4"]`},
+		queries[0].BindParams())
+}
+
+func TestGenerateGroupWithChunkedList(t *testing.T) {
+	// A top-level list is still chunked; the group is repeated in every chunk.
+	params := []*wordsearcher.SearchRequest_SearchParam{
+		groupSP(wordsearcher.SearchRequest_OR,
+			minMaxSP(wordsearcher.SearchRequest_LENGTH, 7, 7),
+			minMaxSP(wordsearcher.SearchRequest_NUMBER_OF_ANAGRAMS, 2, 5)),
+		alphagramListSP(fakeAlphagrams(5)),
+	}
+	qg := NewQueryGen("NWL23", AlphagramsAndWords, params, 3, &config.Config{})
+	assert.Nil(t, qg.Validate())
+	queries, err := qg.Generate(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(queries))
+	for _, q := range queries {
+		assert.Contains(t, q.Rendered(),
+			"WHERE (alphagrams.length = ? OR alphagrams.num_anagrams BETWEEN ? and ?) AND "+
+				"alphagrams.alphagram IN (")
+	}
+	assert.Equal(t, []interface{}{int32(7), int32(2), int32(5), "A0", "A1", "A2"},
+		queries[0].BindParams())
+	assert.Equal(t, []interface{}{int32(7), int32(2), int32(5), "A3", "A4"},
+		queries[1].BindParams())
+}
+
+func TestGenerateSortedListIsOneQuery(t *testing.T) {
+	// A sorted search can't be split up, or each chunk would be sorted on
+	// its own; the long list is bound as one JSON array instead.
+	params := []*wordsearcher.SearchRequest_SearchParam{
+		minMaxSP(wordsearcher.SearchRequest_LENGTH, 7, 7),
+		alphagramListSP(fakeAlphagrams(5)),
+	}
+	qg := NewQueryGen("NWL23", AlphagramsAndWords, params, 3, &config.Config{})
+	qg.SetSort(&wordsearcher.SearchRequest_SortSpec{Field: wordsearcher.SearchRequest_SortSpec_ALPHAGRAM})
+	queries, err := qg.Generate(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(queries))
+	assert.Contains(t, queries[0].Rendered(),
+		"alphagrams.alphagram IN (SELECT value FROM json_each(?))")
+	assert.Contains(t, queries[0].Rendered(), "ORDER BY alphagrams.alphagram ASC")
+	assert.Equal(t, []interface{}{int32(7), `["A0","A1","A2","A3","A4"]`},
+		queries[0].BindParams())
+
+	// So is a search that is being paged through, in the default order.
+	qg = NewQueryGen("NWL23", AlphagramsAndWords, params, 3, &config.Config{})
+	qg.SetSingleQuery(true)
+	queries, err = qg.Generate(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(queries))
+
+	// Short lists are still bound item by item.
+	qg = NewQueryGen("NWL23", AlphagramsAndWords, params, 10, &config.Config{})
+	qg.SetSingleQuery(true)
+	queries, err = qg.Generate(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{int32(7), "A0", "A1", "A2", "A3", "A4"},
+		queries[0].BindParams())
+}
+
+func TestValidateGroup(t *testing.T) {
+	for _, params := range [][]*wordsearcher.SearchRequest_SearchParam{
+		{groupSP(wordsearcher.SearchRequest_OR)},
+		{groupSP(wordsearcher.SearchRequest_OR,
+			minMaxSP(wordsearcher.SearchRequest_PROBABILITY_LIMIT, 1, 100))},
+		{groupSP(wordsearcher.SearchRequest_AND,
+			groupSP(wordsearcher.SearchRequest_NOT,
+				&wordsearcher.SearchRequest_SearchParam{
+					Condition: wordsearcher.SearchRequest_DELETED_WORD}))},
+	} {
+		qg := NewQueryGen("NWL23", AlphagramsAndWords, params, 3, &config.Config{})
+		assert.NotNil(t, qg.Validate())
+	}
+}
+
+func TestQueryPage(t *testing.T) {
+	params := []*wordsearcher.SearchRequest_SearchParam{
+		minMaxSP(wordsearcher.SearchRequest_LENGTH, 8, 8),
+	}
+	qg := NewQueryGen("NWL23", AlphagramsAndWords, params, 3, &config.Config{})
+	queries, err := qg.Generate(context.Background())
+	assert.Nil(t, err)
+	page := queries[0].Page(200, 100)
+	assert.Contains(t, page.Rendered(), "LIMIT ? OFFSET ?")
+	assert.Equal(t, []interface{}{int32(8), 100, 200}, page.BindParams())
+	// The original query is unchanged.
+	assert.Equal(t, []interface{}{int32(8)}, queries[0].BindParams())
+}
+
+func TestQueryPageWithinProbabilityLimit(t *testing.T) {
+	params := []*wordsearcher.SearchRequest_SearchParam{
+		minMaxSP(wordsearcher.SearchRequest_LENGTH, 8, 8),
+		minMaxSP(wordsearcher.SearchRequest_PROBABILITY_LIMIT, 101, 250),
+	}
+	qg := NewQueryGen("NWL23", AlphagramsAndWords, params, 3, &config.Config{})
+	queries, err := qg.Generate(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{int32(8), 150, 100}, queries[0].BindParams())
+
+	assert.Equal(t, []interface{}{int32(8), 100, 100},
+		queries[0].Page(0, 100).BindParams())
+	// The last page is cut off at the end of the probability limit.
+	assert.Equal(t, []interface{}{int32(8), 50, 200},
+		queries[0].Page(100, 100).BindParams())
+	assert.Equal(t, []interface{}{int32(8), 0, 300},
+		queries[0].Page(200, 100).BindParams())
+	// No limit means the rest of the probability limit.
+	assert.Equal(t, []interface{}{int32(8), 120, 130},
+		queries[0].Page(30, -1).BindParams())
+}
+
+func TestGenerateHookCounts(t *testing.T) {
+	params := []*wordsearcher.SearchRequest_SearchParam{
+		minMaxSP(wordsearcher.SearchRequest_LENGTH, 7, 7),
+		minMaxSP(wordsearcher.SearchRequest_NUM_BACK_HOOKS, 3, 100),
+		groupSP(wordsearcher.SearchRequest_OR,
+			&wordsearcher.SearchRequest_SearchParam{Condition: wordsearcher.SearchRequest_HOOKLESS},
+			minMaxSP(wordsearcher.SearchRequest_NUM_FRONT_EXTENSIONS, 5, 5)),
+	}
+	qg := NewQueryGen("NWL23", WordFilteredUnexpandedWithAlphagrams, params, 3, &config.Config{})
+	assert.Nil(t, qg.Validate())
+	queries, err := qg.Generate(context.Background())
+	assert.Nil(t, err)
+	assert.Contains(t, queries[0].Rendered(),
+		"WHERE a2.length = ? AND w2.num_back_hooks BETWEEN ? and ? AND "+
+			"(w2.num_hooks = ? OR w2.num_front_extensions = ?)")
+	assert.Equal(t, []interface{}{int32(7), int32(3), int32(100), 0, int32(5)},
+		queries[0].BindParams())
+}
+
+func TestForVersion(t *testing.T) {
+	assert.Equal(t, FullQuery, ForVersion(FullQuery, 15))
+	q := ForVersion(FullQuery, 12)
+	assert.Contains(t, q, "first_lexicon, last_lexicon")
+	assert.NotContains(t, q, "definitions")
+	q = ForVersion(WordInfoQuery, 11)
+	assert.NotContains(t, q, "first_lexicon")
+	assert.NotContains(t, q, "definitions")
+	assert.Contains(t, q, "inner_back_hook, NULL, NULL,\n\tNULL\nFROM words")
+	assert.Contains(t, ForVersion(FullQuery, 7), "alphagrams.playability")
+	q = ForVersion(FullQuery, 6)
+	assert.NotContains(t, q, "alphagrams.playability")
+	assert.Contains(t, q, "NULL AS playability")
+	assert.NotContains(t, ForVersion(AlphagramOnlyQuery, 6), "playability")
+}
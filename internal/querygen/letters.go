@@ -0,0 +1,37 @@
+package querygen
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/domino14/word-golib/tilemapping"
+)
+
+// parseLetterCounts splits a string of letters into tiles of the given
+// distribution, and counts how many times each one occurs. Letters are
+// returned in the order they first appear.
+func parseLetterCounts(letters string, dist *tilemapping.LetterDistribution) ([]LetterCount, error) {
+	letters = strings.TrimSpace(strings.ToUpper(letters))
+	if letters == "" {
+		return nil, errors.New("no letters provided")
+	}
+	mls, err := tilemapping.ToMachineLetters(letters, dist.TileMapping())
+	if err != nil {
+		return nil, err
+	}
+	counts := []LetterCount{}
+	idx := map[tilemapping.MachineLetter]int{}
+	for _, ml := range mls {
+		if ml == 0 {
+			return nil, errors.New("blanks are not allowed in a letter search")
+		}
+		i, ok := idx[ml]
+		if !ok {
+			i = len(counts)
+			idx[ml] = i
+			counts = append(counts, LetterCount{Letter: dist.TileMapping().Letter(ml)})
+		}
+		counts[i].Count++
+	}
+	return counts, nil
+}
@@ -0,0 +1,40 @@
+package querygen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/domino14/word-golib/tilemapping"
+	"github.com/stretchr/testify/assert"
+)
+
+// A tiny distribution with a multi-character tile.
+const miniDistribution = `?,2,0,0
+A,12,1,1
+C,4,3,0
+CH,1,5,0
+E,12,1,1
+Q,1,10,0
+S,6,1,0
+U,5,1,1
+`
+
+func TestParseLetterCounts(t *testing.T) {
+	dist, err := tilemapping.ScanLetterDistribution(strings.NewReader(miniDistribution))
+	assert.Nil(t, err)
+
+	counts, err := parseLetterCounts(" qEe", dist)
+	assert.Nil(t, err)
+	assert.Equal(t, []LetterCount{{"Q", 1}, {"E", 2}}, counts)
+
+	counts, err = parseLetterCounts("CHACCH", dist)
+	assert.Nil(t, err)
+	assert.Equal(t, []LetterCount{{"CH", 2}, {"A", 1}, {"C", 1}}, counts)
+
+	_, err = parseLetterCounts("", dist)
+	assert.NotNil(t, err)
+	_, err = parseLetterCounts("QE?", dist)
+	assert.NotNil(t, err)
+	_, err = parseLetterCounts("QZ", dist)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,53 @@
+package querygen
+
+import (
+	wglconfig "github.com/domino14/word-golib/config"
+	"github.com/domino14/word-golib/kwg"
+	"github.com/domino14/word-golib/tilemapping"
+)
+
+// The SQL functions that cross-lexicon conditions use. Connections that run
+// our queries must register the LexiconFuncs methods under these names.
+const (
+	InLexiconFunc          = "wdb_in_lexicon"
+	AlphagramInLexiconFunc = "wdb_alphagram_in_lexicon"
+)
+
+// LexiconFuncs checks words against other lexica, using their KWGs.
+type LexiconFuncs struct {
+	cfg *wglconfig.Config
+}
+
+func NewLexiconFuncs(dataPath string) *LexiconFuncs {
+	return &LexiconFuncs{cfg: &wglconfig.Config{DataPath: dataPath}}
+}
+
+// InLexicon returns whether the word is valid in the lexicon.
+func (lf *LexiconFuncs) InLexicon(lexicon, word string) (bool, error) {
+	dawg, err := kwg.GetKWG(lf.cfg, lexicon)
+	if err != nil {
+		return false, err
+	}
+	mw, err := tilemapping.ToMachineLetters(word, dawg.GetAlphabet())
+	if err != nil {
+		// The word can't even be spelled in this lexicon's alphabet.
+		return false, nil
+	}
+	return kwg.FindMachineWord(dawg, mw), nil
+}
+
+// AlphagramInLexicon returns whether any anagram of the alphagram is valid
+// in the lexicon.
+func (lf *LexiconFuncs) AlphagramInLexicon(lexicon, alphagram string) (bool, error) {
+	dawg, err := kwg.GetKWG(lf.cfg, lexicon)
+	if err != nil {
+		return false, err
+	}
+	mw, err := tilemapping.ToMachineLetters(alphagram, dawg.GetAlphabet())
+	if err != nil {
+		return false, nil
+	}
+	da := kwg.DaPool.Get().(*kwg.KWGAnagrammer)
+	defer kwg.DaPool.Put(da)
+	return da.IsValidJumble(dawg, mw)
+}
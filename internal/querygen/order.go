@@ -0,0 +1,87 @@
+package querygen
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/domino14/word_db_server/api/rpc/wordsearcher"
+)
+
+// SeededRankFunc is the name of the SQL function that random orders sort
+// by. Connections that run our queries must register SeededRank under
+// this name.
+const SeededRankFunc = "wdb_seeded_rank"
+
+// SeededRank hashes a value together with a seed. Sorting by it shuffles
+// the rows, always the same way for the same seed.
+func SeededRank(value string, seed int64) int64 {
+	h := fnv.New64a()
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(seed))
+	h.Write(b[:])
+	h.Write([]byte(value))
+	return int64(h.Sum64())
+}
+
+// probabilityOrder is the default order. Probabilities are only unique
+// within a length, so length breaks the ties; the order has to be total
+// for LIMIT/OFFSET windows to be stable.
+const probabilityOrder = "alphagrams.probability, alphagrams.length"
+
+func defaultOrder(qt QueryType) string {
+	switch qt {
+	case WordsOnly, DeletedWords:
+		return "word"
+	}
+	return probabilityOrder
+}
+
+// orderByClause renders the ORDER BY expression for a sort spec, and the
+// params it binds. Every order ends with a tie-breaker so that it is total.
+// A random order's seed is bound rather than put in the SQL, so that every
+// seed runs the same prepared statement.
+func orderByClause(qt QueryType, spec *wordsearcher.SearchRequest_SortSpec) (string, []interface{}, error) {
+	if spec == nil {
+		return defaultOrder(qt), nil, nil
+	}
+	dir := "ASC"
+	if spec.Descending {
+		dir = "DESC"
+	}
+	if qt == WordsOnly {
+		return "", nil, errors.New("word queries can't be sorted")
+	}
+	if qt == DeletedWords {
+		switch spec.Field {
+		case wordsearcher.SearchRequest_SortSpec_ALPHAGRAM:
+			return "word " + dir, nil, nil
+		case wordsearcher.SearchRequest_SortSpec_RANDOM:
+			return fmt.Sprintf("%s(word, ?) %s, word", SeededRankFunc, dir), []interface{}{spec.Seed}, nil
+		}
+		return "", nil, errors.New("deleted words can only be sorted alphabetically or randomly")
+	}
+
+	var column string
+	switch spec.Field {
+	case wordsearcher.SearchRequest_SortSpec_PROBABILITY:
+		return fmt.Sprintf("alphagrams.probability %s, alphagrams.length %s", dir, dir), nil, nil
+	case wordsearcher.SearchRequest_SortSpec_ALPHAGRAM:
+		return "alphagrams.alphagram " + dir, nil, nil
+	case wordsearcher.SearchRequest_SortSpec_RANDOM:
+		return fmt.Sprintf("%s(alphagrams.alphagram, ?) %s, alphagrams.alphagram",
+			SeededRankFunc, dir), []interface{}{spec.Seed}, nil
+	case wordsearcher.SearchRequest_SortSpec_DIFFICULTY:
+		column = "difficulty"
+	case wordsearcher.SearchRequest_SortSpec_PLAYABILITY:
+		column = "playability"
+	case wordsearcher.SearchRequest_SortSpec_COMBINATIONS:
+		column = "combinations"
+	case wordsearcher.SearchRequest_SortSpec_POINT_VALUE:
+		column = "point_value"
+	default:
+		return "", nil, fmt.Errorf("unsupported sort field: %v", spec.Field)
+	}
+	return fmt.Sprintf("alphagrams.%s %s, %s", column, dir, probabilityOrder), nil, nil
+}
@@ -0,0 +1,105 @@
+package querygen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domino14/word_db_server/api/rpc/wordsearcher"
+	"github.com/domino14/word_db_server/config"
+)
+
+func TestOrderByClause(t *testing.T) {
+	for _, tc := range []struct {
+		spec     *wordsearcher.SearchRequest_SortSpec
+		expected string
+		params   []interface{}
+	}{
+		{nil, "alphagrams.probability, alphagrams.length", nil},
+		{&wordsearcher.SearchRequest_SortSpec{Descending: true},
+			"alphagrams.probability DESC, alphagrams.length DESC", nil},
+		{&wordsearcher.SearchRequest_SortSpec{
+			Field: wordsearcher.SearchRequest_SortSpec_DIFFICULTY, Descending: true},
+			"alphagrams.difficulty DESC, alphagrams.probability, alphagrams.length", nil},
+		{&wordsearcher.SearchRequest_SortSpec{
+			Field: wordsearcher.SearchRequest_SortSpec_POINT_VALUE},
+			"alphagrams.point_value ASC, alphagrams.probability, alphagrams.length", nil},
+		{&wordsearcher.SearchRequest_SortSpec{
+			Field: wordsearcher.SearchRequest_SortSpec_ALPHAGRAM},
+			"alphagrams.alphagram ASC", nil},
+		{&wordsearcher.SearchRequest_SortSpec{
+			Field: wordsearcher.SearchRequest_SortSpec_RANDOM, Seed: -12},
+			"wdb_seeded_rank(alphagrams.alphagram, ?) ASC, alphagrams.alphagram", []interface{}{int64(-12)}},
+	} {
+		clause, params, err := orderByClause(FullExpanded, tc.spec)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.expected, clause)
+		assert.Equal(t, tc.params, params)
+	}
+}
+
+func TestOrderByClauseDeletedWords(t *testing.T) {
+	clause, _, err := orderByClause(DeletedWords, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "word", clause)
+
+	clause, _, err = orderByClause(DeletedWords, &wordsearcher.SearchRequest_SortSpec{
+		Field: wordsearcher.SearchRequest_SortSpec_ALPHAGRAM, Descending: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "word DESC", clause)
+
+	_, _, err = orderByClause(DeletedWords, &wordsearcher.SearchRequest_SortSpec{
+		Field: wordsearcher.SearchRequest_SortSpec_DIFFICULTY})
+	assert.NotNil(t, err)
+}
+
+func TestSeededRank(t *testing.T) {
+	assert.Equal(t, SeededRank("AEINRST", 7), SeededRank("AEINRST", 7))
+	assert.NotEqual(t, SeededRank("AEINRST", 7), SeededRank("AEINRST", 8))
+	assert.NotEqual(t, SeededRank("AEINRST", 7), SeededRank("AEINRSS", 7))
+}
+
+func TestGenerateSortedWithLimit(t *testing.T) {
+	params := []*wordsearcher.SearchRequest_SearchParam{
+		minMaxSP(wordsearcher.SearchRequest_LENGTH, 7, 7),
+		minMaxSP(wordsearcher.SearchRequest_PROBABILITY_LIMIT, 1, 200),
+	}
+	qg := NewQueryGen("NWL23", AlphagramsAndWords, params, 3, &config.Config{})
+	qg.SetSort(&wordsearcher.SearchRequest_SortSpec{
+		Field: wordsearcher.SearchRequest_SortSpec_DIFFICULTY, Descending: true})
+	assert.Nil(t, qg.Validate())
+	queries, err := qg.Generate(context.Background())
+	assert.Nil(t, err)
+	assert.Contains(t, queries[0].Rendered(),
+		"ORDER BY alphagrams.difficulty DESC, alphagrams.probability, alphagrams.length\n\tLIMIT ? OFFSET ?")
+	assert.Equal(t, []interface{}{int32(7), 200, 0}, queries[0].BindParams())
+	// Pages keep the order.
+	assert.Contains(t, queries[0].Page(50, 50).Rendered(), "ORDER BY alphagrams.difficulty DESC")
+}
+
+func TestGenerateRandomOrderBindsSeed(t *testing.T) {
+	params := []*wordsearcher.SearchRequest_SearchParam{
+		minMaxSP(wordsearcher.SearchRequest_LENGTH, 7, 7),
+		minMaxSP(wordsearcher.SearchRequest_PROBABILITY_LIMIT, 1, 200),
+	}
+	qg := NewQueryGen("NWL23", AlphagramsAndWords, params, 3, &config.Config{})
+	qg.SetSort(&wordsearcher.SearchRequest_SortSpec{
+		Field: wordsearcher.SearchRequest_SortSpec_RANDOM, Seed: 99})
+	assert.Nil(t, qg.Validate())
+	queries, err := qg.Generate(context.Background())
+	assert.Nil(t, err)
+	assert.NotContains(t, queries[0].Rendered(), "99")
+	// The alphagrams are numbered in the random order before the WHERE,
+	// and sorted by it after.
+	assert.Equal(t, []interface{}{int64(99), int32(7), int64(99), 200, 0}, queries[0].BindParams())
+	assert.Equal(t, []interface{}{int64(99), int32(7), int64(99), 10, 50},
+		queries[0].Page(50, 10).BindParams())
+
+	qg = NewQueryGen("NWL23", DeletedWords, params[:1], 3, &config.Config{})
+	qg.SetSort(&wordsearcher.SearchRequest_SortSpec{
+		Field: wordsearcher.SearchRequest_SortSpec_RANDOM, Seed: 99})
+	queries, err = qg.Generate(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{int32(7), int64(99)}, queries[0].BindParams())
+}
@@ -0,0 +1,90 @@
+package querygen
+
+import (
+	"fmt"
+)
+
+// The facets a search summary can be broken down by.
+const (
+	FacetLength        = "length"
+	FacetProbability   = "probability"
+	FacetDifficulty    = "difficulty"
+	FacetNumAnagrams   = "num_anagrams"
+	FacetLexiconSymbol = "lexicon_symbol"
+)
+
+// CountQuery counts the alphagrams and words an unexpanded query matches,
+// without returning them. Deleted words have no alphagrams, so they're
+// counted as both.
+func (q *Query) CountQuery() (string, []interface{}, error) {
+	switch q.qtype {
+	case DeletedWords:
+		return "SELECT count(*), count(*) FROM (" + q.rendered + ") m", q.bindParams, nil
+	case AlphagramsAndWords, WordFilteredUnexpanded, WordFilteredUnexpandedWithAlphagrams:
+		return "SELECT count(DISTINCT alphagram), count(*) FROM (" + q.rendered + ") m",
+			q.bindParams, nil
+	}
+	return "", nil, fmt.Errorf("can't count an expanded or alphagram-only query")
+}
+
+// RowCountQuery counts the rows a query returns, which is what running it
+// costs. If limit is positive, counting stops at limit rows, so that the
+// count is cheap however many rows the query would return.
+func (q *Query) RowCountQuery(limit int) (string, []interface{}) {
+	if limit <= 0 {
+		return "SELECT count(*) FROM (" + q.rendered + ") m", q.bindParams
+	}
+	bp := append(append([]interface{}{}, q.bindParams...), limit)
+	return "SELECT count(*) FROM (SELECT 1 FROM (" + q.rendered + ") m LIMIT ?) c", bp
+}
+
+// FacetQuery counts the alphagrams and words an unexpanded query matches,
+// grouped into buckets by the facet. Each row is the smallest value in the
+// bucket, then the counts. Facets with ranges of values (probability and
+// difficulty) are split into buckets of bucketSize values, with a
+// difficulty of 0, meaning unrated, in a bucket of its own. Lexicon symbol
+// buckets are strings; a word with several symbols counts in several
+// buckets, and words without any aren't counted.
+func (q *Query) FacetQuery(facet string, bucketSize int) (string, []interface{}, error) {
+	switch q.qtype {
+	case AlphagramsAndWords, WordFilteredUnexpanded, WordFilteredUnexpandedWithAlphagrams:
+	default:
+		return "", nil, fmt.Errorf("can't break down an expanded, alphagram-only or deleted word query")
+	}
+	if bucketSize < 1 {
+		bucketSize = 1
+	}
+	bp := append([]interface{}{}, q.bindParams...)
+	var bucket string
+	switch facet {
+	case FacetLength:
+		bucket = "a.length"
+	case FacetNumAnagrams:
+		bucket = "a.num_anagrams"
+	case FacetProbability:
+		bucket = "(a.probability - 1) / ? * ? + 1"
+		bp = append([]interface{}{bucketSize, bucketSize}, bp...)
+	case FacetDifficulty:
+		bucket = "CASE WHEN a.difficulty > 0 THEN (a.difficulty - 1) / ? * ? + 1 ELSE 0 END"
+		bp = append([]interface{}{bucketSize, bucketSize}, bp...)
+	case FacetLexiconSymbol:
+		// Split each word's symbols into one row per symbol.
+		return `
+		WITH RECURSIVE symbols(alphagram, symbol, rest) AS (
+			SELECT m.alphagram, substr(w.lexicon_symbols, 1, 1), substr(w.lexicon_symbols, 2)
+			FROM (` + q.rendered + `) m INNER JOIN words w USING (word)
+			WHERE w.lexicon_symbols != ''
+			UNION ALL
+			SELECT alphagram, substr(rest, 1, 1), substr(rest, 2) FROM symbols
+			WHERE rest != ''
+		)
+		SELECT symbol, count(DISTINCT alphagram), count(*) FROM symbols
+		GROUP BY symbol ORDER BY symbol`, bp, nil
+	default:
+		return "", nil, fmt.Errorf("unknown facet %q", facet)
+	}
+	return `
+	SELECT ` + bucket + ` AS bucket, count(DISTINCT m.alphagram), count(*)
+	FROM (` + q.rendered + `) m INNER JOIN alphagrams a USING (alphagram)
+	GROUP BY bucket ORDER BY bucket`, bp, nil
+}
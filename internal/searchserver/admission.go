@@ -0,0 +1,70 @@
+package searchserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	"github.com/domino14/word_db_server/config"
+	"github.com/domino14/word_db_server/internal/auth"
+	"github.com/domino14/word_db_server/internal/querygen"
+)
+
+// searchBudget is the most rows a search by the caller can return in one
+// response. Signed-in users get a bigger budget than anonymous ones. A
+// budget of 0 means there's no limit.
+func searchBudget(ctx context.Context, cfg *config.Config) int {
+	if auth.UserFromContext(ctx) != nil {
+		return cfg.MaxSearchCost
+	}
+	return cfg.MaxAnonymousSearchCost
+}
+
+// admitSearch counts the rows the queries would return before running
+// them, and turns the search away if there are more than the caller's
+// budget. Pages are bounded, so paginated searches are always let in. How
+// long the counting takes is logged, since it comes on top of the search.
+func admitSearch(ctx context.Context, db *LexiconDB, queries []*querygen.Query, cfg *config.Config) error {
+	budget := searchBudget(ctx, cfg)
+	if budget <= 0 {
+		return nil
+	}
+	start := time.Now()
+	cost := 0
+	for _, query := range queries {
+		// Nothing past the rest of the budget needs counting.
+		stmt, bp := query.RowCountQuery(budget - cost + 1)
+		var rows int
+		if err := db.QueryRowContext(ctx, stmt, bp...).Scan(&rows); err != nil {
+			return err
+		}
+		cost += rows
+		if cost > budget {
+			break
+		}
+	}
+	anonymous := auth.UserFromContext(ctx) == nil
+	logged := log.Info().Int("budget", budget).Bool("anonymous", anonymous).
+		Dur("probe-ms", time.Since(start))
+	if cost <= budget {
+		logged.Int("cost", cost).Msg("search-admitted")
+		return nil
+	}
+	logged.Msg("search-over-budget")
+	return overBudgetError(ctx, budget)
+}
+
+// overBudgetError is the error for a search that matches more than the
+// caller's budget.
+func overBudgetError(ctx context.Context, budget int) error {
+	hint := ""
+	if auth.UserFromContext(ctx) == nil {
+		hint = " (signed-in users can get more at once)"
+	}
+	return connect.NewError(connect.CodeResourceExhausted, fmt.Errorf(
+		"this search matches more than %d words, which is too many for one response; narrow it down, "+
+			"or ask for it a page at a time with page_size%s", budget, hint))
+}
@@ -0,0 +1,67 @@
+package searchserver
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+	"github.com/domino14/word_db_server/internal/auth"
+)
+
+func TestSearchBudget(t *testing.T) {
+	cfg := makeTestLexiconDB(t, "TEST")
+	writeTestWords(t, cfg, "TEST", `
+		DROP TABLE alphagrams;
+		CREATE TABLE alphagrams (alphagram varchar(20), length int, probability int,
+			difficulty int, num_anagrams int);
+		INSERT INTO alphagrams VALUES ('AEINST', 6, 2, 12, 2), ('AEINRST', 7, 1, 0, 3);
+		INSERT INTO words (word, alphagram, inner_front_hook, inner_back_hook) VALUES
+			('SATINE', 'AEINST', 0, 0), ('TISANE', 'AEINST', 0, 0),
+			('RETINAS', 'AEINRST', 0, 0), ('RETAINS', 'AEINRST', 0, 0),
+			('STAINER', 'AEINRST', 0, 0);`)
+	cfg.MaxAnonymousSearchCost = 4
+	cfg.MaxSearchCost = 5
+
+	s := &Server{Config: cfg}
+	search := func(ctx context.Context, pageSize int32) (*pb.SearchResponse, error) {
+		resp, err := s.Search(ctx, connect.NewRequest(&pb.SearchRequest{
+			Searchparams: []*pb.SearchRequest_SearchParam{
+				SearchDescLexicon("TEST"), SearchDescLength(6, 7)},
+			PageSize: pageSize,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+
+	// The search matches 5 words, more than an anonymous caller may get.
+	_, err := search(context.Background(), 0)
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+	assert.Contains(t, err.Error(), "more than 4 words")
+	assert.Contains(t, err.Error(), "signed-in users")
+
+	// It can still be had a page at a time.
+	resp, err := search(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Len(t, resp.Alphagrams, 1)
+
+	// Signed-in users have a bigger budget.
+	ctx := auth.StoreUserInContext(context.Background(), 1, "cesar", false)
+	resp, err = search(ctx, 0)
+	assert.Nil(t, err)
+	assert.Len(t, resp.Alphagrams, 2)
+
+	cfg.MaxSearchCost = 3
+	_, err = search(ctx, 0)
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+	assert.NotContains(t, err.Error(), "signed-in users")
+
+	// A budget of 0 is no limit.
+	cfg.MaxSearchCost = 0
+	_, err = search(ctx, 0)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,153 @@
+package searchserver
+
+import (
+	"container/list"
+	"expvar"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Hits, misses and evictions for each result cache, served at /debug/vars.
+var cacheStats = expvar.NewMap("search_cache")
+
+// A resultCache keeps recent results in memory, forgetting the least
+// recently used ones once they add up to more than maxWords words, and any
+// that are older than the TTL. Each entry remembers the lexica it was
+// computed from, so that it can be dropped when one of them is reloaded.
+//
+// Values are cloned on the way in and out, so callers are free to modify
+// what they get.
+type resultCache struct {
+	name     string
+	maxWords int
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	words int
+	items map[string]*list.Element
+	lru   *list.List
+	// generations counts how many times each lexicon has been purged.
+	generations map[string]uint64
+}
+
+type cacheEntry struct {
+	key     string
+	lexica  []string
+	value   proto.Message
+	words   int
+	expires time.Time
+}
+
+// newResultCache makes a cache. A maxWords of 0 turns it off, and a ttl of
+// 0 means entries only leave when they're pushed out.
+func newResultCache(name string, maxWords int, ttl time.Duration) *resultCache {
+	return &resultCache{
+		name:        name,
+		maxWords:    maxWords,
+		ttl:         ttl,
+		now:         time.Now,
+		items:       map[string]*list.Element{},
+		lru:         list.New(),
+		generations: map[string]uint64{},
+	}
+}
+
+// get returns a copy of the value stored under key, or nil.
+func (c *resultCache) get(key string) proto.Message {
+	if c.maxWords <= 0 {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if ok && c.ttl > 0 && c.now().After(el.Value.(*cacheEntry).expires) {
+		c.remove(el)
+		ok = false
+	}
+	if !ok {
+		cacheStats.Add(c.name+"_misses", 1)
+		return nil
+	}
+	cacheStats.Add(c.name+"_hits", 1)
+	c.lru.MoveToFront(el)
+	return proto.Clone(el.Value.(*cacheEntry).value)
+}
+
+// generation changes whenever any of the lexica is purged. Get it before
+// computing a value, and hand it to put.
+func (c *resultCache) generation(lexica []string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sumGenerations(lexica)
+}
+
+func (c *resultCache) sumGenerations(lexica []string) uint64 {
+	var gen uint64
+	for _, lexName := range lexica {
+		gen += c.generations[lexName]
+	}
+	return gen
+}
+
+// put stores a copy of value, which holds the given number of words and
+// came from the given lexica. Values too big to ever fit aren't stored,
+// and neither are ones computed before one of the lexica was purged
+// (reloaded), since they may be out of date.
+func (c *resultCache) put(key string, lexica []string, gen uint64, value proto.Message, words int) {
+	// Even an empty result takes some room.
+	words = max(words, 1)
+	if words > c.maxWords {
+		return
+	}
+	entry := &cacheEntry{
+		key:     key,
+		lexica:  lexica,
+		value:   proto.Clone(value),
+		words:   words,
+		expires: c.now().Add(c.ttl),
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sumGenerations(lexica) != gen {
+		return
+	}
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+	c.items[key] = c.lru.PushFront(entry)
+	c.words += words
+	for c.words > c.maxWords {
+		c.remove(c.lru.Back())
+		cacheStats.Add(c.name+"_evictions", 1)
+	}
+}
+
+// purgeLexicon drops everything computed from the lexicon.
+func (c *resultCache) purgeLexicon(lexName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generations[lexName]++
+	for el := c.lru.Front(); el != nil; {
+		next := el.Next()
+		if slices.Contains(el.Value.(*cacheEntry).lexica, lexName) {
+			c.remove(el)
+		}
+		el = next
+	}
+}
+
+func (c *resultCache) remove(el *list.Element) {
+	entry := c.lru.Remove(el).(*cacheEntry)
+	delete(c.items, entry.key)
+	c.words -= entry.words
+}
+
+func (c *resultCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
@@ -0,0 +1,221 @@
+package searchserver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+)
+
+func TestResultCache(t *testing.T) {
+	c := newResultCache("test", 5, time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	alpha := func(a string) *pb.Alphagram { return &pb.Alphagram{Alphagram: a} }
+	get := func(key string) string {
+		if v := c.get(key); v != nil {
+			return v.(*pb.Alphagram).Alphagram
+		}
+		return ""
+	}
+
+	c.put("a", []string{"CSW24"}, 0, alpha("A"), 2)
+	c.put("b", []string{"NWL2023"}, 0, alpha("B"), 2)
+	assert.Equal(t, "A", get("a"))
+	// b is the least recently used, so it makes way for c.
+	c.put("c", []string{"CSW24"}, 0, alpha("C"), 2)
+	assert.Equal(t, "", get("b"))
+	assert.Equal(t, "A", get("a"))
+	assert.Equal(t, "C", get("c"))
+	// Too big to ever fit.
+	c.put("d", []string{"CSW24"}, 0, alpha("D"), 6)
+	assert.Equal(t, "", get("d"))
+	assert.Equal(t, 2, c.len())
+
+	// What comes out is a copy.
+	v := c.get("a").(*pb.Alphagram)
+	v.Alphagram = "Z"
+	assert.Equal(t, "A", get("a"))
+
+	// Entries expire.
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, "", get("a"))
+	c.put("a", []string{"CSW24"}, 0, alpha("A"), 1)
+	c.put("b", []string{"NWL2023", "CSW24"}, 0, alpha("B"), 1)
+	c.put("c", []string{"NWL2023"}, 0, alpha("C"), 1)
+
+	// Purging a lexicon drops everything that came from it, and anything
+	// that was being worked out from it at the time.
+	gen := c.generation([]string{"CSW24"})
+	c.purgeLexicon("CSW24")
+	assert.Equal(t, 1, c.len())
+	assert.Equal(t, "C", get("c"))
+	c.put("a", []string{"CSW24"}, gen, alpha("A"), 1)
+	assert.Equal(t, "", get("a"))
+	c.put("a", []string{"CSW24"}, c.generation([]string{"CSW24"}), alpha("A"), 1)
+	assert.Equal(t, "A", get("a"))
+
+	// A size of 0 turns the cache off.
+	off := newResultCache("off", 0, 0)
+	off.put("a", nil, 0, alpha("A"), 1)
+	assert.Nil(t, off.get("a"))
+}
+
+func TestSearchCacheKey(t *testing.T) {
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("TEST"), SearchDescAlphagramList([]string{"AEINST", "AEINRST"})}, false)
+	same := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("TEST"), SearchDescAlphagramList([]string{"AEINRST", "AEINST"})}, false)
+	assert.Equal(t, searchCacheKey(req), searchCacheKey(same))
+	// Canonicalizing doesn't change the request itself.
+	assert.Equal(t, []string{"AEINST", "AEINRST"}, req.Searchparams[1].GetStringarray().Values)
+
+	// Lists long enough to be searched in chunks come back in chunk order,
+	// so their order matters unless the search is sorted.
+	long := make([]string, MaxSQLChunkSize+1)
+	for i := range long {
+		long[i] = fmt.Sprintf("A%04d", i)
+	}
+	reversed := slices.Clone(long)
+	slices.Reverse(reversed)
+	longReq := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("TEST"), SearchDescAlphagramList(long)}, false)
+	reversedReq := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("TEST"), SearchDescAlphagramList(reversed)}, false)
+	assert.NotEqual(t, searchCacheKey(longReq), searchCacheKey(reversedReq))
+	longReq.Sort = &pb.SearchRequest_SortSpec{Field: pb.SearchRequest_SortSpec_ALPHAGRAM}
+	reversedReq.Sort = &pb.SearchRequest_SortSpec{Field: pb.SearchRequest_SortSpec_ALPHAGRAM}
+	assert.Equal(t, searchCacheKey(longReq), searchCacheKey(reversedReq))
+
+	expanded := proto.Clone(req).(*pb.SearchRequest)
+	expanded.Expand = true
+	assert.NotEqual(t, searchCacheKey(req), searchCacheKey(expanded))
+
+	sorted := proto.Clone(req).(*pb.SearchRequest)
+	sorted.Sort = &pb.SearchRequest_SortSpec{Field: pb.SearchRequest_SortSpec_DIFFICULTY, Seed: 5}
+	seedless := proto.Clone(sorted).(*pb.SearchRequest)
+	seedless.Sort.Seed = 0
+	assert.Equal(t, searchCacheKey(sorted), searchCacheKey(seedless))
+	sorted.Sort.Field = pb.SearchRequest_SortSpec_RANDOM
+	seedless.Sort.Field = pb.SearchRequest_SortSpec_RANDOM
+	assert.NotEqual(t, searchCacheKey(sorted), searchCacheKey(seedless))
+}
+
+func makeCachedTestLexiconDB(t *testing.T) *Server {
+	cfg := makeTestLexiconDB(t, "TEST")
+	cfg.SearchCacheSize = 100
+	writeTestWords(t, cfg, "TEST", `
+		DROP TABLE alphagrams;
+		CREATE TABLE alphagrams (alphagram varchar(20), length int, probability int,
+			combinations int, difficulty int, playability int, num_anagrams int);
+		INSERT INTO alphagrams VALUES ('AEINST', 6, 2, 400, 12, 30, 2), ('AEINRST', 7, 1, 900, 0, 40, 3);
+		INSERT INTO words (word, alphagram, definition, inner_front_hook, inner_back_hook) VALUES
+			('SATINE', 'AEINST', 'a satin', 0, 0), ('TISANE', 'AEINST', 'a tea', 0, 0),
+			('RETINAS', 'AEINRST', 'eyes', 0, 0), ('RETAINS', 'AEINRST', 'keeps', 0, 0),
+			('STAINER', 'AEINRST', 'dyer', 0, 0);`)
+	return &Server{Config: cfg}
+}
+
+func TestSearchCache(t *testing.T) {
+	s := makeCachedTestLexiconDB(t)
+	r := RegistryFor(s.Config)
+	search := func() []string {
+		resp, err := s.Search(context.Background(), connect.NewRequest(WordSearch(
+			[]*pb.SearchRequest_SearchParam{SearchDescLexicon("TEST"), SearchDescLength(6, 7)}, false)))
+		assert.Nil(t, err)
+		return alphagrams(resp.Msg)
+	}
+	assert.ElementsMatch(t, []string{"AEINRST", "AEINST"}, search())
+	assert.Equal(t, 1, r.searches.len())
+
+	// Change the database under the cache; the cached result is still
+	// served until the lexicon is reloaded.
+	db, err := sql.Open("sqlite3", filepath.Join(s.Config.DataPath, "lexica", "db", "TEST.db"))
+	assert.Nil(t, err)
+	_, err = db.Exec("DELETE FROM alphagrams WHERE alphagram = 'AEINST'")
+	assert.Nil(t, err)
+	assert.Nil(t, db.Close())
+	assert.ElementsMatch(t, []string{"AEINRST", "AEINST"}, search())
+	assert.Nil(t, r.Reload("TEST"))
+	assert.Equal(t, 0, r.searches.len())
+	assert.Equal(t, []string{"AEINRST"}, search())
+}
+
+func TestAlphagramCache(t *testing.T) {
+	s := makeCachedTestLexiconDB(t)
+	r := RegistryFor(s.Config)
+	search := func(alphas ...string) *pb.SearchResponse {
+		resp, err := s.Search(context.Background(), connect.NewRequest(WordSearch(
+			[]*pb.SearchRequest_SearchParam{SearchDescLexicon("TEST"), SearchDescAlphagramList(alphas)},
+			true)))
+		assert.Nil(t, err)
+		return resp.Msg
+	}
+	resp := search("AEINST")
+	assert.Equal(t, []string{"AEINST"}, alphagrams(resp))
+	assert.Len(t, resp.Alphagrams[0].Words, 2)
+	assert.Equal(t, 1, r.alphagrams.len())
+
+	// Lists that overlap share the expansions, and come back in
+	// probability order.
+	resp = search("AEINST", "AEINRST", "QQQ")
+	assert.Equal(t, []string{"AEINRST", "AEINST"}, alphagrams(resp))
+	assert.Equal(t, "dyer", resp.Alphagrams[0].Words[2].Definition)
+	assert.True(t, resp.Alphagrams[0].ExpandedRepr)
+	assert.Equal(t, 2, r.alphagrams.len())
+
+	// Expand uses them too, keeping only the words asked for. SATINE
+	// isn't in AEINRST, so that alphagram is expanded word by word, as are
+	// alphagrams that aren't cached.
+	expand := func() *pb.SearchResponse {
+		resp, err := s.Expand(context.Background(), connect.NewRequest(&pb.SearchResponse{
+			Lexicon: "TEST",
+			Alphagrams: []*pb.Alphagram{
+				{Alphagram: "AEINST", Words: []*pb.Word{{Word: "TISANE"}}},
+				{Alphagram: "AEINRST", Words: []*pb.Word{{Word: "STAINER"}, {Word: "SATINE"}}},
+			},
+		}))
+		assert.Nil(t, err)
+		return resp.Msg
+	}
+	resp = expand()
+	assert.Equal(t, []string{"AEINST", "AEINRST"}, alphagrams(resp))
+	assert.Equal(t, "a tea", resp.Alphagrams[0].Words[0].Definition)
+	assert.Len(t, resp.Alphagrams[0].Words, 1)
+	assert.Equal(t, "SATINE", resp.Alphagrams[1].Words[0].Word)
+	assert.Equal(t, "STAINER", resp.Alphagrams[1].Words[1].Word)
+
+	// Expanding from the cache gives what expanding from the database
+	// does.
+	fromCache := resp.Alphagrams[0]
+	r.alphagrams.purgeLexicon("TEST")
+	uncached, err := s.expandWords(context.Background(), &pb.SearchResponse{
+		Lexicon: "TEST",
+		Alphagrams: []*pb.Alphagram{
+			{Alphagram: "AEINST", Words: []*pb.Word{{Word: "TISANE"}}}},
+	})
+	assert.Nil(t, err)
+	assert.True(t, proto.Equal(uncached[0], fromCache))
+	assert.True(t, proto.Equal(fromCache, expand().Alphagrams[0]))
+	// Expand doesn't fill the cache with alphagrams it only needed a few
+	// words of.
+	assert.Equal(t, 0, r.alphagrams.len())
+
+	// Lists of alphagrams have to fit the budget, cached or not.
+	search("AEINST", "AEINRST")
+	s.Config.MaxAnonymousSearchCost = 4
+	_, err = s.Search(context.Background(), connect.NewRequest(WordSearch(
+		[]*pb.SearchRequest_SearchParam{SearchDescLexicon("TEST"),
+			SearchDescAlphagramList([]string{"AEINST", "AEINRST"})}, true)))
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+	assert.Len(t, search("AEINST").Alphagrams, 1)
+}
@@ -0,0 +1,159 @@
+package searchserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	wglconfig "github.com/domino14/word-golib/config"
+	"github.com/domino14/word-golib/kwg"
+	"github.com/domino14/word-golib/tilemapping"
+	"github.com/rs/zerolog/log"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+	"github.com/domino14/word_db_server/dbmaker"
+)
+
+const (
+	// MaxCheckWords is the most words CheckWords takes at once.
+	MaxCheckWords = 1000
+	// MaxOtherLexica is the most lexica CheckWords checks the words in on
+	// top of the request's own.
+	MaxOtherLexica = 5
+)
+
+// CheckWords rules on whether words are valid in one or more lexica. The
+// rulings come from the lexica's KWGs, the same as the anagrammer's, so
+// they don't depend on the lexicon databases, which are only used to look
+// up the valid words' info.
+func (s *WordSearchServer) CheckWords(ctx context.Context, req *connect.Request[pb.CheckWordsRequest]) (
+	*connect.Response[pb.CheckWordsResponse], error) {
+
+	if req.Msg.Lexicon == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("lexicon is required"))
+	}
+	if len(req.Msg.Words) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("there are no words to check"))
+	}
+	if len(req.Msg.Words) > MaxCheckWords {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("cannot check more than %d words at a time", MaxCheckWords))
+	}
+	words := make([]string, len(req.Msg.Words))
+	for i, w := range req.Msg.Words {
+		words[i] = strings.ToUpper(strings.TrimSpace(w))
+		if words[i] == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument,
+				fmt.Errorf("word %d is empty", i+1))
+		}
+	}
+	lexica, err := s.checkLexica(req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	log.Info().Str("lexicon", req.Msg.Lexicon).Strs("other-lexica", req.Msg.OtherLexica).
+		Strs("words", words).Msg("check-words")
+
+	resp := &pb.CheckWordsResponse{}
+	for _, lex := range lexica {
+		verdict, err := s.checkWords(ctx, lex, words, !req.Msg.ValidityOnly)
+		if err != nil {
+			return nil, err
+		}
+		resp.Verdicts = append(resp.Verdicts, verdict)
+	}
+	resp.Valid = resp.Verdicts[0].Valid
+	return connect.NewResponse(resp), nil
+}
+
+// checkLexica lists the lexica a CheckWords request wants verdicts in. Each
+// has to be in the lexicon manifest, so nothing is looked for in the data
+// path under a name that isn't a lexicon.
+func (s *WordSearchServer) checkLexica(req *pb.CheckWordsRequest) ([]*dbmaker.ManifestLexicon, error) {
+	if len(req.OtherLexica) > MaxOtherLexica {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("cannot check words in more than %d other lexica at a time", MaxOtherLexica))
+	}
+	manifest, err := RegistryFor(s.Config).Manifest()
+	if err != nil {
+		return nil, err
+	}
+	lexica := []*dbmaker.ManifestLexicon{}
+	for _, lexName := range append([]string{req.Lexicon}, req.OtherLexica...) {
+		if slices.ContainsFunc(lexica, func(l *dbmaker.ManifestLexicon) bool { return l.Name == lexName }) {
+			return nil, connect.NewError(connect.CodeInvalidArgument,
+				fmt.Errorf("lexicon %v is asked for more than once", lexName))
+		}
+		lex, _, _, ok := manifest.Lexicon(lexName)
+		if !ok {
+			return nil, connect.NewError(connect.CodeInvalidArgument,
+				fmt.Errorf("the lexicon %v is not supported", lexName))
+		}
+		lexica = append(lexica, lex)
+	}
+	return lexica, nil
+}
+
+// checkWords gives the verdict on the words in one lexicon, going by the
+// word graph the manifest names for it.
+func (s *WordSearchServer) checkWords(ctx context.Context, lex *dbmaker.ManifestLexicon, words []string,
+	withInfo bool) (*pb.LexiconVerdict, error) {
+
+	lexName := lex.Name
+	dawg, err := kwg.GetKWG(&wglconfig.Config{DataPath: s.Config.DataPath}, lex.KWGName())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("cannot check words in %s: %w", lexName, err))
+	}
+	verdict := &pb.LexiconVerdict{Lexicon: lexName, Valid: true}
+	valid := []string{}
+	for _, w := range words {
+		checked := &pb.CheckedWord{Word: w}
+		// A word that can't even be spelled in the lexicon's alphabet isn't
+		// valid in it.
+		mw, err := tilemapping.ToMachineLetters(w, dawg.GetAlphabet())
+		checked.Valid = err == nil && kwg.FindMachineWord(dawg, mw)
+		if checked.Valid {
+			valid = append(valid, w)
+		} else {
+			verdict.Valid = false
+		}
+		verdict.Words = append(verdict.Words, checked)
+	}
+	if !withInfo || len(valid) == 0 {
+		return verdict, nil
+	}
+
+	db, err := acquireDB(ctx, s.Config, lexName)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		// Some lexica only have a KWG. The verdict stands without the info.
+		log.Warn().Err(err).Str("lexicon", lexName).Msg("check-words-no-info")
+		return verdict, nil
+	}
+	defer db.Release()
+	encoded, err := json.Marshal(valid)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := wordInfo(ctx, db, "word IN (SELECT value FROM json_each(?))", string(encoded))
+	if err != nil {
+		return nil, err
+	}
+	byWord := map[string]*pb.Word{}
+	for _, info := range infos {
+		byWord[info.Word] = info
+	}
+	for _, checked := range verdict.Words {
+		if checked.Valid {
+			checked.Info = byWord[checked.Word]
+		}
+	}
+	return verdict, nil
+}
@@ -0,0 +1,128 @@
+package searchserver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/domino14/word-golib/tilemapping"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+	"github.com/domino14/word_db_server/config"
+	"github.com/domino14/word_db_server/internal/kwgtest"
+)
+
+const checkDistribution = `?,2,0,0
+A,9,1,1
+E,12,1,1
+I,9,1,1
+N,6,1,0
+O,8,1,1
+Q,1,10,0
+R,6,1,0
+S,4,1,0
+T,6,1,0
+`
+
+// writeTestKWG writes a KWG for the words to the data path. The lexicon
+// name has to start like a real English lexicon's for its letter
+// distribution to be found.
+func writeTestKWG(t *testing.T, cfg *config.Config, lexName string, words []string) {
+	ldDir := filepath.Join(cfg.DataPath, "letterdistributions")
+	assert.Nil(t, os.MkdirAll(ldDir, 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(ldDir, "english"), []byte(checkDistribution), 0644))
+	ld, err := tilemapping.ScanLetterDistribution(strings.NewReader(checkDistribution))
+	assert.Nil(t, err)
+	bts, err := kwgtest.Encode(words, ld.TileMapping())
+	assert.Nil(t, err)
+	kwgDir := filepath.Join(cfg.DataPath, "lexica", "gaddag")
+	assert.Nil(t, os.MkdirAll(kwgDir, 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(kwgDir, lexName+".kwg"), bts, 0644))
+}
+
+func TestCheckWords(t *testing.T) {
+	// Lexicon KWGs are cached by name for the whole process, so these
+	// names are only used here.
+	cfg := makeTestLexiconDB(t, "CSWCHECK")
+	writeTestWords(t, cfg, "CSWCHECK", `
+		INSERT INTO words (word, alphagram, lexicon_symbols, definition, inner_front_hook, inner_back_hook)
+		VALUES ('QI', 'IQ', '', 'a vital force', 0, 0), ('QIS', 'IQS', '', 'QI, a vital force', 1, 0),
+			('TRANQ', 'ANQRT', '#', 'a tranquilizer', 0, 0);`)
+	writeTestKWG(t, cfg, "CSWCHECK", []string{"QI", "QIS", "TRANQ"})
+	writeTestKWG(t, cfg, "NWLCHECK", []string{"QI", "QIS"})
+	writeTestKWG(t, cfg, "NOKWG", nil)
+	writeTestKWG(t, cfg, "CSWGRAPHCHECK", []string{"QI", "QAT"})
+	assert.Nil(t, os.WriteFile(filepath.Join(cfg.DataPath, "lexica", "manifest.yaml"), []byte(`
+families:
+  - name: CHECK
+    lexica:
+      - {name: NWLCHECK, letter_distribution: english}
+      - {name: CSWCHECK, letter_distribution: english}
+      - {name: KWGCHECK, kwg: CSWGRAPHCHECK, letter_distribution: english}
+`), 0644))
+
+	s := &WordSearchServer{Config: cfg}
+	check := func(req *pb.CheckWordsRequest) *pb.CheckWordsResponse {
+		resp, err := s.CheckWords(context.Background(), connect.NewRequest(req))
+		assert.Nil(t, err)
+		return resp.Msg
+	}
+	rulings := func(v *pb.LexiconVerdict) map[string]bool {
+		m := map[string]bool{}
+		for _, w := range v.Words {
+			m[w.Word] = w.Valid
+		}
+		return m
+	}
+
+	resp := check(&pb.CheckWordsRequest{Lexicon: "CSWCHECK", Words: []string{"qIs", " tranq"}})
+	assert.True(t, resp.Valid)
+	assert.Len(t, resp.Verdicts, 1)
+	v := resp.Verdicts[0]
+	assert.Equal(t, "CSWCHECK", v.Lexicon)
+	assert.Equal(t, []string{"QIS", "TRANQ"}, []string{v.Words[0].Word, v.Words[1].Word})
+	assert.Equal(t, "QI, a vital force", v.Words[0].Info.Definition)
+	assert.Equal(t, "#", v.Words[1].Info.LexiconSymbols)
+
+	// TRANQ is only good in one of the lexica.
+	resp = check(&pb.CheckWordsRequest{Lexicon: "NWLCHECK", OtherLexica: []string{"CSWCHECK"},
+		Words: []string{"QI", "TRANQ"}})
+	assert.False(t, resp.Valid)
+	assert.Equal(t, map[string]bool{"QI": true, "TRANQ": false}, rulings(resp.Verdicts[0]))
+	assert.True(t, resp.Verdicts[1].Valid)
+	// NWLCHECK has no database, but the verdict doesn't need one.
+	assert.Nil(t, resp.Verdicts[0].Words[0].Info)
+	assert.Equal(t, "a vital force", resp.Verdicts[1].Words[0].Info.Definition)
+
+	// There's no U in the letter distribution, so QUA can't even be spelled.
+	resp = check(&pb.CheckWordsRequest{Lexicon: "CSWCHECK", Words: []string{"QI", "QAT", "QUA"},
+		ValidityOnly: true})
+	assert.False(t, resp.Valid)
+	assert.Equal(t, map[string]bool{"QI": true, "QAT": false, "QUA": false}, rulings(resp.Verdicts[0]))
+	assert.Nil(t, resp.Verdicts[0].Words[0].Info)
+
+	// KWGCHECK's graph goes by another name in the manifest, the way
+	// Deutsch's is RD28.
+	resp = check(&pb.CheckWordsRequest{Lexicon: "KWGCHECK", Words: []string{"QAT", "QIS"}, ValidityOnly: true})
+	assert.Equal(t, "KWGCHECK", resp.Verdicts[0].Lexicon)
+	assert.Equal(t, map[string]bool{"QAT": true, "QIS": false}, rulings(resp.Verdicts[0]))
+
+	for _, req := range []*pb.CheckWordsRequest{
+		{Lexicon: "CSWCHECK"},
+		{Words: []string{"QI"}},
+		{Lexicon: "CSWCHECK", Words: []string{"QI", " "}},
+		{Lexicon: "NOPE", Words: []string{"QI"}},
+		// NOKWG has a KWG, but isn't in the manifest.
+		{Lexicon: "CSWCHECK", OtherLexica: []string{"NOKWG"}, Words: []string{"QI"}},
+		{Lexicon: "CSWCHECK", OtherLexica: []string{"NWLCHECK", "CSWCHECK"}, Words: []string{"QI"}},
+		{Lexicon: "CSWCHECK", OtherLexica: []string{"NWLCHECK", "NWLCHECK"}, Words: []string{"QI"}},
+		{Lexicon: "CSWCHECK", OtherLexica: []string{"A", "B", "C", "D", "E", "F"}, Words: []string{"QI"}},
+	} {
+		_, err := s.CheckWords(context.Background(), connect.NewRequest(req))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "%v", req)
+	}
+}
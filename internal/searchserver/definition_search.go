@@ -0,0 +1,74 @@
+package searchserver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+	"github.com/domino14/word_db_server/internal/querygen"
+)
+
+// hasDefinitionSearch checks that the database has the definitions_fts
+// index, and that this build of SQLite can read it (it needs the
+// sqlite_fts5 build tag).
+func hasDefinitionSearch(db *sql.DB, lexName string) bool {
+	var count int
+	err := db.QueryRow("SELECT count(*) FROM sqlite_master WHERE name = 'definitions_fts'").
+		Scan(&count)
+	if err != nil || count == 0 {
+		return false
+	}
+	err = db.QueryRow("SELECT count(*) FROM definitions_fts WHERE rowid = 0").Scan(&count)
+	if err != nil {
+		log.Warn().Err(err).Str("lexicon", lexName).
+			Msg("can't use the definition search index; is the server built with -tags sqlite_fts5?")
+		return false
+	}
+	return true
+}
+
+// definitionSearch does a full-text search of the definitions, returning
+// the most relevant words first along with highlighted snippets.
+func (s *WordSearchServer) definitionSearch(ctx context.Context, db *LexiconDB, req *pb.WordSearchRequest) (
+	*connect.Response[pb.WordSearchResponse], error) {
+
+	search := querygen.FullTextQuery(req.Glob)
+	if search == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("definition search term cannot be empty"))
+	}
+	start, end := req.HighlightStart, req.HighlightEnd
+	if start == "" && end == "" {
+		start, end = "<b>", "</b>"
+	}
+	limit := ""
+	if req.Limit > 0 {
+		limit = fmt.Sprintf("LIMIT %d", req.Limit)
+	}
+	query := fmt.Sprintf(querygen.DefinitionSearchQuery, limit)
+	log.Debug().Str("search", search).Msg("definition-search")
+	rows, err := db.QueryContext(ctx, query, start, end, search)
+	if err != nil {
+		return nil, definitionSearchError(err)
+	}
+	defer rows.Close()
+	words, err := processWordRows(rows)
+	if err != nil {
+		return nil, definitionSearchError(err)
+	}
+	return connect.NewResponse(&pb.WordSearchResponse{Words: words}), nil
+}
+
+// definitionSearchError tells searches FTS5 can't parse apart from other
+// errors.
+func definitionSearchError(err error) error {
+	if strings.Contains(err.Error(), "fts5: syntax error") {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return err
+}
@@ -0,0 +1,71 @@
+package searchserver
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+)
+
+func TestWordSearchDefinitions(t *testing.T) {
+	cfg := makeTestLexiconDB(t, "TEST")
+	writeTestWords(t, cfg, "TEST", `
+		INSERT INTO words (word, alphagram, definition, inner_front_hook, inner_back_hook) VALUES
+			('EEL', 'EEL', 'a snakelike fish [n EELS]', 0, 0),
+			('FISHING', 'FGHIINS', 'FISH, to catch fish [v]', 0, 0),
+			('CAT', 'ACT', 'a small mammal [n CATS]', 0, 0);`)
+	db, err := sql.Open("sqlite3", filepath.Join(cfg.DataPath, "lexica", "db", "TEST.db"))
+	assert.Nil(t, err)
+	_, err = db.Exec(`CREATE VIRTUAL TABLE definitions_fts USING fts5(word UNINDEXED,
+		definition, tokenize = 'porter unicode61');
+		INSERT INTO definitions_fts SELECT word, definition FROM words;`)
+	db.Close()
+	fullText := err == nil
+	if !fullText {
+		assert.Contains(t, err.Error(), "no such module: fts5")
+	}
+
+	s := &WordSearchServer{Config: cfg}
+	search := func(req *pb.WordSearchRequest) []*pb.Word {
+		req.Lexicon, req.AppliesTo = "TEST", "definition"
+		resp, err := s.WordSearch(context.Background(), connect.NewRequest(req))
+		assert.Nil(t, err)
+		return resp.Msg.Words
+	}
+
+	words := search(&pb.WordSearchRequest{Glob: "*fish*"})
+	if !fullText {
+		// Without FTS5, it's the substring search it always was.
+		assert.Equal(t, []string{"EEL", "FISHING"}, wordsWithSymbols(words))
+		t.Skip("needs -tags sqlite_fts5")
+	}
+	// Most relevant first.
+	assert.Equal(t, []string{"FISHING", "EEL"}, wordsWithSymbols(words))
+	assert.Equal(t, "a snakelike <b>fish</b> [n EELS]", words[1].DefinitionSnippet)
+
+	words = search(&pb.WordSearchRequest{Glob: `"small mammal" OR snake*`,
+		HighlightStart: "**", HighlightEnd: "**"})
+	assert.Equal(t, []string{"CAT", "EEL"}, sortedWords(words))
+	for _, w := range words {
+		assert.True(t, strings.Contains(w.DefinitionSnippet, "**"), w.DefinitionSnippet)
+	}
+
+	assert.Len(t, search(&pb.WordSearchRequest{Glob: "fish", Limit: 1}), 1)
+
+	_, err = s.WordSearch(context.Background(), connect.NewRequest(&pb.WordSearchRequest{
+		Lexicon: "TEST", AppliesTo: "definition", Glob: "fish AND"}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func sortedWords(words []*pb.Word) []string {
+	strs := wordsWithSymbols(words)
+	sort.Strings(strs)
+	return strs
+}
@@ -22,40 +22,104 @@ func (s *Server) Expand(ctx context.Context, req *connect.Request[pb.SearchRespo
 	log.Info().Int("num-alphagrams", len(req.Msg.Alphagrams)).Str("lexicon", req.Msg.Lexicon).
 		Msg("expand-request")
 	lexName := req.Msg.Lexicon
-	// Get all the alphagrams from the search request.
-	db, err := getDbConnection(s.Config, lexName)
+	toExpand := req.Msg
+	nextPageToken := ""
+	if req.Msg.PageSize > 0 {
+		var err error
+		toExpand, nextPageToken, err = expandPage(req.Msg)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		if len(toExpand.Alphagrams) == 0 {
+			return connect.NewResponse(&pb.SearchResponse{Lexicon: lexName}), nil
+		}
+	}
+	cached := s.expandFromCache(toExpand)
+	rest := &pb.SearchResponse{Lexicon: lexName}
+	for _, a := range toExpand.Alphagrams {
+		if _, ok := cached[a.Alphagram]; !ok {
+			rest.Alphagrams = append(rest.Alphagrams, a)
+		}
+	}
+	restAlphas := []*pb.Alphagram{}
+	if len(rest.Alphagrams) > 0 {
+		var err error
+		restAlphas, err = s.expandWords(ctx, rest)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Put them back in the order they were asked for.
+	outputAlphas := []*pb.Alphagram{}
+	for _, a := range toExpand.Alphagrams {
+		if c, ok := cached[a.Alphagram]; ok {
+			outputAlphas = append(outputAlphas, c)
+		} else {
+			outputAlphas = append(outputAlphas, restAlphas[0])
+			restAlphas = restAlphas[1:]
+		}
+	}
+
+	return connect.NewResponse(&pb.SearchResponse{
+		Alphagrams:    outputAlphas,
+		Lexicon:       lexName,
+		NextPageToken: nextPageToken,
+	}), nil
+}
+
+// expandWords expands the alphagrams in the request, and just the words
+// listed under each of them.
+func (s *Server) expandWords(ctx context.Context, req *pb.SearchResponse) ([]*pb.Alphagram, error) {
+	db, err := acquireDB(ctx, s.Config, req.Lexicon)
 	if err != nil {
 		return nil, err
 	}
-	defer db.Close()
-	alphStrToObjs, err := getInputAlphagramInfo(req.Msg, s.Config, db)
+	defer db.Release()
+	alphStrToObjs, err := getInputAlphagramInfo(ctx, req, s.Config, db)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
-	outputAlphas, err := mergeInputWordInfo(req.Msg, s.Config, alphStrToObjs, db)
+	outputAlphas, err := mergeInputWordInfo(ctx, req, s.Config, alphStrToObjs, db)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
+	return outputAlphas, nil
+}
 
-	return connect.NewResponse(&pb.SearchResponse{
-		Alphagrams: outputAlphas,
-		Lexicon:    lexName,
-	}), nil
+// expandPage picks out the page of alphagrams to expand from an Expand
+// request, along with the token for the next page.
+func expandPage(req *pb.SearchResponse) (*pb.SearchResponse, string, error) {
+	tok, err := decodePageToken(req.PageToken, expandFingerprint(req), 0)
+	if err != nil {
+		return nil, "", err
+	}
+	start := min(tok.Offset, len(req.Alphagrams))
+	end := min(start+pageSize(req.PageSize), len(req.Alphagrams))
+	page := &pb.SearchResponse{
+		Alphagrams: req.Alphagrams[start:end],
+		Lexicon:    req.Lexicon,
+	}
+	if end == len(req.Alphagrams) {
+		return page, "", nil
+	}
+	return page, tok.at(0, end).encode(), nil
 }
 
-func getInputAlphagramInfo(req *pb.SearchResponse, cfg *config.Config, db *sql.DB) (map[string]*pb.Alphagram, error) {
+func getInputAlphagramInfo(ctx context.Context, req *pb.SearchResponse, cfg *config.Config, db *LexiconDB) (
+	map[string]*pb.Alphagram, error) {
 	inputAlphas := alphasFromSearchResponse(req)
 	alphaQgen := querygen.NewQueryGen(req.Lexicon, querygen.AlphagramsOnly,
 		[]*pb.SearchRequest_SearchParam{SearchDescAlphagramList(inputAlphas)},
 		MaxSQLChunkSize, cfg)
+	alphaQgen.SetDBVersion(db.version)
 
-	queries, err := alphaQgen.Generate()
+	queries, err := alphaQgen.Generate(ctx)
 	if err != nil {
 		return nil, err
 	}
 	log.Debug().Msgf("alphaQgen generated queries %v", queries)
 
-	alphagrams, err := combineAlphaQueryResults(queries, db)
+	alphagrams, err := combineAlphaQueryResults(ctx, queries, db)
 	if err != nil {
 		return nil, err
 	}
@@ -68,8 +132,8 @@ func getInputAlphagramInfo(req *pb.SearchResponse, cfg *config.Config, db *sql.D
 	return alphStrToObjs, nil
 }
 
-func mergeInputWordInfo(req *pb.SearchResponse, cfg *config.Config,
-	alphStrToObjs map[string]*pb.Alphagram, db *sql.DB) ([]*pb.Alphagram, error) {
+func mergeInputWordInfo(ctx context.Context, req *pb.SearchResponse, cfg *config.Config,
+	alphStrToObjs map[string]*pb.Alphagram, db *LexiconDB) ([]*pb.Alphagram, error) {
 	outputAlphas := []*pb.Alphagram{}
 
 	wordToAlphagramDict := map[string]*pb.Alphagram{}
@@ -96,13 +160,14 @@ func mergeInputWordInfo(req *pb.SearchResponse, cfg *config.Config,
 	wordsQGen := querygen.NewQueryGen(req.Lexicon, querygen.WordsOnly,
 		[]*pb.SearchRequest_SearchParam{SearchDescWordList(listOfWords)},
 		MaxSQLChunkSize, cfg)
-	queries, err := wordsQGen.Generate()
+	wordsQGen.SetDBVersion(db.version)
+	queries, err := wordsQGen.Generate(ctx)
 
 	if err != nil {
 		return nil, err
 	}
 	log.Debug().Msgf("Generated word queries %v", queries)
-	words, err := combineWordQueryResults(queries, db)
+	words, err := combineWordQueryResults(ctx, queries, db)
 	if err != nil {
 		return nil, err
 	}
@@ -122,36 +187,48 @@ func alphasFromSearchResponse(req *pb.SearchResponse) []string {
 	return astrs
 }
 
-func combineAlphaQueryResults(queries []*querygen.Query, db *sql.DB) ([]*pb.Alphagram, error) {
+func combineAlphaQueryResults(ctx context.Context, queries []*querygen.Query, db *LexiconDB) (
+	[]*pb.Alphagram, error) {
+
 	alphagrams := []*pb.Alphagram{}
 	// Execute the queries.
 	for _, query := range queries {
-		rows, err := db.Query(query.Rendered(), query.BindParams()...)
+		rows, err := db.QueryContext(ctx, query.Rendered(), query.BindParams()...)
 		if err != nil {
 			return nil, err
 		}
-		alphagrams = append(alphagrams, processAlphagramRows(rows)...)
+		results, err := processAlphagramRows(rows)
 		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+		alphagrams = append(alphagrams, results...)
 	}
 	return alphagrams, nil
 }
 
-func combineWordQueryResults(queries []*querygen.Query, db *sql.DB) ([]*pb.Word, error) {
+func combineWordQueryResults(ctx context.Context, queries []*querygen.Query, db *LexiconDB) (
+	[]*pb.Word, error) {
+
 	words := []*pb.Word{}
 	for _, query := range queries {
-		rows, err := db.Query(query.Rendered(), query.BindParams()...)
+		rows, err := db.QueryContext(ctx, query.Rendered(), query.BindParams()...)
 		if err != nil {
 			return nil, err
 		}
-		words = append(words, processWordRows(rows)...)
+		results, err := processWordRows(rows)
 		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, results...)
 	}
 	return words, nil
 }
 
-func processAlphagramRows(rows *sql.Rows) []*pb.Alphagram {
+func processAlphagramRows(rows *sql.Rows) ([]*pb.Alphagram, error) {
 	alphagrams := []*pb.Alphagram{}
-	rawBuffer := make([]sql.RawBytes, 4)
+	rawBuffer := make([]sql.RawBytes, 5)
 	scanCallArgs := make([]interface{}, len(rawBuffer))
 	for i := range rawBuffer {
 		scanCallArgs[i] = &rawBuffer[i]
@@ -159,7 +236,7 @@ func processAlphagramRows(rows *sql.Rows) []*pb.Alphagram {
 
 	for rows.Next() {
 		var alphagram string
-		var probability, difficulty int32
+		var probability, difficulty, playability int32
 		var combinations int64
 
 		rows.Scan(scanCallArgs...)
@@ -173,6 +250,8 @@ func processAlphagramRows(rows *sql.Rows) []*pb.Alphagram {
 				combinations = toint64(col)
 			case 3:
 				difficulty = toint32(col)
+			case 4:
+				playability = toint32(col)
 			}
 		}
 
@@ -181,16 +260,24 @@ func processAlphagramRows(rows *sql.Rows) []*pb.Alphagram {
 			Probability:  probability,
 			Combinations: combinations,
 			Difficulty:   difficulty,
+			Playability:  playability,
 			Length:       int32(len([]rune(alphagram))),
 		}
 		alphagrams = append(alphagrams, alpha)
 	}
-	return alphagrams
+	return alphagrams, rows.Err()
 }
 
-func processWordRows(rows *sql.Rows) []*pb.Word {
+// processWordRows reads the rows of a words query. If reading them fails
+// partway, as it does when the request is cancelled, it returns the error.
+func processWordRows(rows *sql.Rows) ([]*pb.Word, error) {
 	words := []*pb.Word{}
-	rawBuffer := make([]sql.RawBytes, 8)
+	// Definition searches add a snippet to the usual columns.
+	numColumns := 11
+	if cols, err := rows.Columns(); err == nil && len(cols) > numColumns {
+		numColumns = len(cols)
+	}
+	rawBuffer := make([]sql.RawBytes, numColumns)
 	scanCallArgs := make([]interface{}, len(rawBuffer))
 	for i := range rawBuffer {
 		scanCallArgs[i] = &rawBuffer[i]
@@ -198,6 +285,9 @@ func processWordRows(rows *sql.Rows) []*pb.Word {
 
 	for rows.Next() {
 		var lexSymbols, definition, frontHooks, backHooks, alphagram, word string
+		var firstLexicon, lastLexicon string
+		var definitions []*pb.Definition
+		var snippet string
 		var innerFrontHook, innerBackHook bool
 		rows.Scan(scanCallArgs...)
 		for i, col := range rawBuffer {
@@ -218,20 +308,32 @@ func processWordRows(rows *sql.Rows) []*pb.Word {
 				innerFrontHook = tobool(col)
 			case 7:
 				innerBackHook = tobool(col)
+			case 8:
+				firstLexicon = string(col)
+			case 9:
+				lastLexicon = string(col)
+			case 10:
+				definitions = todefinitions(col)
+			case 11:
+				snippet = string(col)
 			}
 		}
 
 		pbWord := &pb.Word{
-			LexiconSymbols: lexSymbols,
-			Definition:     definition,
-			FrontHooks:     frontHooks,
-			BackHooks:      backHooks,
-			InnerFrontHook: innerFrontHook,
-			InnerBackHook:  innerBackHook,
-			Alphagram:      alphagram,
-			Word:           word,
+			LexiconSymbols:    lexSymbols,
+			Definition:        definition,
+			FrontHooks:        frontHooks,
+			BackHooks:         backHooks,
+			InnerFrontHook:    innerFrontHook,
+			InnerBackHook:     innerBackHook,
+			Alphagram:         alphagram,
+			Word:              word,
+			FirstLexicon:      firstLexicon,
+			LastLexicon:       lastLexicon,
+			Definitions:       definitions,
+			DefinitionSnippet: snippet,
 		}
 		words = append(words, pbWord)
 	}
-	return words
+	return words, rows.Err()
 }
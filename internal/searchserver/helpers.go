@@ -37,6 +37,13 @@ func SearchDescDifficultyRange(min int, max int) *pb.SearchRequest_SearchParam {
 	}
 }
 
+func SearchDescPlayabilityRange(min int, max int) *pb.SearchRequest_SearchParam {
+	return &pb.SearchRequest_SearchParam{
+		Condition:      pb.SearchRequest_PLAYABILITY_RANGE,
+		Conditionparam: minMaxParam(min, max),
+	}
+}
+
 func SearchDescProbLimit(min int, max int) *pb.SearchRequest_SearchParam {
 	return &pb.SearchRequest_SearchParam{
 		Condition:      pb.SearchRequest_PROBABILITY_LIMIT,
@@ -58,6 +65,117 @@ func SearchDescNumAnagrams(min int, max int) *pb.SearchRequest_SearchParam {
 	}
 }
 
+func SearchDescContainsLetters(letters string) *pb.SearchRequest_SearchParam {
+	return &pb.SearchRequest_SearchParam{
+		Condition:      pb.SearchRequest_CONTAINS_LETTERS,
+		Conditionparam: stringParam(letters),
+	}
+}
+
+func SearchDescExcludesLetters(letters string) *pb.SearchRequest_SearchParam {
+	return &pb.SearchRequest_SearchParam{
+		Condition:      pb.SearchRequest_EXCLUDES_LETTERS,
+		Conditionparam: stringParam(letters),
+	}
+}
+
+func SearchDescNumDistinctLetters(min int, max int) *pb.SearchRequest_SearchParam {
+	return &pb.SearchRequest_SearchParam{
+		Condition:      pb.SearchRequest_NUM_DISTINCT_LETTERS,
+		Conditionparam: minMaxParam(min, max),
+	}
+}
+
+func SearchDescMaxLetterRepeats(min int, max int) *pb.SearchRequest_SearchParam {
+	return &pb.SearchRequest_SearchParam{
+		Condition:      pb.SearchRequest_MAX_LETTER_REPEATS,
+		Conditionparam: minMaxParam(min, max),
+	}
+}
+
+func SearchDescPattern(pattern *pb.PatternParam) *pb.SearchRequest_SearchParam {
+	return &pb.SearchRequest_SearchParam{
+		Condition: pb.SearchRequest_WORD_PATTERN,
+		Conditionparam: &pb.SearchRequest_SearchParam_Pattern{
+			Pattern: pattern,
+		},
+	}
+}
+
+// SearchDescHookCount is for any of the min/max hook and extension count
+// conditions, e.g. NUM_FRONT_HOOKS.
+func SearchDescHookCount(c pb.SearchRequest_Condition, min int, max int) *pb.SearchRequest_SearchParam {
+	return &pb.SearchRequest_SearchParam{
+		Condition:      c,
+		Conditionparam: minMaxParam(min, max),
+	}
+}
+
+func SearchDescHookless() *pb.SearchRequest_SearchParam {
+	return &pb.SearchRequest_SearchParam{
+		Condition: pb.SearchRequest_HOOKLESS,
+	}
+}
+
+// SearchDescCrossLexicon matches alphagrams (or, with wordLevel, words)
+// that are valid in every lexicon in inLexica and in none of notInLexica.
+func SearchDescCrossLexicon(inLexica []string, notInLexica []string, wordLevel bool) *pb.SearchRequest_SearchParam {
+	return &pb.SearchRequest_SearchParam{
+		Condition: pb.SearchRequest_CROSS_LEXICON,
+		Conditionparam: &pb.SearchRequest_SearchParam_Crosslexicon{
+			Crosslexicon: &pb.SearchRequest_CrossLexiconParam{
+				InLexica:    inLexica,
+				NotInLexica: notInLexica,
+				WordLevel:   wordLevel,
+			},
+		},
+	}
+}
+
+func SearchDescLexiconSymbols(symbols string) *pb.SearchRequest_SearchParam {
+	return &pb.SearchRequest_SearchParam{
+		Condition:      pb.SearchRequest_LEXICON_SYMBOLS,
+		Conditionparam: stringParam(symbols),
+	}
+}
+
+func SearchDescAddedInLexicon(lexicon string) *pb.SearchRequest_SearchParam {
+	return &pb.SearchRequest_SearchParam{
+		Condition:      pb.SearchRequest_ADDED_IN_LEXICON,
+		Conditionparam: stringParam(lexicon),
+	}
+}
+
+func SearchDescInEveryLexiconSince(lexicon string) *pb.SearchRequest_SearchParam {
+	return &pb.SearchRequest_SearchParam{
+		Condition:      pb.SearchRequest_IN_EVERY_LEXICON_SINCE,
+		Conditionparam: stringParam(lexicon),
+	}
+}
+
+func SearchDescPartOfSpeech(pos string) *pb.SearchRequest_SearchParam {
+	return &pb.SearchRequest_SearchParam{
+		Condition:      pb.SearchRequest_PART_OF_SPEECH,
+		Conditionparam: stringParam(pos),
+	}
+}
+
+func SearchDescInflectionOf(word string) *pb.SearchRequest_SearchParam {
+	return &pb.SearchRequest_SearchParam{
+		Condition:      pb.SearchRequest_INFLECTION_OF,
+		Conditionparam: stringParam(word),
+	}
+}
+
+// SearchDescChangedSince matches alphagrams that changed since the prior
+// lexicon, in any of the given ways (or any way at all).
+func SearchDescChangedSince(prior string, kinds ...string) *pb.SearchRequest_SearchParam {
+	return &pb.SearchRequest_SearchParam{
+		Condition:      pb.SearchRequest_CHANGED_SINCE,
+		Conditionparam: stringArrayParam(append([]string{prior}, kinds...)),
+	}
+}
+
 func SearchDescAlphagramList(alphas []string) *pb.SearchRequest_SearchParam {
 	return &pb.SearchRequest_SearchParam{
 		Condition:      pb.SearchRequest_ALPHAGRAM_LIST,
@@ -92,6 +210,20 @@ func SearchDescDeleted() *pb.SearchRequest_SearchParam {
 	}
 }
 
+// SearchDescGroup combines params with a boolean operator. It can be nested
+// inside other groups.
+func SearchDescGroup(op pb.SearchRequest_GroupOperator, params ...*pb.SearchRequest_SearchParam) *pb.SearchRequest_SearchParam {
+	return &pb.SearchRequest_SearchParam{
+		Condition: pb.SearchRequest_CONDITION_GROUP,
+		Conditionparam: &pb.SearchRequest_SearchParam_Group{
+			Group: &pb.SearchRequest_ConditionGroup{
+				Operator: op,
+				Params:   params,
+			},
+		},
+	}
+}
+
 func stringArrayParam(sa []string) *pb.SearchRequest_SearchParam_Stringarray {
 	return &pb.SearchRequest_SearchParam_Stringarray{
 		Stringarray: &pb.SearchRequest_StringArray{
@@ -142,46 +274,89 @@ func WordSearch(params []*pb.SearchRequest_SearchParam, expand bool) *pb.SearchR
 
 func searchReqDescription(req *pb.SearchRequest) string {
 	var ss strings.Builder
-	for i := range req.Searchparams {
-		switch req.Searchparams[i].Condition {
+	writeParamsDescription(&ss, req.Searchparams)
+	if req.Sort != nil {
+		ss.WriteString("<Sort: " + req.Sort.String() + "> ")
+	}
+	ss.WriteString(fmt.Sprintf("(Expand: %v)", req.Expand))
+	return ss.String()
+}
+
+func writeParamsDescription(ss *strings.Builder, params []*pb.SearchRequest_SearchParam) {
+	for i := range params {
+		switch params[i].Condition {
 		case pb.SearchRequest_LEXICON:
-			ss.WriteString("<Lexicon: " + req.Searchparams[i].GetStringvalue().Value + "> ")
+			ss.WriteString("<Lexicon: " + params[i].GetStringvalue().Value + "> ")
 		case pb.SearchRequest_LENGTH:
-			ss.WriteString("<Length: " + req.Searchparams[i].GetMinmax().String() + "> ")
+			ss.WriteString("<Length: " + params[i].GetMinmax().String() + "> ")
 		case pb.SearchRequest_PROBABILITY_RANGE:
-			ss.WriteString("<Prob Range: " + req.Searchparams[i].GetMinmax().String() + "> ")
+			ss.WriteString("<Prob Range: " + params[i].GetMinmax().String() + "> ")
 		case pb.SearchRequest_DIFFICULTY_RANGE:
-			ss.WriteString("<Difficulty Range: " + req.Searchparams[i].GetMinmax().String() + "> ")
+			ss.WriteString("<Difficulty Range: " + params[i].GetMinmax().String() + "> ")
+		case pb.SearchRequest_PLAYABILITY_RANGE:
+			ss.WriteString("<Playability Range: " + params[i].GetMinmax().String() + "> ")
 		case pb.SearchRequest_PROBABILITY_LIMIT:
-			ss.WriteString("<Prob Limit: " + req.Searchparams[i].GetMinmax().String() + "> ")
+			ss.WriteString("<Prob Limit: " + params[i].GetMinmax().String() + "> ")
 		case pb.SearchRequest_POINT_VALUE:
-			ss.WriteString("<Point Value: " + req.Searchparams[i].GetMinmax().String() + "> ")
+			ss.WriteString("<Point Value: " + params[i].GetMinmax().String() + "> ")
 		case pb.SearchRequest_NUMBER_OF_ANAGRAMS:
-			ss.WriteString("<Num Anagrams: " + req.Searchparams[i].GetMinmax().String() + "> ")
+			ss.WriteString("<Num Anagrams: " + params[i].GetMinmax().String() + "> ")
 		case pb.SearchRequest_ALPHAGRAM_LIST:
-			nalphas := len(req.Searchparams[i].GetStringarray().Values)
-			preview := req.Searchparams[i].GetStringarray().Values[:min(nalphas, 3)]
+			nalphas := len(params[i].GetStringarray().Values)
+			preview := params[i].GetStringarray().Values[:min(nalphas, 3)]
 			desc := fmt.Sprintf("%d alphagrams (preview: %v)", nalphas, preview)
 			ss.WriteString("<Alphagram List: " + desc + "> ")
 		case pb.SearchRequest_WORD_LIST:
-			nwords := len(req.Searchparams[i].GetStringarray().Values)
-			preview := req.Searchparams[i].GetStringarray().Values[:min(nwords, 3)]
+			nwords := len(params[i].GetStringarray().Values)
+			preview := params[i].GetStringarray().Values[:min(nwords, 3)]
 			desc := fmt.Sprintf("%d words (preview: %v)", nwords, preview)
 			ss.WriteString("<Word List: " + desc + "> ")
 		case pb.SearchRequest_PROBABILITY_LIST:
-			nalphas := len(req.Searchparams[i].GetNumberarray().Values)
-			preview := req.Searchparams[i].GetNumberarray().Values[:min(nalphas, 3)]
+			nalphas := len(params[i].GetNumberarray().Values)
+			preview := params[i].GetNumberarray().Values[:min(nalphas, 3)]
 			desc := fmt.Sprintf("%d alphas (preview: %v)", nalphas, preview)
 			ss.WriteString("<Probability List: " + desc + "> ")
 		case pb.SearchRequest_NOT_IN_LEXICON:
-			ss.WriteString("<Not in lexicon: " + req.Searchparams[i].GetNumbervalue().String() + "> ")
+			ss.WriteString("<Not in lexicon: " + params[i].GetNumbervalue().String() + "> ")
 		case pb.SearchRequest_DELETED_WORD:
 			ss.WriteString("<Deleted words> ")
 		case pb.SearchRequest_MATCHING_ANAGRAM:
-			ss.WriteString("<Matching anagram: " + req.Searchparams[i].GetStringvalue().Value + "> ")
-
+			ss.WriteString("<Matching anagram: " + params[i].GetStringvalue().Value + "> ")
+		case pb.SearchRequest_CONTAINS_LETTERS:
+			ss.WriteString("<Contains letters: " + params[i].GetStringvalue().Value + "> ")
+		case pb.SearchRequest_EXCLUDES_LETTERS:
+			ss.WriteString("<Excludes letters: " + params[i].GetStringvalue().Value + "> ")
+		case pb.SearchRequest_NUM_DISTINCT_LETTERS:
+			ss.WriteString("<Num Distinct Letters: " + params[i].GetMinmax().String() + "> ")
+		case pb.SearchRequest_MAX_LETTER_REPEATS:
+			ss.WriteString("<Max Letter Repeats: " + params[i].GetMinmax().String() + "> ")
+		case pb.SearchRequest_WORD_PATTERN:
+			ss.WriteString("<Pattern: " + params[i].GetPattern().String() + "> ")
+		case pb.SearchRequest_NUM_FRONT_HOOKS, pb.SearchRequest_NUM_BACK_HOOKS,
+			pb.SearchRequest_NUM_HOOKS, pb.SearchRequest_NUM_FRONT_EXTENSIONS,
+			pb.SearchRequest_NUM_BACK_EXTENSIONS:
+			ss.WriteString("<" + params[i].Condition.String() + ": " +
+				params[i].GetMinmax().String() + "> ")
+		case pb.SearchRequest_HOOKLESS:
+			ss.WriteString("<Hookless> ")
+		case pb.SearchRequest_CROSS_LEXICON:
+			ss.WriteString("<Cross lexicon: " + params[i].GetCrosslexicon().String() + "> ")
+		case pb.SearchRequest_LEXICON_SYMBOLS:
+			ss.WriteString("<Lexicon symbols: " + params[i].GetStringvalue().Value + "> ")
+		case pb.SearchRequest_ADDED_IN_LEXICON:
+			ss.WriteString("<Added in: " + params[i].GetStringvalue().Value + "> ")
+		case pb.SearchRequest_IN_EVERY_LEXICON_SINCE:
+			ss.WriteString("<In every lexicon since: " + params[i].GetStringvalue().Value + "> ")
+		case pb.SearchRequest_PART_OF_SPEECH:
+			ss.WriteString("<Part of speech: " + params[i].GetStringvalue().Value + "> ")
+		case pb.SearchRequest_INFLECTION_OF:
+			ss.WriteString("<Inflection of: " + params[i].GetStringvalue().Value + "> ")
+		case pb.SearchRequest_CHANGED_SINCE:
+			ss.WriteString("<Changed since: " + strings.Join(params[i].GetStringarray().GetValues(), " ") + "> ")
+		case pb.SearchRequest_CONDITION_GROUP:
+			ss.WriteString("<" + params[i].GetGroup().GetOperator().String() + " group: ")
+			writeParamsDescription(ss, params[i].GetGroup().GetParams())
+			ss.WriteString("> ")
 		}
 	}
-	ss.WriteString(fmt.Sprintf("(Expand: %v)", req.Expand))
-	return ss.String()
 }
@@ -0,0 +1,152 @@
+package searchserver
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"connectrpc.com/connect"
+	wglconfig "github.com/domino14/word-golib/config"
+	"github.com/domino14/word-golib/tilemapping"
+	"github.com/rs/zerolog/log"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+	"github.com/domino14/word_db_server/config"
+)
+
+// ListLexica describes every lexicon database in the data path. A lexicon
+// whose database can't be read is left out, rather than failing the list.
+func (s *Server) ListLexica(ctx context.Context, req *connect.Request[pb.ListLexicaRequest]) (
+	*connect.Response[pb.ListLexicaResponse], error) {
+
+	files, err := filepath.Glob(filepath.Join(s.Config.DataPath, "lexica", "db", "*.db"))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(files))
+	for i, f := range files {
+		names[i] = strings.TrimSuffix(filepath.Base(f), ".db")
+	}
+	sort.Strings(names)
+
+	lexica := []*pb.LexiconDescription{}
+	for _, name := range names {
+		desc, err := describeLexicon(ctx, s.Config, name)
+		if err != nil {
+			log.Err(err).Str("lexicon", name).Msg("describe-lexicon")
+			continue
+		}
+		lexica = append(lexica, desc)
+	}
+	return connect.NewResponse(&pb.ListLexicaResponse{Lexica: lexica}), nil
+}
+
+func describeLexicon(ctx context.Context, cfg *config.Config, name string) (*pb.LexiconDescription, error) {
+	db, err := acquireDB(ctx, cfg, name)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Release()
+	// A loaded database never changes, so it only needs describing once.
+	db.descMu.Lock()
+	defer db.descMu.Unlock()
+	if db.desc == nil {
+		desc, err := db.describe(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		db.desc = desc
+	}
+	return db.desc, nil
+}
+
+func (l *LexiconDB) describe(ctx context.Context, cfg *config.Config) (*pb.LexiconDescription, error) {
+	desc := &pb.LexiconDescription{Name: l.name, DbVersion: int32(l.version)}
+
+	var hasDifficulty, hasPlayability int
+	err := l.QueryRowContext(ctx, `
+		SELECT descriptive_name, family, prior_lexicon, letter_distribution,
+			has_difficulty, has_playability
+		FROM lexicon_info`).Scan(&desc.DescriptiveName, &desc.Family,
+		&desc.PriorLexicon, &desc.LetterDistribution, &hasDifficulty, &hasPlayability)
+	if err != nil {
+		// Databases from before version 11 don't have this table, so go by
+		// the manifest and make the best guesses we can.
+		log.Debug().Err(err).Str("lexicon", l.name).Msg("no-lexicon-info")
+		desc.DescriptiveName = l.name
+		if m, err := RegistryFor(cfg).Manifest(); err == nil {
+			if ml, family, prior, ok := m.Lexicon(l.name); ok {
+				desc.DescriptiveName = ml.DescriptiveName
+				desc.Family = family
+				desc.LetterDistribution = ml.LetterDistribution
+				if prior != nil {
+					desc.PriorLexicon = prior.Name
+				}
+			}
+		}
+		hasDifficulty = l.hasNonzero(ctx, "difficulty")
+		hasPlayability = l.hasNonzero(ctx, "playability")
+	}
+	desc.HasDifficulty = hasDifficulty != 0
+	desc.HasPlayability = hasPlayability != 0
+
+	if desc.LetterDistribution == "" {
+		desc.LetterDistribution, err = tilemapping.ProbableLetterDistributionName(l.name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	dist, err := tilemapping.GetDistribution(&wglconfig.Config{DataPath: cfg.DataPath},
+		desc.LetterDistribution)
+	if err != nil {
+		return nil, err
+	}
+	desc.Tiles = distributionTiles(dist)
+
+	rows, err := l.QueryContext(ctx, `
+		SELECT length, SUM(num_anagrams) FROM alphagrams
+		GROUP BY length ORDER BY length`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		wc := &pb.WordLengthCount{}
+		if err := rows.Scan(&wc.Length, &wc.NumWords); err != nil {
+			return nil, err
+		}
+		desc.WordCounts = append(desc.WordCounts, wc)
+	}
+	return desc, rows.Err()
+}
+
+// hasNonzero returns 1 if any alphagram has a nonzero value in the column.
+func (l *LexiconDB) hasNonzero(ctx context.Context, column string) int {
+	var has int
+	err := l.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM alphagrams WHERE "+column+" > 0)").Scan(&has)
+	if err != nil {
+		return 0
+	}
+	return has
+}
+
+func distributionTiles(dist *tilemapping.LetterDistribution) []*pb.LetterDistributionTile {
+	vowels := map[tilemapping.MachineLetter]bool{}
+	for _, v := range dist.Vowels {
+		vowels[v] = true
+	}
+	tm := dist.TileMapping()
+	tiles := []*pb.LetterDistributionTile{}
+	for i, count := range dist.Distribution() {
+		ml := tilemapping.MachineLetter(i)
+		tiles = append(tiles, &pb.LetterDistributionTile{
+			Letter: tm.Letter(ml),
+			Count:  int32(count),
+			Score:  int32(dist.Score(ml)),
+			Vowel:  vowels[ml],
+		})
+	}
+	return tiles
+}
@@ -0,0 +1,109 @@
+package searchserver
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+)
+
+func TestListLexica(t *testing.T) {
+	cfg := makeTestLexiconDB(t, "TEST")
+	distDir := filepath.Join(cfg.DataPath, "letterdistributions")
+	assert.Nil(t, os.MkdirAll(distDir, 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(distDir, "listlexicatest"),
+		[]byte("?,2,0,0\nA,9,1,1\nB,2,3,0\n"), 0644))
+
+	db, err := sql.Open("sqlite3", filepath.Join(cfg.DataPath, "lexica", "db", "TEST.db"))
+	assert.Nil(t, err)
+	_, err = db.Exec(`
+		DROP TABLE alphagrams;
+		CREATE TABLE alphagrams (alphagram varchar(20), length int, num_anagrams int);
+		INSERT INTO alphagrams VALUES ('AB', 2, 2), ('AAB', 3, 1), ('ABB', 3, 3);
+		CREATE TABLE lexicon_info (lexicon_name varchar(20),
+			descriptive_name varchar(255), family varchar(20),
+			prior_lexicon varchar(20), letter_distribution varchar(50),
+			has_difficulty int, has_playability int);
+		INSERT INTO lexicon_info VALUES ('TEST', 'Test Lexicon', 'TWL', 'TEST0',
+			'listlexicatest', 0, 1);`)
+	assert.Nil(t, err)
+	db.Close()
+	// Not a database, so it is left out of the list.
+	assert.Nil(t, os.WriteFile(filepath.Join(cfg.DataPath, "lexica", "db", "BAD.db"),
+		[]byte("nope"), 0644))
+
+	s := &Server{Config: cfg}
+	defer RegistryFor(cfg).Close()
+	resp, err := s.ListLexica(context.Background(), connect.NewRequest(&pb.ListLexicaRequest{}))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(resp.Msg.Lexica))
+	lex := resp.Msg.Lexica[0]
+	assert.Equal(t, "TEST", lex.Name)
+	assert.Equal(t, "Test Lexicon", lex.DescriptiveName)
+	assert.Equal(t, "TWL", lex.Family)
+	assert.Equal(t, "TEST0", lex.PriorLexicon)
+	assert.Equal(t, int32(10), lex.DbVersion)
+	assert.False(t, lex.HasDifficulty)
+	assert.True(t, lex.HasPlayability)
+	assert.Equal(t, []int32{2, 3}, []int32{lex.WordCounts[0].Length, lex.WordCounts[1].Length})
+	assert.Equal(t, []int32{2, 4}, []int32{lex.WordCounts[0].NumWords, lex.WordCounts[1].NumWords})
+	assert.Equal(t, 3, len(lex.Tiles))
+	assert.Equal(t, "A", lex.Tiles[1].Letter)
+	assert.Equal(t, int32(9), lex.Tiles[1].Count)
+	assert.True(t, lex.Tiles[1].Vowel)
+	assert.Equal(t, int32(3), lex.Tiles[2].Score)
+}
+
+func TestListLexicaFromManifest(t *testing.T) {
+	// A database from before lexicon_info existed is described by the
+	// manifest.
+	cfg := makeTestLexiconDB(t, "CLUB2")
+	distDir := filepath.Join(cfg.DataPath, "letterdistributions")
+	assert.Nil(t, os.MkdirAll(distDir, 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(distDir, "clubtiles"),
+		[]byte("?,2,0,0\nA,9,1,1\nB,2,3,0\n"), 0644))
+	manifestFile := filepath.Join(cfg.DataPath, "lexica", "manifest.yaml")
+	assert.Nil(t, os.WriteFile(manifestFile, []byte(`
+families:
+  - name: CLUB
+    lexica:
+      - {name: CLUB1, letter_distribution: clubtiles}
+      - {name: CLUB2, descriptive_name: Club List 2, letter_distribution: clubtiles}
+`), 0644))
+
+	db, err := sql.Open("sqlite3", filepath.Join(cfg.DataPath, "lexica", "db", "CLUB2.db"))
+	assert.Nil(t, err)
+	_, err = db.Exec(`
+		DROP TABLE alphagrams;
+		CREATE TABLE alphagrams (alphagram varchar(20), length int, num_anagrams int,
+			difficulty int, playability int);
+		INSERT INTO alphagrams VALUES ('AB', 2, 2, 0, 0);`)
+	assert.Nil(t, err)
+	db.Close()
+
+	s := &Server{Config: cfg}
+	defer RegistryFor(cfg).Close()
+	resp, err := s.ListLexica(context.Background(), connect.NewRequest(&pb.ListLexicaRequest{}))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(resp.Msg.Lexica))
+	lex := resp.Msg.Lexica[0]
+	assert.Equal(t, "Club List 2", lex.DescriptiveName)
+	assert.Equal(t, "CLUB", lex.Family)
+	assert.Equal(t, "CLUB1", lex.PriorLexicon)
+	assert.Equal(t, "clubtiles", lex.LetterDistribution)
+	assert.Equal(t, 3, len(lex.Tiles))
+
+	// An invalid manifest doesn't replace the one already loaded.
+	assert.Nil(t, os.WriteFile(manifestFile, []byte("families: []"), 0644))
+	m, err := RegistryFor(cfg).Manifest()
+	assert.Nil(t, err)
+	_, family, _, ok := m.Lexicon("CLUB2")
+	assert.True(t, ok)
+	assert.Equal(t, "CLUB", family)
+}
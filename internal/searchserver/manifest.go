@@ -0,0 +1,49 @@
+package searchserver
+
+import (
+	"sync"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/domino14/word_db_server/dbmaker"
+)
+
+// manifestCache holds the lexicon manifest, and what its file looked like
+// when it was read.
+type manifestCache struct {
+	mu       sync.Mutex
+	manifest *dbmaker.Manifest
+	path     string
+	stamp    fileStamp
+}
+
+// Manifest returns the lexicon manifest for the data path. It is read again
+// whenever the file changes; if the new one is invalid, the old one is kept
+// and the error is logged.
+func (r *DBRegistry) Manifest() (*dbmaker.Manifest, error) {
+	c := &r.manifest
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := dbmaker.ManifestPath(r.cfg.DataPath)
+	var stamp fileStamp
+	if path != "" {
+		var err error
+		if stamp, err = stampFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if c.manifest != nil && path == c.path && stamp == c.stamp {
+		return c.manifest, nil
+	}
+	m, err := dbmaker.LoadManifest(r.cfg.DataPath)
+	if err != nil {
+		if c.manifest != nil {
+			log.Err(err).Str("path", path).Msg("keeping-old-lexicon-manifest")
+			return c.manifest, nil
+		}
+		return nil, err
+	}
+	c.manifest, c.path, c.stamp = m, path, stamp
+	return m, nil
+}
@@ -0,0 +1,147 @@
+package searchserver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+	"github.com/domino14/word_db_server/config"
+	"github.com/domino14/word_db_server/internal/querygen"
+)
+
+// MaxPageSize is the largest page size we honor. Bigger requests are
+// clamped to this.
+const MaxPageSize = 5000
+
+var (
+	errInvalidPageToken = errors.New("invalid page token")
+	errStalePageToken   = errors.New("the lexicon has been updated since this page token " +
+		"was issued; please start the search again")
+)
+
+// pageToken is where the next page of a search starts: an offset into the
+// results of one of the (possibly chunked) generated queries. It is
+// serialized to an opaque string for clients. The fingerprint ties the
+// token to the request it came from, and the generation to the copy of the
+// lexicon database it was read from, since offsets into one copy mean
+// nothing in another.
+type pageToken struct {
+	Query       int    `json:"q"`
+	Offset      int    `json:"o"`
+	Fingerprint uint64 `json:"f"`
+	Generation  uint64 `json:"g,omitempty"`
+}
+
+// at returns a token for the same search, starting somewhere else.
+func (t pageToken) at(query, offset int) *pageToken {
+	t.Query = query
+	t.Offset = offset
+	return &t
+}
+
+func (t *pageToken) encode() string {
+	bts, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(bts)
+}
+
+// decodePageToken decodes a token, checking that it belongs to the request
+// with the given fingerprint, and to the given generation of the database.
+// Tokens that don't depend on the database use generation 0.
+func decodePageToken(s string, fingerprint, generation uint64) (pageToken, error) {
+	var t pageToken
+	if s == "" {
+		return pageToken{Fingerprint: fingerprint, Generation: generation}, nil
+	}
+	bts, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return t, errInvalidPageToken
+	}
+	if err := json.Unmarshal(bts, &t); err != nil {
+		return t, errInvalidPageToken
+	}
+	if t.Fingerprint != fingerprint || t.Query < 0 || t.Offset < 0 {
+		return t, errInvalidPageToken
+	}
+	if t.Generation != generation {
+		return t, errStalePageToken
+	}
+	return t, nil
+}
+
+// pageTokenError turns an error from decodePageToken into one for the
+// client. A stale token is a fine request at the wrong time.
+func pageTokenError(err error) error {
+	if errors.Is(err, errStalePageToken) {
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	return connect.NewError(connect.CodeInvalidArgument, err)
+}
+
+func pageSize(requested int32) int {
+	return int(min(requested, MaxPageSize))
+}
+
+// fingerprint hashes a message deterministically.
+func fingerprint(m proto.Message) uint64 {
+	bts, _ := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	h := fnv.New64a()
+	h.Write(bts)
+	return h.Sum64()
+}
+
+// searchFingerprint identifies the result set of a search request. Fields
+// that don't change which alphagrams come back, or in what order, are
+// left out, so that for example page sizes can change between pages.
+func searchFingerprint(req *pb.SearchRequest) uint64 {
+	r := proto.Clone(req).(*pb.SearchRequest)
+	r.PageSize = 0
+	r.PageToken = ""
+	r.Expand = false
+	return fingerprint(r)
+}
+
+// expandFingerprint identifies the list of alphagrams in an Expand request.
+func expandFingerprint(req *pb.SearchResponse) uint64 {
+	r := &pb.SearchResponse{Lexicon: req.Lexicon}
+	for _, a := range req.Alphagrams {
+		r.Alphagrams = append(r.Alphagrams, &pb.Alphagram{Alphagram: a.Alphagram})
+	}
+	return fingerprint(r)
+}
+
+// fetchPage returns up to `size` alphagrams from the queries, starting at
+// the given token. It returns the token for the page after this one, or
+// nil if there are no more results.
+func fetchPage(ctx context.Context, queries []*querygen.Query, db *LexiconDB, expand bool,
+	qtype querygen.QueryType, cfg *config.Config, tok pageToken, size int) (
+	[]*pb.Alphagram, *pageToken, error) {
+
+	alphagrams := []*pb.Alphagram{}
+	qidx, offset := tok.Query, tok.Offset
+	for qidx < len(queries) && len(alphagrams) < size {
+		need := size - len(alphagrams)
+		// Ask for one more than we need, so we know whether this query
+		// has any results left after this page.
+		results, err := runQuery(ctx, queries[qidx].Page(offset, need+1), db, expand, qtype, cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(results) > need {
+			alphagrams = append(alphagrams, results[:need]...)
+			return alphagrams, tok.at(qidx, offset+need), nil
+		}
+		alphagrams = append(alphagrams, results...)
+		qidx++
+		offset = 0
+	}
+	if qidx < len(queries) {
+		return alphagrams, tok.at(qidx, 0), nil
+	}
+	return alphagrams, nil, nil
+}
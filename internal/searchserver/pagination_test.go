@@ -0,0 +1,88 @@
+package searchserver
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+)
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("NWL23"),
+		SearchDescLength(8, 8),
+	}, false)
+	fp := searchFingerprint(req)
+	tok := &pageToken{Query: 2, Offset: 300, Fingerprint: fp, Generation: 7}
+
+	decoded, err := decodePageToken(tok.encode(), fp, 7)
+	assert.Nil(t, err)
+	assert.Equal(t, *tok, decoded)
+
+	// Page size and expand don't change which results come back.
+	req.PageSize = 50
+	req.Expand = true
+	assert.Equal(t, fp, searchFingerprint(req))
+
+	// But a different search does.
+	other := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("NWL23"),
+		SearchDescLength(7, 7),
+	}, false)
+	_, err = decodePageToken(tok.encode(), searchFingerprint(other), 7)
+	assert.Equal(t, errInvalidPageToken, err)
+
+	// Nor does it work against another copy of the database.
+	_, err = decodePageToken(tok.encode(), fp, 8)
+	assert.Equal(t, errStalePageToken, err)
+
+	_, err = decodePageToken("not a token", fp, 7)
+	assert.Equal(t, errInvalidPageToken, err)
+}
+
+func TestPageTokenAfterReload(t *testing.T) {
+	s := makeCachedTestLexiconDB(t)
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("TEST"), SearchDescLength(6, 7)}, false)
+	req.PageSize = 1
+	resp, err := s.Search(context.Background(), connect.NewRequest(req))
+	assert.Nil(t, err)
+	assert.NotEmpty(t, resp.Msg.NextPageToken)
+
+	db, err := sql.Open("sqlite3", filepath.Join(s.Config.DataPath, "lexica", "db", "TEST.db"))
+	assert.Nil(t, err)
+	_, err = db.Exec("UPDATE db_version SET version = version + 1")
+	assert.Nil(t, err)
+	assert.Nil(t, db.Close())
+	assert.Nil(t, RegistryFor(s.Config).Reload("TEST"))
+
+	req.PageToken = resp.Msg.NextPageToken
+	_, err = s.Search(context.Background(), connect.NewRequest(req))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+}
+
+func TestExpandPage(t *testing.T) {
+	req := &pb.SearchResponse{
+		Lexicon:  "NWL23",
+		PageSize: 2,
+	}
+	for _, a := range []string{"ABC", "DEF", "GHI", "JKL", "MNO"} {
+		req.Alphagrams = append(req.Alphagrams, &pb.Alphagram{Alphagram: a})
+	}
+	seen := []string{}
+	for {
+		page, next, err := expandPage(req)
+		assert.Nil(t, err)
+		seen = append(seen, alphsFromPB(page.Alphagrams)...)
+		if next == "" {
+			break
+		}
+		req.PageToken = next
+	}
+	assert.Equal(t, []string{"ABC", "DEF", "GHI", "JKL", "MNO"}, seen)
+}
@@ -0,0 +1,378 @@
+package searchserver
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+	"github.com/domino14/word_db_server/config"
+)
+
+const (
+	// DefaultMaxLexiconConns is how many queries can run at once against a
+	// single lexicon if the config doesn't say.
+	DefaultMaxLexiconConns = 8
+	// StmtCacheSize is how many prepared statements we keep per lexicon.
+	StmtCacheSize = 256
+)
+
+var errRegistryClosed = errors.New("lexicon databases are shut down")
+
+// A DBRegistry keeps a pool of read-only connections open for every lexicon
+// database it has been asked for, so requests don't pay for opening the file
+// and parsing its schema every time.
+type DBRegistry struct {
+	cfg *config.Config
+
+	mu     sync.Mutex
+	dbs    map[string]*LexiconDB
+	closed bool
+	// kwgStamps is what the KWG files looked like when we last checked.
+	kwgStamps map[string]fileStamp
+
+	manifest manifestCache
+
+	// searches caches whole search responses, and alphagrams caches fully
+	// expanded alphagrams. Both are emptied of a lexicon when it's reloaded.
+	searches   *resultCache
+	alphagrams *resultCache
+}
+
+func NewDBRegistry(cfg *config.Config) *DBRegistry {
+	return &DBRegistry{
+		cfg:        cfg,
+		dbs:        map[string]*LexiconDB{},
+		kwgStamps:  map[string]fileStamp{},
+		searches:   newResultCache("searches", cfg.SearchCacheSize, cfg.SearchCacheTTL),
+		alphagrams: newResultCache("alphagrams", cfg.SearchCacheSize, cfg.SearchCacheTTL),
+	}
+}
+
+var (
+	registriesMu sync.Mutex
+	registries   = map[string]*DBRegistry{}
+)
+
+// RegistryFor returns the shared registry for the config's data path.
+func RegistryFor(cfg *config.Config) *DBRegistry {
+	registriesMu.Lock()
+	defer registriesMu.Unlock()
+	r, ok := registries[cfg.DataPath]
+	if !ok {
+		r = NewDBRegistry(cfg)
+		registries[cfg.DataPath] = r
+	}
+	return r
+}
+
+// CloseRegistries closes every shared registry. It should be called once
+// the server has stopped taking requests.
+func CloseRegistries() error {
+	registriesMu.Lock()
+	defer registriesMu.Unlock()
+	var errs []error
+	for path, r := range registries {
+		errs = append(errs, r.Close())
+		delete(registries, path)
+	}
+	return errors.Join(errs...)
+}
+
+// Acquire returns the database for a lexicon, waiting if the lexicon
+// already has as many queries running as it allows. The caller must call
+// Release on it when done.
+func (r *DBRegistry) Acquire(ctx context.Context, lexName string) (*LexiconDB, error) {
+	for {
+		ldb, err := r.get(lexName)
+		if err != nil {
+			return nil, err
+		}
+		select {
+		case ldb.sem <- struct{}{}:
+			if !ldb.isRetired() {
+				return ldb, nil
+			}
+			// It was swapped out while we waited; try the new one.
+			ldb.Release()
+		case <-ldb.retired:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (r *DBRegistry) get(lexName string) (*LexiconDB, error) {
+	if lexName == "" {
+		return nil, errors.New("lexicon not specified")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil, errRegistryClosed
+	}
+	if ldb, ok := r.dbs[lexName]; ok {
+		return ldb, nil
+	}
+	ldb, err := r.open(lexName)
+	if err != nil {
+		return nil, err
+	}
+	r.dbs[lexName] = ldb
+	return ldb, nil
+}
+
+func (r *DBRegistry) dbFileName(lexName string) string {
+	return filepath.Join(r.cfg.DataPath, "lexica", "db", lexName+".db")
+}
+
+// open opens a lexicon database and reads its version.
+func (r *DBRegistry) open(lexName string) (*LexiconDB, error) {
+	fileName := r.dbFileName(lexName)
+	stamp, err := stampFile(fileName)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("the lexicon %v is not supported", lexName)
+	} else if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open(sqliteDriverName(r.cfg.DataPath), "file:"+fileName+"?mode=ro")
+	if err != nil {
+		return nil, err
+	}
+	maxConns := r.cfg.MaxLexiconConns
+	if maxConns <= 0 {
+		maxConns = DefaultMaxLexiconConns
+	}
+	db.SetMaxOpenConns(maxConns)
+	db.SetMaxIdleConns(maxConns)
+
+	var version int
+	if err := db.QueryRow("SELECT version FROM db_version").Scan(&version); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("reading the version of %v: %w", lexName, err)
+	}
+
+	ldb := &LexiconDB{
+		name:       lexName,
+		fileName:   fileName,
+		stamp:      stamp,
+		version:    version,
+		hasFTS:     hasDefinitionSearch(db, lexName),
+		hasChanges: hasTable(db, "lexicon_changes"),
+		loadedAt:   time.Now(),
+		db:         db,
+		sem:        make(chan struct{}, maxConns),
+		retired:    make(chan struct{}),
+		stmts:      map[string]*list.Element{},
+		lru:        list.New(),
+	}
+	log.Info().Str("lexicon", lexName).Int("version", version).Bool("fts", ldb.hasFTS).
+		Int("max-conns", maxConns).Msg("opened-lexicon-db")
+	return ldb, nil
+}
+
+func hasTable(db *sql.DB, table string) bool {
+	var count int
+	err := db.QueryRow("SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = ?", table).
+		Scan(&count)
+	return err == nil && count > 0
+}
+
+// Close closes all the lexicon databases. Acquire fails after this.
+func (r *DBRegistry) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	var errs []error
+	for lexName, ldb := range r.dbs {
+		errs = append(errs, ldb.close())
+		delete(r.dbs, lexName)
+	}
+	return errors.Join(errs...)
+}
+
+// A cachedStmt is a prepared statement and how many queries are about to
+// run it. A statement that is evicted while in use is closed by the last
+// query to finish with it.
+type cachedStmt struct {
+	query   string
+	stmt    *sql.Stmt
+	users   int
+	evicted bool
+}
+
+// A LexiconDB is a pool of connections to one lexicon database. Queries go
+// through a cache of prepared statements, so the query templates are only
+// compiled once per lexicon.
+type LexiconDB struct {
+	name     string
+	fileName string
+	stamp    fileStamp
+	version  int
+	// hasFTS is whether definitions can be searched with the definitions_fts
+	// index.
+	hasFTS bool
+	// hasChanges is whether dbmaker has stored a diff against a prior
+	// lexicon in the lexicon_changes table.
+	hasChanges bool
+	loadedAt   time.Time
+
+	db  *sql.DB
+	sem chan struct{}
+	// retired is closed once a newer copy of the database replaces this one.
+	retired chan struct{}
+
+	checksumOnce sync.Once
+	checksum     string
+	checksumErr  error
+
+	descMu sync.Mutex
+	desc   *pb.LexiconDescription
+
+	mu    sync.Mutex
+	stmts map[string]*list.Element
+	lru   *list.List
+}
+
+// Release gives back the slot taken by Acquire.
+func (l *LexiconDB) Release() {
+	<-l.sem
+}
+
+// generation identifies this copy of the lexicon database. A reload gives
+// the lexicon a new one.
+func (l *LexiconDB) generation() uint64 {
+	h := fnv.New64a()
+	var b [24]byte
+	binary.LittleEndian.PutUint64(b[0:], uint64(l.stamp.modTime.UnixNano()))
+	binary.LittleEndian.PutUint64(b[8:], uint64(l.stamp.size))
+	binary.LittleEndian.PutUint64(b[16:], uint64(l.version))
+	h.Write(b[:])
+	return h.Sum64()
+}
+
+// stmt returns the prepared statement for a query, preparing it if it
+// isn't cached. The caller must call doneWith on it once the query has
+// started.
+func (l *LexiconDB) stmt(ctx context.Context, query string) (*cachedStmt, error) {
+	l.mu.Lock()
+	if el, ok := l.stmts[query]; ok {
+		l.lru.MoveToFront(el)
+		cs := el.Value.(*cachedStmt)
+		cs.users++
+		l.mu.Unlock()
+		return cs, nil
+	}
+	l.mu.Unlock()
+
+	stmt, err := l.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if el, ok := l.stmts[query]; ok {
+		// Someone else prepared it in the meantime.
+		stmt.Close()
+		cs := el.Value.(*cachedStmt)
+		cs.users++
+		return cs, nil
+	}
+	cs := &cachedStmt{query: query, stmt: stmt, users: 1}
+	l.stmts[query] = l.lru.PushFront(cs)
+	for l.lru.Len() > StmtCacheSize {
+		oldest := l.lru.Remove(l.lru.Back()).(*cachedStmt)
+		delete(l.stmts, oldest.query)
+		oldest.evicted = true
+		if oldest.users == 0 {
+			oldest.stmt.Close()
+		}
+	}
+	return cs, nil
+}
+
+// doneWith gives back a statement returned by stmt. Rows that are still
+// open keep the statement alive on their own (database/sql waits for them
+// before really closing it), so this can be called as soon as the query
+// has started.
+func (l *LexiconDB) doneWith(cs *cachedStmt) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	cs.users--
+	if cs.evicted && cs.users == 0 {
+		cs.stmt.Close()
+	}
+}
+
+func (l *LexiconDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	cs, err := l.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer l.doneWith(cs)
+	return cs.stmt.QueryContext(ctx, args...)
+}
+
+func (l *LexiconDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return l.QueryContext(context.Background(), query, args...)
+}
+
+func (l *LexiconDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	cs, err := l.stmt(ctx, query)
+	if err != nil {
+		// Let the error come out of Scan, the way it does for sql.DB.
+		return l.db.QueryRowContext(ctx, query, args...)
+	}
+	defer l.doneWith(cs)
+	return cs.stmt.QueryRowContext(ctx, args...)
+}
+
+func (l *LexiconDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return l.QueryRowContext(context.Background(), query, args...)
+}
+
+func (l *LexiconDB) isRetired() bool {
+	select {
+	case <-l.retired:
+		return true
+	default:
+		return false
+	}
+}
+
+// retire stops new queries from starting on the database, waits for the
+// ones in flight to finish, and closes it.
+func (l *LexiconDB) retire() {
+	close(l.retired)
+	go func() {
+		for i := 0; i < cap(l.sem); i++ {
+			l.sem <- struct{}{}
+		}
+		if err := l.close(); err != nil {
+			log.Err(err).Str("lexicon", l.name).Msg("closing-retired-lexicon-db")
+		}
+		log.Info().Str("lexicon", l.name).Int("version", l.version).Msg("retired-lexicon-db")
+	}()
+}
+
+func (l *LexiconDB) close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for el := l.lru.Front(); el != nil; el = el.Next() {
+		el.Value.(*cachedStmt).stmt.Close()
+	}
+	l.stmts = map[string]*list.Element{}
+	l.lru.Init()
+	return l.db.Close()
+}
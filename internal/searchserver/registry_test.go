@@ -0,0 +1,146 @@
+package searchserver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domino14/word_db_server/config"
+)
+
+func writeTestLexiconDB(t testing.TB, fileName string, version int) {
+	db, err := sql.Open("sqlite3", fileName)
+	assert.Nil(t, err)
+	defer db.Close()
+	_, err = db.Exec(`CREATE TABLE alphagrams (alphagram varchar(20));
+		INSERT INTO alphagrams VALUES ('AEINST'), ('AEINRST');
+		CREATE TABLE db_version (version integer);`)
+	assert.Nil(t, err)
+	_, err = db.Exec("INSERT INTO db_version VALUES (?)", version)
+	assert.Nil(t, err)
+}
+
+func makeTestLexiconDB(t testing.TB, lexName string) *config.Config {
+	dataPath := t.TempDir()
+	dbDir := filepath.Join(dataPath, "lexica", "db")
+	assert.Nil(t, os.MkdirAll(dbDir, 0755))
+	writeTestLexiconDB(t, filepath.Join(dbDir, lexName+".db"), 10)
+	return &config.Config{DataPath: dataPath, MaxLexiconConns: 1}
+}
+
+func TestRegistryAcquire(t *testing.T) {
+	cfg := makeTestLexiconDB(t, "TEST")
+	r := NewDBRegistry(cfg)
+	defer r.Close()
+
+	_, err := r.Acquire(context.Background(), "NOPE")
+	assert.NotNil(t, err)
+
+	ldb, err := r.Acquire(context.Background(), "TEST")
+	assert.Nil(t, err)
+	var count int
+	for i := 0; i < 3; i++ {
+		err = ldb.QueryRow("SELECT count(*) FROM alphagrams WHERE length(alphagram) = ?", 7).Scan(&count)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, count)
+	}
+	assert.Equal(t, 1, ldb.lru.Len())
+
+	// The database is read-only.
+	_, err = ldb.db.Exec("INSERT INTO alphagrams VALUES ('ABC')")
+	assert.NotNil(t, err)
+
+	// Only one query at a time is allowed, so this has to wait.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = r.Acquire(ctx, "TEST")
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	ldb.Release()
+	again, err := r.Acquire(context.Background(), "TEST")
+	assert.Nil(t, err)
+	assert.Same(t, ldb, again)
+	again.Release()
+
+	assert.Nil(t, r.Close())
+	_, err = r.Acquire(context.Background(), "TEST")
+	assert.Equal(t, errRegistryClosed, err)
+}
+
+func TestRegistryReload(t *testing.T) {
+	cfg := makeTestLexiconDB(t, "TEST")
+	r := NewDBRegistry(cfg)
+	defer r.Close()
+
+	old, err := r.Acquire(context.Background(), "TEST")
+	assert.Nil(t, err)
+	reloaded, err := r.ReloadChanged()
+	assert.Nil(t, err)
+	assert.Empty(t, reloaded)
+
+	// Replace the database the way a deploy would, with a rename.
+	fileName := r.dbFileName("TEST")
+	writeTestLexiconDB(t, fileName+".new", 11)
+	later := time.Now().Add(time.Minute)
+	assert.Nil(t, os.Chtimes(fileName+".new", later, later))
+	assert.Nil(t, os.Rename(fileName+".new", fileName))
+
+	reloaded, err = r.ReloadChanged()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"TEST"}, reloaded)
+	assert.True(t, old.isRetired())
+
+	// The old database still works for the query in flight.
+	var count int
+	assert.Nil(t, old.QueryRow("SELECT count(*) FROM alphagrams").Scan(&count))
+	assert.Equal(t, 2, count)
+
+	// Even with the old one's only slot taken, the new one is available.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	ldb, err := r.Acquire(ctx, "TEST")
+	assert.Nil(t, err)
+	assert.NotSame(t, old, ldb)
+	ldb.Release()
+	old.Release()
+
+	loaded := r.Loaded()
+	assert.Equal(t, 1, len(loaded))
+	assert.Equal(t, "TEST", loaded[0].Name)
+	assert.Equal(t, 11, loaded[0].Version)
+	assert.Equal(t, 64, len(loaded[0].Checksum))
+}
+
+func TestStmtEvictedWhileInUse(t *testing.T) {
+	cfg := makeTestLexiconDB(t, "TEST")
+	r := NewDBRegistry(cfg)
+	defer r.Close()
+
+	ldb, err := r.Acquire(context.Background(), "TEST")
+	assert.Nil(t, err)
+	defer ldb.Release()
+
+	ctx := context.Background()
+	cs, err := ldb.stmt(ctx, "SELECT count(*) FROM alphagrams")
+	assert.Nil(t, err)
+	// Push it out of the cache before it has been run.
+	for i := 0; i < StmtCacheSize; i++ {
+		rows, err := ldb.QueryContext(ctx, fmt.Sprintf("SELECT %d", i))
+		assert.Nil(t, err)
+		rows.Close()
+	}
+	assert.True(t, cs.evicted)
+	assert.Equal(t, StmtCacheSize, ldb.lru.Len())
+
+	var count int
+	assert.Nil(t, cs.stmt.QueryRowContext(ctx).Scan(&count))
+	assert.Equal(t, 2, count)
+	ldb.doneWith(cs)
+	assert.NotNil(t, cs.stmt.QueryRowContext(ctx).Scan(&count))
+}
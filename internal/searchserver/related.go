@@ -0,0 +1,122 @@
+package searchserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+	"github.com/domino14/word_db_server/internal/querygen"
+)
+
+// relatedWordsVersion is the first database version with the
+// definition_links table.
+const relatedWordsVersion = 14
+
+// GetRelatedWords finds the family of a word: the roots it's an inflection
+// of, every inflection of those roots, and their variants.
+func (s *WordSearchServer) GetRelatedWords(ctx context.Context, req *connect.Request[pb.DefineRequest]) (
+	*connect.Response[pb.RelatedWordsResponse], error) {
+
+	db, err := acquireDB(ctx, s.Config, req.Msg.Lexicon)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Release()
+	if db.version < relatedWordsVersion {
+		return nil, connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("the %v database is version %d; related words need version %d",
+				req.Msg.Lexicon, db.version, relatedWordsVersion))
+	}
+
+	word := strings.ToUpper(strings.TrimSpace(req.Msg.Word))
+	words, err := wordInfo(ctx, db, "word = ?", word)
+	if err != nil {
+		return nil, err
+	}
+	if len(words) == 0 {
+		return nil, connect.NewError(connect.CodeNotFound,
+			fmt.Errorf("%v is not in %v", word, req.Msg.Lexicon))
+	}
+
+	rootNames, err := findRoots(ctx, db, word)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(rootNames)
+	if err != nil {
+		return nil, err
+	}
+	roots, err := wordInfo(ctx, db, "word IN (SELECT value FROM json_each(?))", string(encoded))
+	if err != nil {
+		return nil, err
+	}
+	inflections, err := wordInfo(ctx, db, `word IN (
+		SELECT word FROM definitions
+		WHERE root_word IN (SELECT value FROM json_each(?)))`, string(encoded))
+	if err != nil {
+		return nil, err
+	}
+	// Variants go both ways: EMEER is a variant of EMIR, so EMIR is one of
+	// EMEER's, and the other way around.
+	variants, err := wordInfo(ctx, db, `word IN (
+		SELECT linked_word FROM definition_links
+		WHERE variant = 1 AND word IN (SELECT value FROM json_each(?1))
+		UNION
+		SELECT word FROM definition_links
+		WHERE variant = 1 AND linked_word IN (SELECT value FROM json_each(?1)))
+		AND word NOT IN (SELECT value FROM json_each(?1))`, string(encoded))
+	if err != nil {
+		return nil, err
+	}
+	log.Debug().Str("word", word).Strs("roots", rootNames).Int("inflections", len(inflections)).
+		Int("variants", len(variants)).Msg("related-words")
+
+	return connect.NewResponse(&pb.RelatedWordsResponse{
+		Word:        words[0],
+		Roots:       roots,
+		Inflections: inflections,
+		Variants:    variants,
+	}), nil
+}
+
+// findRoots returns the words that the word is an inflection of, or just the
+// word if there aren't any.
+func findRoots(ctx context.Context, db *LexiconDB, word string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT DISTINCT root_word FROM definitions
+		WHERE word = ? AND root_word != '' ORDER BY root_word`, word)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	roots := []string{}
+	for rows.Next() {
+		var root string
+		if err := rows.Scan(&root); err != nil {
+			return nil, err
+		}
+		roots = append(roots, root)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(roots) == 0 {
+		roots = append(roots, word)
+	}
+	return roots, nil
+}
+
+func wordInfo(ctx context.Context, db *LexiconDB, where string, args ...interface{}) ([]*pb.Word, error) {
+	query := fmt.Sprintf(querygen.ForVersion(querygen.WordInfoQuery, db.version), where, "word", "")
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return processWordRows(rows)
+}
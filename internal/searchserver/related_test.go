@@ -0,0 +1,98 @@
+package searchserver
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+	"github.com/domino14/word_db_server/config"
+)
+
+// writeTestWords adds the word and definition tables to a test lexicon
+// database, runs the given inserts, and sets its version to one that has
+// all of them.
+func writeTestWords(t testing.TB, cfg *config.Config, lexName string, inserts string) {
+	db, err := sql.Open("sqlite3", filepath.Join(cfg.DataPath, "lexica", "db", lexName+".db"))
+	assert.Nil(t, err)
+	defer db.Close()
+	_, err = db.Exec(`
+		CREATE TABLE words (word varchar(20), alphagram varchar(20),
+			lexicon_symbols varchar(5) DEFAULT '', definition varchar(512),
+			front_hooks varchar(26) DEFAULT '', back_hooks varchar(26) DEFAULT '',
+			inner_front_hook int, inner_back_hook int,
+			first_lexicon varchar(20) DEFAULT '', last_lexicon varchar(20) DEFAULT '');
+		CREATE TABLE definitions (word varchar(20), sense int,
+			part_of_speech varchar(20), gloss varchar(512),
+			inflections varchar(512), root_word varchar(20));
+		CREATE TABLE definition_links (word varchar(20), sense int,
+			linked_word varchar(20), part_of_speech varchar(20), variant int);
+		UPDATE db_version SET version = 14;` + inserts)
+	assert.Nil(t, err)
+}
+
+func wordsWithSymbols(words []*pb.Word) []string {
+	strs := []string{}
+	for _, w := range words {
+		strs = append(strs, w.Word+w.LexiconSymbols)
+	}
+	return strs
+}
+
+func TestGetRelatedWords(t *testing.T) {
+	cfg := makeTestLexiconDB(t, "TEST")
+	writeTestWords(t, cfg, "TEST", `
+		INSERT INTO words (word, alphagram, lexicon_symbols, inner_front_hook, inner_back_hook) VALUES
+			('EMEER', 'EEEMR', '', 0, 0), ('EMEERS', 'EEEMRS', '', 0, 0),
+			('EMIR', 'EIMR', '', 0, 0), ('EMIRS', 'EIMRS', '#', 0, 0),
+			('AMEER', 'AEEMR', '', 0, 0), ('HIP', 'HIP', '', 0, 0),
+			('HIPNESS', 'EHINPSS', '', 0, 0);
+		INSERT INTO definitions (word, sense, part_of_speech, root_word) VALUES
+			('EMEER', 0, 'n', ''), ('EMEERS', 0, 'n', 'EMEER'),
+			('EMIR', 0, 'n', ''), ('EMIRS', 0, 'n', 'EMIR'),
+			('AMEER', 0, 'n', ''), ('HIP', 0, 'adj', ''), ('HIPNESS', 0, 'n', '');
+		INSERT INTO definition_links VALUES
+			('EMEER', 0, 'EMIR', 'n', 1), ('AMEER', 0, 'EMIR', 'n', 1),
+			('HIPNESS', 0, 'HIP', 'adj', 0), ('EMIR', 0, 'EMEERATE', 'n', 1);`)
+
+	s := &WordSearchServer{Config: cfg}
+	related := func(word string) *pb.RelatedWordsResponse {
+		resp, err := s.GetRelatedWords(context.Background(),
+			connect.NewRequest(&pb.DefineRequest{Lexicon: "TEST", Word: word}))
+		assert.Nil(t, err)
+		return resp.Msg
+	}
+
+	resp := related("emirs")
+	assert.Equal(t, "EMIRS", resp.Word.Word)
+	assert.Equal(t, []string{"EMIR"}, wordsWithSymbols(resp.Roots))
+	assert.Equal(t, []string{"EMIRS#"}, wordsWithSymbols(resp.Inflections))
+	// Words that aren't in the lexicon are left out.
+	assert.Equal(t, []string{"AMEER", "EMEER"}, wordsWithSymbols(resp.Variants))
+
+	resp = related("EMEER")
+	assert.Equal(t, []string{"EMEER"}, wordsWithSymbols(resp.Roots))
+	assert.Equal(t, []string{"EMEERS"}, wordsWithSymbols(resp.Inflections))
+	assert.Equal(t, []string{"EMIR"}, wordsWithSymbols(resp.Variants))
+
+	// A link that isn't a whole sense doesn't make a variant.
+	resp = related("HIPNESS")
+	assert.Empty(t, resp.Inflections)
+	assert.Empty(t, resp.Variants)
+
+	_, err := s.GetRelatedWords(context.Background(),
+		connect.NewRequest(&pb.DefineRequest{Lexicon: "TEST", Word: "EMEERATE"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestGetRelatedWordsOldDatabase(t *testing.T) {
+	cfg := makeTestLexiconDB(t, "TEST")
+	s := &WordSearchServer{Config: cfg}
+	_, err := s.GetRelatedWords(context.Background(),
+		connect.NewRequest(&pb.DefineRequest{Lexicon: "TEST", Word: "EMIR"}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+}
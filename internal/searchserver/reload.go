@@ -0,0 +1,244 @@
+package searchserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/domino14/word-golib/cache"
+	wglconfig "github.com/domino14/word-golib/config"
+	"github.com/domino14/word-golib/kwg"
+	"github.com/rs/zerolog/log"
+)
+
+// fileStamp is what we look at to tell whether a file has been replaced.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func stampFile(path string) (fileStamp, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+func (r *DBRegistry) kwgFileName(lexName string) string {
+	return filepath.Join(r.cfg.DataPath, "lexica", "gaddag", lexName+".kwg")
+}
+
+// LoadedLexicon describes a lexicon database that is being served.
+type LoadedLexicon struct {
+	Name     string    `json:"name"`
+	Version  int       `json:"db_version"`
+	Checksum string    `json:"checksum"`
+	LoadedAt time.Time `json:"loaded_at"`
+}
+
+// Loaded returns the lexicon databases that are open, sorted by name. The
+// checksum is a SHA-256 of the database file and the KWG, computed the
+// first time it is asked for.
+func (r *DBRegistry) Loaded() []LoadedLexicon {
+	r.mu.Lock()
+	ldbs := make([]*LexiconDB, 0, len(r.dbs))
+	for _, ldb := range r.dbs {
+		ldbs = append(ldbs, ldb)
+	}
+	r.mu.Unlock()
+
+	loaded := make([]LoadedLexicon, 0, len(ldbs))
+	for _, ldb := range ldbs {
+		ldb.checksumOnce.Do(func() {
+			ldb.checksum, ldb.checksumErr = checksumFiles(ldb.fileName, r.kwgFileName(ldb.name))
+		})
+		checksum := ldb.checksum
+		if ldb.checksumErr != nil {
+			checksum = "error: " + ldb.checksumErr.Error()
+		}
+		loaded = append(loaded, LoadedLexicon{
+			Name:     ldb.name,
+			Version:  ldb.version,
+			Checksum: checksum,
+			LoadedAt: ldb.loadedAt,
+		})
+	}
+	sort.Slice(loaded, func(i, j int) bool { return loaded[i].Name < loaded[j].Name })
+	return loaded
+}
+
+// checksumFiles hashes the files one after the other. Missing files are
+// skipped, since not every lexicon has a KWG.
+func checksumFiles(paths ...string) (string, error) {
+	h := sha256.New()
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Reload swaps in the lexicon's database and KWG as they are on disk now.
+// Queries already running on the old database finish on it; it is closed
+// once they are done.
+func (r *DBRegistry) Reload(lexName string) error {
+	if err := r.reloadKWG(lexName); err != nil {
+		return err
+	}
+	// Once the new database is in, nothing worked out from the old one (or
+	// the old KWG) should be served.
+	defer r.purgeCaches(lexName)
+
+	r.mu.Lock()
+	closed := r.closed
+	_, ok := r.dbs[lexName]
+	r.mu.Unlock()
+	if closed {
+		return errRegistryClosed
+	}
+	if !ok {
+		// It'll be opened fresh the first time someone asks for it.
+		return nil
+	}
+	// Opening reads the version and looks for tables, so it's done before
+	// taking the lock; searches on other lexica shouldn't wait on it.
+	ldb, err := r.open(lexName)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		ldb.close()
+		return errRegistryClosed
+	}
+	old, ok := r.dbs[lexName]
+	r.dbs[lexName] = ldb
+	if !ok {
+		return nil
+	}
+	old.retire()
+	log.Info().Str("lexicon", lexName).Int("old-version", old.version).
+		Int("new-version", ldb.version).Msg("reloaded-lexicon-db")
+	return nil
+}
+
+func (r *DBRegistry) purgeCaches(lexName string) {
+	r.searches.purgeLexicon(lexName)
+	r.alphagrams.purgeLexicon(lexName)
+}
+
+// reloadKWG replaces the lexicon's KWG in the word-golib cache, if it has
+// one. Anyone still holding the old graph can keep using it.
+func (r *DBRegistry) reloadKWG(lexName string) error {
+	fileName := r.kwgFileName(lexName)
+	stamp, err := stampFile(fileName)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	g, err := kwg.LoadKWG(&wglconfig.Config{DataPath: r.cfg.DataPath}, fileName)
+	if err != nil {
+		return err
+	}
+	err = cache.Populate(kwg.CacheKeyPrefixKWG+lexName, nil, func([]byte) (interface{}, error) {
+		return g, nil
+	})
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.kwgStamps[lexName] = stamp
+	r.mu.Unlock()
+	log.Info().Str("lexicon", lexName).Msg("reloaded-kwg")
+	return nil
+}
+
+// ReloadChanged reloads every lexicon whose database or KWG has changed on
+// disk since it was loaded (or since the last call, for KWGs). It returns
+// the names of the lexica it reloaded.
+func (r *DBRegistry) ReloadChanged() ([]string, error) {
+	changed := map[string]bool{}
+
+	r.mu.Lock()
+	for lexName, ldb := range r.dbs {
+		stamp, err := stampFile(ldb.fileName)
+		if err == nil && stamp != ldb.stamp {
+			changed[lexName] = true
+		}
+	}
+	r.mu.Unlock()
+
+	kwgFiles, err := filepath.Glob(filepath.Join(r.cfg.DataPath, "lexica", "gaddag", "*.kwg"))
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	for _, fileName := range kwgFiles {
+		lexName := strings.TrimSuffix(filepath.Base(fileName), ".kwg")
+		stamp, err := stampFile(fileName)
+		if err != nil {
+			continue
+		}
+		seen, ok := r.kwgStamps[lexName]
+		if !ok {
+			// The first time we see a KWG is the baseline to compare to.
+			r.kwgStamps[lexName] = stamp
+		} else if stamp != seen {
+			changed[lexName] = true
+		}
+	}
+	r.mu.Unlock()
+
+	reloaded := []string{}
+	var errs []error
+	for lexName := range changed {
+		if err := r.Reload(lexName); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		reloaded = append(reloaded, lexName)
+	}
+	sort.Strings(reloaded)
+	return reloaded, errors.Join(errs...)
+}
+
+// Watch checks for changed lexica every interval until the context is done.
+// It also takes the baseline for KWGs right away, so that one replaced
+// before the first check isn't missed.
+func (r *DBRegistry) Watch(ctx context.Context, interval time.Duration) {
+	if _, err := r.ReloadChanged(); err != nil {
+		log.Err(err).Msg("reload-changed-lexica")
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.ReloadChanged(); err != nil {
+				log.Err(err).Msg("reload-changed-lexica")
+			}
+		}
+	}
+}
@@ -19,35 +19,99 @@ import (
 func (s *Server) Search(ctx context.Context, req *connect.Request[pb.SearchRequest]) (
 	*connect.Response[pb.SearchResponse], error) {
 
+	resp, err := s.cachedSearch(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// search runs a search against the database, without looking in the
+// cache. Unless budgeted is false, searches that aren't paginated have to
+// fit the caller's budget (see admitSearch).
+func (s *Server) search(ctx context.Context, req *pb.SearchRequest, budgeted bool) (*pb.SearchResponse, error) {
 	defer timeTrack(time.Now(), "search")
-	log.Info().Str("desc", searchReqDescription(req.Msg)).Msg("searchRequest")
+	log.Info().Str("desc", searchReqDescription(req)).Msg("searchRequest")
 
-	qgen, err := createQueryGen(req.Msg, s.Config, MaxSQLChunkSize)
+	qgen, err := createQueryGen(req, s.Config, MaxSQLChunkSize)
 	if err != nil {
 		return nil, err
 	}
 
-	db, err := getDbConnection(s.Config, qgen.LexiconName())
+	db, err := acquireDB(ctx, s.Config, qgen.LexiconName())
 	if err != nil {
 		return nil, err
 	}
-	defer db.Close()
+	defer db.Release()
 
-	queries, err := qgen.Generate()
+	fitQueryGen(qgen, db)
+	queries, err := generateQueries(ctx, qgen)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		return nil, err
 	}
 	log.Debug().Msgf("Generated queries %v", queries)
 
-	alphagrams, err := combineQueryResults(queries, db, req.Msg.Expand, qgen.Type(), s.Config)
+	if req.PageSize > 0 {
+		return s.searchPage(ctx, req, queries, db, qgen)
+	}
+	if budgeted {
+		if err := admitSearch(ctx, db, queries, s.Config); err != nil {
+			return nil, err
+		}
+	}
+
+	alphagrams, err := combineQueryResults(ctx, queries, db, req.Expand, qgen.Type(), s.Config)
 	if err != nil {
 		return nil, err
 	}
 
-	return connect.NewResponse(&pb.SearchResponse{
+	return &pb.SearchResponse{
 		Alphagrams: alphagrams,
 		Lexicon:    qgen.LexiconName(),
-	}), nil
+	}, nil
+}
+
+func (s *Server) searchPage(ctx context.Context, req *pb.SearchRequest, queries []*querygen.Query,
+	db *LexiconDB, qgen *querygen.QueryGen) (*pb.SearchResponse, error) {
+
+	tok, err := decodePageToken(req.PageToken, searchFingerprint(req), db.generation())
+	if err != nil {
+		return nil, pageTokenError(err)
+	}
+	alphagrams, next, err := fetchPage(ctx, queries, db, req.Expand, qgen.Type(), s.Config,
+		tok, pageSize(req.PageSize))
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.SearchResponse{
+		Alphagrams: alphagrams,
+		Lexicon:    qgen.LexiconName(),
+	}
+	if next != nil {
+		resp.NextPageToken = next.encode()
+	}
+	return resp, nil
+}
+
+// fitQueryGen tells a query generator what the lexicon database has.
+func fitQueryGen(qgen *querygen.QueryGen, db *LexiconDB) {
+	qgen.SetFullTextDefinitions(db.hasFTS)
+	qgen.SetLexiconChanges(db.hasChanges)
+	qgen.SetDBVersion(db.version)
+}
+
+// generateQueries makes the queries for a search. Mistakes in the search
+// are InvalidArgument errors, but running out of time to look for anagram
+// or pattern matches isn't one.
+func generateQueries(ctx context.Context, qgen *querygen.QueryGen) ([]*querygen.Query, error) {
+	queries, err := qgen.Generate(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return queries, nil
 }
 
 func createQueryGen(req *pb.SearchRequest, cfg *config.Config, maxChunkSize int) (*querygen.QueryGen, error) {
@@ -65,14 +129,32 @@ func createQueryGen(req *pb.SearchRequest, cfg *config.Config, maxChunkSize int)
 	needsAlphagramAccess := false
 	
 	// Check if any search params require word-level filtering or alphagram access
-	for _, p := range req.Searchparams {
+	walkSearchParams(req.Searchparams, func(p *pb.SearchRequest_SearchParam) bool {
 		if p.Condition == pb.SearchRequest_DELETED_WORD {
 			queryType = querygen.DeletedWords
-			break
+			return false
 		}
-		if p.Condition == pb.SearchRequest_CONTAINS_HOOKS || 
-		   p.Condition == pb.SearchRequest_DEFINITION_CONTAINS {
+		switch p.Condition {
+		case pb.SearchRequest_CONTAINS_HOOKS,
+			pb.SearchRequest_DEFINITION_CONTAINS,
+			pb.SearchRequest_NUM_FRONT_HOOKS,
+			pb.SearchRequest_NUM_BACK_HOOKS,
+			pb.SearchRequest_NUM_HOOKS,
+			pb.SearchRequest_HOOKLESS,
+			pb.SearchRequest_NUM_FRONT_EXTENSIONS,
+			pb.SearchRequest_NUM_BACK_EXTENSIONS,
+			pb.SearchRequest_LEXICON_SYMBOLS,
+			pb.SearchRequest_ADDED_IN_LEXICON,
+			pb.SearchRequest_IN_EVERY_LEXICON_SINCE,
+			pb.SearchRequest_PART_OF_SPEECH,
+			pb.SearchRequest_INFLECTION_OF:
 			needsWordFiltering = true
+		case pb.SearchRequest_CROSS_LEXICON:
+			if p.GetCrosslexicon().GetWordLevel() {
+				needsWordFiltering = true
+			} else {
+				needsAlphagramAccess = true
+			}
 		}
 		// Check if condition needs alphagram table columns
 		switch p.Condition {
@@ -80,15 +162,25 @@ func createQueryGen(req *pb.SearchRequest, cfg *config.Config, maxChunkSize int)
 			pb.SearchRequest_NUMBER_OF_ANAGRAMS,
 			pb.SearchRequest_PROBABILITY_RANGE,
 			pb.SearchRequest_DIFFICULTY_RANGE,
+			pb.SearchRequest_PLAYABILITY_RANGE,
 			pb.SearchRequest_NUMBER_OF_VOWELS,
 			pb.SearchRequest_POINT_VALUE,
 			pb.SearchRequest_NOT_IN_LEXICON,
 			pb.SearchRequest_PROBABILITY_LIST,
-			pb.SearchRequest_ALPHAGRAM_LIST:
+			pb.SearchRequest_ALPHAGRAM_LIST,
+			pb.SearchRequest_MATCHING_ANAGRAM,
+			pb.SearchRequest_CONTAINS_LETTERS,
+			pb.SearchRequest_EXCLUDES_LETTERS,
+			pb.SearchRequest_NUM_DISTINCT_LETTERS,
+			pb.SearchRequest_MAX_LETTER_REPEATS,
+			pb.SearchRequest_WORD_PATTERN,
+			pb.SearchRequest_CHANGED_SINCE,
+			pb.SearchRequest_UPLOADED_WORD_OR_ALPHAGRAM_LIST:
 			needsAlphagramAccess = true
 		}
-	}
-	
+		return true
+	})
+
 	// Set query type based on filtering needs and expand parameter
 	if queryType != querygen.DeletedWords {
 		if needsWordFiltering {
@@ -117,6 +209,9 @@ func createQueryGen(req *pb.SearchRequest, cfg *config.Config, maxChunkSize int)
 	}
 
 	qgen := querygen.NewQueryGen(lexName, queryType, req.Searchparams[1:], maxChunkSize, cfg)
+	qgen.SetSort(req.Sort)
+	// Pages have to come from one ordering of all the results.
+	qgen.SetSingleQuery(req.PageSize > 0 || req.PageToken != "")
 	log.Debug().Msgf("Creating new querygen with lexicon name %v, search params %v, expand %v",
 		lexName, req.Searchparams[1:], req.Expand)
 
@@ -127,31 +222,69 @@ func createQueryGen(req *pb.SearchRequest, cfg *config.Config, maxChunkSize int)
 	return qgen, nil
 }
 
-func combineQueryResults(queries []*querygen.Query, db *sql.DB, expand bool, qtype querygen.QueryType, cfg *config.Config) (
-	[]*pb.Alphagram, error) {
+// walkSearchParams calls fn for every search param, descending into
+// condition groups. Group params themselves are also passed to fn. The
+// walk stops as soon as fn returns false, in which case walkSearchParams
+// returns false too.
+func walkSearchParams(params []*pb.SearchRequest_SearchParam, fn func(*pb.SearchRequest_SearchParam) bool) bool {
+	for _, p := range params {
+		if !fn(p) {
+			return false
+		}
+		if p.Condition == pb.SearchRequest_CONDITION_GROUP {
+			if !walkSearchParams(p.GetGroup().GetParams(), fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func combineQueryResults(ctx context.Context, queries []*querygen.Query, db *LexiconDB, expand bool,
+	qtype querygen.QueryType, cfg *config.Config) ([]*pb.Alphagram, error) {
 
 	alphagrams := []*pb.Alphagram{}
 	// Execute the queries.
 	for _, query := range queries {
-		rows, err := db.Query(query.Rendered(), query.BindParams()...)
-		if err != nil {
-			return nil, err
-		}
-		results, err := processQuestionRows(rows, expand, qtype, cfg)
+		results, err := runQuery(ctx, query, db, expand, qtype, cfg)
 		if err != nil {
-			rows.Close()
 			return nil, err
 		}
 		alphagrams = append(alphagrams, results...)
-
-		rows.Close()
 	}
 
 	return alphagrams, nil
 }
 
+func runQuery(ctx context.Context, query *querygen.Query, db *LexiconDB, expand bool,
+	qtype querygen.QueryType, cfg *config.Config) ([]*pb.Alphagram, error) {
+
+	rows, err := db.QueryContext(ctx, query.Rendered(), query.BindParams()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return processQuestionRows(rows, expand, qtype, cfg)
+}
+
 func processQuestionRows(rows *sql.Rows, expanded bool, qtype querygen.QueryType, cfg *config.Config) ([]*pb.Alphagram, error) {
 	alphagrams := []*pb.Alphagram{}
+	err := walkQuestionRows(rows, expanded, qtype, cfg.MaxQueryResults, func(a *pb.Alphagram) error {
+		alphagrams = append(alphagrams, a)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return alphagrams, nil
+}
+
+// walkQuestionRows groups the word rows returned by a question query into
+// alphagrams, calling emit for each alphagram once all its words have been
+// read. If emit returns an error, the walk stops and returns it. If maxRows
+// is positive, the walk fails after that many rows.
+func walkQuestionRows(rows *sql.Rows, expanded bool, qtype querygen.QueryType, maxRows int,
+	emit func(*pb.Alphagram) error) error {
 	start := time.Now()
 
 	var lastAlphagram *pb.Alphagram
@@ -159,7 +292,7 @@ func processQuestionRows(rows *sql.Rows, expanded bool, qtype querygen.QueryType
 	var rawBuffer []sql.RawBytes
 	var numColumns int
 	if expanded {
-		numColumns = 11
+		numColumns = 15
 	} else {
 		numColumns = 2
 	}
@@ -181,13 +314,15 @@ func processQuestionRows(rows *sql.Rows, expanded bool, qtype querygen.QueryType
 	for rows.Next() {
 		rowCtr++
 		// Check if we've exceeded the maximum number of results
-		if rowCtr > cfg.MaxQueryResults {
-			return nil, fmt.Errorf("query exceeded maximum results limit of %d. Please refine your search criteria", cfg.MaxQueryResults)
+		if maxRows > 0 && rowCtr > maxRows {
+			return fmt.Errorf("query exceeded maximum results limit of %d. Please refine your search criteria", maxRows)
 		}
-		
+
 		var word, alphagram string
 		var lexSymbols, definition, frontHooks, backHooks string
-		var probability, difficulty int32
+		var firstLexicon, lastLexicon string
+		var definitions []*pb.Definition
+		var probability, difficulty, playability int32
 		var combinations int64
 		var innerFrontHook, innerBackHook bool
 		err := rows.Scan(scanCallArgs...)
@@ -219,6 +354,14 @@ func processQuestionRows(rows *sql.Rows, expanded bool, qtype querygen.QueryType
 				combinations = toint64(col)
 			case 10:
 				difficulty = toint32(col)
+			case 11:
+				playability = toint32(col)
+			case 12:
+				firstLexicon = string(col)
+			case 13:
+				lastLexicon = string(col)
+			case 14:
+				definitions = todefinitions(col)
 			}
 		}
 		if qtype == querygen.DeletedWords {
@@ -232,10 +375,13 @@ func processQuestionRows(rows *sql.Rows, expanded bool, qtype querygen.QueryType
 			Length:       int32(len([]rune(alphagram))),
 			ExpandedRepr: expanded,
 			Difficulty:   difficulty,
+			Playability:  playability,
 		}
 		if lastAlphagram != nil && alpha.Alphagram != lastAlphagram.Alphagram {
 			lastAlphagram.Words = curWords
-			alphagrams = append(alphagrams, lastAlphagram)
+			if err := emit(lastAlphagram); err != nil {
+				return err
+			}
 			curWords = []*pb.Word{}
 		}
 		if !expanded {
@@ -253,14 +399,22 @@ func processQuestionRows(rows *sql.Rows, expanded bool, qtype querygen.QueryType
 			LexiconSymbols: lexSymbols,
 			InnerFrontHook: innerFrontHook,
 			InnerBackHook:  innerBackHook,
+			FirstLexicon:   firstLexicon,
+			LastLexicon:    lastLexicon,
+			Definitions:    definitions,
 		})
 
 		lastAlphagram = alpha
 	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
 	if lastAlphagram != nil {
 		lastAlphagram.Words = curWords
-		alphagrams = append(alphagrams, lastAlphagram)
+		if err := emit(lastAlphagram); err != nil {
+			return err
+		}
 	}
 	log.Debug().Msgf("Scanned %v rows", rowCtr)
-	return alphagrams, nil
+	return nil
 }
@@ -0,0 +1,235 @@
+package searchserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"slices"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+)
+
+// cachedSearch answers a search from the cache if it can. Expanded
+// searches for a list of alphagrams, which is how cards are looked up, are
+// put together from the alphagram cache instead, so that lists that
+// overlap share their work.
+func (s *Server) cachedSearch(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
+	if alphas, ok := alphagramListSearch(req); ok {
+		return s.searchAlphagramList(ctx, req.Searchparams[0].GetStringvalue().GetValue(), alphas)
+	}
+
+	cache := RegistryFor(s.Config).searches
+	key := searchCacheKey(req)
+	if cached := cache.get(key); cached != nil {
+		resp := cached.(*pb.SearchResponse)
+		// The search still has to fit the caller's budget.
+		budget := searchBudget(ctx, s.Config)
+		if req.PageSize > 0 || budget <= 0 || countWords(resp) <= budget {
+			log.Debug().Str("desc", searchReqDescription(req)).Msg("search-cache-hit")
+			return resp, nil
+		}
+	}
+	lexica := searchLexica(req)
+	gen := cache.generation(lexica)
+	resp, err := s.search(ctx, req, true)
+	if err != nil {
+		return nil, err
+	}
+	cache.put(key, lexica, gen, resp, countWords(resp))
+	return resp, nil
+}
+
+// searchCacheKey identifies what a search returns. Lists of alphagrams,
+// words and probabilities are sorted when the order they're given in
+// can't change the results, and the seed only matters to random orders.
+// An unsorted list too long for one query is searched a chunk at a time,
+// and the chunks come back in the list's order, so that order is kept.
+func searchCacheKey(req *pb.SearchRequest) string {
+	r := proto.Clone(req).(*pb.SearchRequest)
+	// Sorted and paged searches are made in one query, like querygen's.
+	oneQuery := r.Sort != nil || r.PageSize > 0 || r.PageToken != ""
+	walkSearchParams(r.Searchparams, func(p *pb.SearchRequest_SearchParam) bool {
+		switch p.Condition {
+		case pb.SearchRequest_ALPHAGRAM_LIST, pb.SearchRequest_WORD_LIST,
+			pb.SearchRequest_UPLOADED_WORD_OR_ALPHAGRAM_LIST:
+			if sv := p.GetStringarray(); sv != nil && (oneQuery || len(sv.Values) <= MaxSQLChunkSize) {
+				slices.Sort(sv.Values)
+			}
+		case pb.SearchRequest_PROBABILITY_LIST:
+			if nv := p.GetNumberarray(); nv != nil && (oneQuery || len(nv.Values) <= MaxSQLChunkSize) {
+				slices.Sort(nv.Values)
+			}
+		}
+		return true
+	})
+	if r.Sort != nil && r.Sort.Field != pb.SearchRequest_SortSpec_RANDOM {
+		r.Sort.Seed = 0
+	}
+	bts, _ := proto.MarshalOptions{Deterministic: true}.Marshal(r)
+	sum := sha256.Sum256(bts)
+	return string(sum[:])
+}
+
+// searchLexica lists the lexica a search's results come from: the one
+// searched, and any it is compared against.
+func searchLexica(req *pb.SearchRequest) []string {
+	lexica := []string{}
+	if len(req.Searchparams) > 0 {
+		lexica = append(lexica, req.Searchparams[0].GetStringvalue().GetValue())
+	}
+	walkSearchParams(req.Searchparams, func(p *pb.SearchRequest_SearchParam) bool {
+		if p.Condition == pb.SearchRequest_CROSS_LEXICON {
+			lexica = append(lexica, p.GetCrosslexicon().GetInLexica()...)
+			lexica = append(lexica, p.GetCrosslexicon().GetNotInLexica()...)
+		}
+		return true
+	})
+	return lexica
+}
+
+func countWords(resp *pb.SearchResponse) int {
+	words := 0
+	for _, a := range resp.Alphagrams {
+		words += max(len(a.Words), 1)
+	}
+	return words
+}
+
+// alphagramListSearch returns the alphagrams of an expanded search that
+// asks for nothing but a list of alphagrams, in the default order.
+func alphagramListSearch(req *pb.SearchRequest) ([]string, bool) {
+	if !req.Expand || req.Sort != nil || req.PageSize > 0 || req.PageToken != "" ||
+		len(req.Searchparams) != 2 || req.Searchparams[0].Condition != pb.SearchRequest_LEXICON ||
+		req.Searchparams[1].Condition != pb.SearchRequest_ALPHAGRAM_LIST {
+		return nil, false
+	}
+	return req.Searchparams[1].GetStringarray().GetValues(), true
+}
+
+func alphagramCacheKey(lexName, alphagram string) string {
+	return lexName + "\x00" + alphagram
+}
+
+// searchAlphagramList is an expanded search for a list of alphagrams, in
+// the default order (by probability). Like any other search, it has to fit
+// the caller's budget, however much of it was cached.
+func (s *Server) searchAlphagramList(ctx context.Context, lexName string, alphas []string) (
+	*pb.SearchResponse, error) {
+
+	expanded, err := s.expandedAlphagrams(ctx, lexName, alphas)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.SearchResponse{Lexicon: lexName, Alphagrams: []*pb.Alphagram{}}
+	for _, a := range expanded {
+		resp.Alphagrams = append(resp.Alphagrams, a)
+	}
+	if budget := searchBudget(ctx, s.Config); budget > 0 && countWords(resp) > budget {
+		return nil, overBudgetError(ctx, budget)
+	}
+	sort.Slice(resp.Alphagrams, func(i, j int) bool {
+		a, b := resp.Alphagrams[i], resp.Alphagrams[j]
+		if a.Probability != b.Probability {
+			return a.Probability < b.Probability
+		}
+		if a.Length != b.Length {
+			return a.Length < b.Length
+		}
+		return a.Alphagram < b.Alphagram
+	})
+	return resp, nil
+}
+
+// cachedAlphagrams looks up the full expansions of alphagrams in the
+// cache, keyed by alphagram. It also returns the alphagrams that aren't
+// cached.
+func (s *Server) cachedAlphagrams(lexName string, alphas []string) (map[string]*pb.Alphagram, []string) {
+	cache := RegistryFor(s.Config).alphagrams
+	expanded := map[string]*pb.Alphagram{}
+	missing := []string{}
+	for _, alpha := range alphas {
+		if _, ok := expanded[alpha]; ok {
+			continue
+		}
+		if cached := cache.get(alphagramCacheKey(lexName, alpha)); cached != nil {
+			expanded[alpha] = cached.(*pb.Alphagram)
+		} else if !slices.Contains(missing, alpha) {
+			missing = append(missing, alpha)
+		}
+	}
+	return expanded, missing
+}
+
+// expandedAlphagrams fully expands the alphagrams that are in the lexicon,
+// with all of their words, keyed by alphagram. Alphagrams that aren't in
+// the lexicon are left out. The ones that aren't cached are searched for,
+// within the caller's budget.
+func (s *Server) expandedAlphagrams(ctx context.Context, lexName string, alphas []string) (
+	map[string]*pb.Alphagram, error) {
+
+	expanded, missing := s.cachedAlphagrams(lexName, alphas)
+	if len(missing) == 0 {
+		return expanded, nil
+	}
+	cache := RegistryFor(s.Config).alphagrams
+	lexica := []string{lexName}
+	gen := cache.generation(lexica)
+	resp, err := s.search(ctx, WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon(lexName), SearchDescAlphagramList(missing)}, true), true)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range resp.Alphagrams {
+		cache.put(alphagramCacheKey(lexName, a.Alphagram), lexica, gen, a, len(a.Words))
+		expanded[a.Alphagram] = a
+	}
+	return expanded, nil
+}
+
+// expandFromCache expands the alphagrams in an Expand request that are
+// cached and whose words can all be found in the alphagram's full
+// expansion, keeping only the words asked for. It returns them keyed by
+// alphagram; the rest have to be expanded word by word, which is cheaper
+// than expanding them fully.
+func (s *Server) expandFromCache(req *pb.SearchResponse) map[string]*pb.Alphagram {
+	expanded, _ := s.cachedAlphagrams(req.Lexicon, alphasFromSearchResponse(req))
+	found := map[string]*pb.Alphagram{}
+	for _, a := range req.Alphagrams {
+		full, ok := expanded[a.Alphagram]
+		if !ok {
+			continue
+		}
+		byWord := map[string]*pb.Word{}
+		for _, w := range full.Words {
+			byWord[w.Word] = w
+		}
+		out := &pb.Alphagram{
+			Alphagram:    full.Alphagram,
+			Probability:  full.Probability,
+			Combinations: full.Combinations,
+			Difficulty:   full.Difficulty,
+			Playability:  full.Playability,
+			Length:       full.Length,
+		}
+		for _, w := range a.Words {
+			if word, ok := byWord[w.Word]; ok {
+				out.Words = append(out.Words, word)
+				// A word listed twice is only expanded once.
+				delete(byWord, w.Word)
+			} else {
+				out = nil
+				break
+			}
+		}
+		if out == nil {
+			continue
+		}
+		// Word info comes back in word order.
+		sort.Slice(out.Words, func(i, j int) bool { return out.Words[i].Word < out.Words[j].Word })
+		found[a.Alphagram] = out
+	}
+	return found
+}
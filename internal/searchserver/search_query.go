@@ -0,0 +1,623 @@
+package searchserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+)
+
+// A QueryError is a mistake in a text search query. Pos is where in the
+// query it is, counting characters from 1.
+type QueryError struct {
+	Pos int
+	Msg string
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("at position %d: %s", e.Pos, e.Msg)
+}
+
+func queryErrorf(pos int, format string, args ...interface{}) *QueryError {
+	return &QueryError{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// SearchByQuery runs a search written in the text query language.
+func (s *Server) SearchByQuery(ctx context.Context, req *connect.Request[pb.SearchByQueryRequest]) (
+	*connect.Response[pb.SearchResponse], error) {
+
+	log.Info().Str("query", req.Msg.Query).Msg("searchByQueryRequest")
+	search, err := ParseSearchQuery(req.Msg.Query, req.Msg.DefaultLexicon)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	search.Expand = req.Msg.Expand
+	search.PageSize = req.Msg.PageSize
+	search.PageToken = req.Msg.PageToken
+	return s.Search(ctx, connect.NewRequest(search))
+}
+
+// ParseSearchQuery parses a text search query into a SearchRequest. A query
+// is a list of field:value terms, all of which must match, e.g.
+//
+//	lex:CSW24 len:7-8 prob:1-5000 hooks:back=S -contains:U def:"fish" order:difficulty desc
+//
+// A - in front of a term or a parenthesized group negates it, and OR
+// between terms (or groups) matches either side. Values with spaces or
+// parentheses in them go in double quotes. Ranges are written as 7 or 7-8.
+// The fields are:
+//
+//	lex, lexicon       the lexicon; defaultLexicon if there isn't one
+//	len, length        range
+//	prob, probability  range
+//	limit              range of positions in the sort order (PROBABILITY_LIMIT)
+//	diff, difficulty   range
+//	play, playability  range
+//	anagrams           range: the number of anagrams
+//	vowels, points, distinct, repeats
+//	                   ranges: vowels, point value, distinct letters, and
+//	                   the most times one letter is repeated
+//	contains, excludes letters
+//	anagram            letters, as for MATCHING_ANAGRAM
+//	pattern            a PatternParam pattern, e.g. ^UN*S$
+//	hooks              front=LETTERS, back=LETTERS, inner, none, or a range
+//	                   of the number of hooks
+//	fronthooks, backhooks, frontext, backext
+//	                   ranges of hook and two-letter extension counts
+//	def, definition    text to search the definitions for
+//	pos                part of speech
+//	root               the word this one is an inflection of
+//	symbols            lexicon symbols, e.g. #
+//	added              the lexicon the word was added in
+//	since              the lexicon the word has been valid since
+//	in, notin          comma-separated lexica the alphagram is (or isn't)
+//	                   valid in
+//	alphagrams         a comma-separated list of alphagrams
+//	is                 deleted or hookless
+//	order              probability, difficulty, playability, combinations,
+//	                   alphagram, points or random, optionally followed by
+//	                   asc or desc
+//	seed               the seed for order:random
+//
+// Fields are case-insensitive. lex, order and seed can't be negated or
+// used inside an OR.
+func ParseSearchQuery(query string, defaultLexicon string) (*pb.SearchRequest, error) {
+	toks, err := lexSearchQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{toks: toks, end: len([]rune(query)) + 1}
+	if len(toks) == 0 {
+		return nil, errors.New("the search query is empty")
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok, ok := p.peek(); ok {
+		// parseOr only stops early at a ) it didn't open.
+		return nil, queryErrorf(tok.pos, "unexpected )")
+	}
+
+	b := &searchRequestBuilder{req: &pb.SearchRequest{}, lexicon: defaultLexicon}
+	if err := b.addTopLevel(root); err != nil {
+		return nil, err
+	}
+	if b.lexicon == "" {
+		return nil, errors.New("the search query needs a lexicon, e.g. lex:CSW24")
+	}
+	if b.seedPos > 0 {
+		if b.req.GetSort().GetField() != pb.SearchRequest_SortSpec_RANDOM {
+			return nil, queryErrorf(b.seedPos, "seed: only applies to order:random")
+		}
+		b.req.Sort.Seed = b.seed
+	}
+	b.req.Searchparams = append([]*pb.SearchRequest_SearchParam{SearchDescLexicon(b.lexicon)},
+		b.req.Searchparams...)
+	return b.req, nil
+}
+
+type queryTokenKind int
+
+const (
+	queryTerm queryTokenKind = iota
+	queryMinus
+	queryOpen
+	queryClose
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	pos  int
+	// text is the term as it was written.
+	text string
+	// For terms: the lowercased field, if the term has one, and the value
+	// with any quotes taken out.
+	field    string
+	hasField bool
+	value    string
+	valuePos int
+}
+
+// isWord reports whether the token is the unquoted bare word w, e.g. OR.
+func (t queryToken) isWord(w string) bool {
+	return t.kind == queryTerm && !t.hasField && t.text == w
+}
+
+func lexSearchQuery(query string) ([]queryToken, error) {
+	rs := []rune(query)
+	toks := []queryToken{}
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			toks = append(toks, queryToken{kind: queryOpen, pos: i + 1, text: "("})
+			i++
+		case r == ')':
+			toks = append(toks, queryToken{kind: queryClose, pos: i + 1, text: ")"})
+			i++
+		case r == '-' && i+1 < len(rs) && !unicode.IsSpace(rs[i+1]) && rs[i+1] != ')':
+			toks = append(toks, queryToken{kind: queryMinus, pos: i + 1, text: "-"})
+			i++
+		default:
+			tok, next, err := lexQueryTerm(rs, i)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, tok)
+			i = next
+		}
+	}
+	return toks, nil
+}
+
+// lexQueryTerm reads the term starting at rs[start], returning it and the
+// index after it. Parentheses inside a term, as in anagram:AB(CD), are
+// part of it as long as they're balanced; an extra ) ends the term.
+func lexQueryTerm(rs []rune, start int) (queryToken, int, error) {
+	tok := queryToken{kind: queryTerm, pos: start + 1, valuePos: start + 1}
+	var value strings.Builder
+	depth := 0
+	i := start
+scan:
+	for i < len(rs) {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			break scan
+		case r == '"':
+			end := i + 1
+			for end < len(rs) && rs[end] != '"' {
+				end++
+			}
+			if end == len(rs) {
+				return tok, 0, queryErrorf(i+1, "unterminated quote")
+			}
+			value.WriteString(string(rs[i+1 : end]))
+			i = end + 1
+		case r == ':' && !tok.hasField:
+			tok.field = strings.ToLower(value.String())
+			tok.hasField = true
+			tok.valuePos = i + 2
+			value.Reset()
+			i++
+		case r == ')' && depth == 0:
+			break scan
+		default:
+			if r == '(' {
+				depth++
+			} else if r == ')' {
+				depth--
+			}
+			value.WriteRune(r)
+			i++
+		}
+	}
+	tok.text = string(rs[start:i])
+	tok.value = value.String()
+	return tok, i, nil
+}
+
+// A queryNode is a term, or an AND, OR or NOT of other nodes.
+type queryNode struct {
+	term *queryToken
+	// direction is the asc or desc after an order: term.
+	direction *queryToken
+	op        pb.SearchRequest_GroupOperator
+	children  []*queryNode
+}
+
+type queryParser struct {
+	toks []queryToken
+	i    int
+	// end is the position just past the end of the query, for errors about
+	// things missing at the end.
+	end int
+}
+
+func (p *queryParser) peek() (queryToken, bool) {
+	if p.i >= len(p.toks) {
+		return queryToken{}, false
+	}
+	return p.toks[p.i], true
+}
+
+// pos is the position of the next token.
+func (p *queryParser) pos() int {
+	if tok, ok := p.peek(); ok {
+		return tok.pos
+	}
+	return p.end
+}
+
+func (p *queryParser) parseOr() (*queryNode, error) {
+	node, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.peek()
+		if !ok || !tok.isWord("OR") {
+			return node, nil
+		}
+		p.i++
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if node.term != nil || node.op != pb.SearchRequest_OR {
+			node = &queryNode{op: pb.SearchRequest_OR, children: []*queryNode{node}}
+		}
+		node.children = append(node.children, next)
+	}
+}
+
+func (p *queryParser) parseAnd() (*queryNode, error) {
+	node := &queryNode{op: pb.SearchRequest_AND}
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind == queryClose || tok.isWord("OR") {
+			break
+		}
+		if tok.isWord("AND") {
+			// AND is what terms next to each other mean anyway.
+			p.i++
+			if _, ok := p.peek(); !ok {
+				return nil, queryErrorf(tok.pos, "AND needs a condition after it")
+			}
+			continue
+		}
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		node.children = append(node.children, child)
+	}
+	switch len(node.children) {
+	case 0:
+		return nil, queryErrorf(p.pos(), "expected a condition")
+	case 1:
+		return node.children[0], nil
+	}
+	return node, nil
+}
+
+func (p *queryParser) parseUnary() (*queryNode, error) {
+	tok := p.toks[p.i]
+	p.i++
+	switch tok.kind {
+	case queryMinus:
+		if _, ok := p.peek(); !ok {
+			return nil, queryErrorf(tok.pos, "- needs a condition after it")
+		}
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &queryNode{op: pb.SearchRequest_NOT, children: []*queryNode{child}}, nil
+	case queryOpen:
+		child, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if close, ok := p.peek(); !ok || close.kind != queryClose {
+			return nil, queryErrorf(tok.pos, "( is never closed")
+		}
+		p.i++
+		return child, nil
+	}
+	if !tok.hasField {
+		if strings.EqualFold(tok.text, "asc") || strings.EqualFold(tok.text, "desc") {
+			return nil, queryErrorf(tok.pos, "%s must come right after an order: term", tok.text)
+		}
+		return nil, queryErrorf(tok.pos, "expected field:value, got %q", tok.text)
+	}
+	node := &queryNode{term: &tok}
+	if tok.field == "order" {
+		if next, ok := p.peek(); ok && next.kind == queryTerm && !next.hasField {
+			switch strings.ToLower(next.text) {
+			case "asc", "desc":
+				node.direction = &next
+				p.i++
+			}
+		}
+	}
+	return node, nil
+}
+
+// searchRequestBuilder turns a parsed query into a SearchRequest.
+type searchRequestBuilder struct {
+	req     *pb.SearchRequest
+	lexicon string
+	// lexPos is where the query's lex: term is, if it has one.
+	lexPos  int
+	seed    int64
+	seedPos int
+}
+
+// addTopLevel adds the terms that all have to match. Only these can set
+// the lexicon and order.
+func (b *searchRequestBuilder) addTopLevel(node *queryNode) error {
+	if node.term == nil && node.op == pb.SearchRequest_AND {
+		for _, child := range node.children {
+			if err := b.addTopLevel(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if node.term != nil {
+		tok := node.term
+		switch tok.field {
+		case "lex", "lexicon":
+			if b.lexPos > 0 {
+				return queryErrorf(tok.pos, "the lexicon was already given at position %d", b.lexPos)
+			}
+			if tok.value == "" {
+				return queryErrorf(tok.valuePos, "%s: needs a lexicon", tok.field)
+			}
+			b.lexicon = tok.value
+			b.lexPos = tok.pos
+			return nil
+		case "order":
+			if b.req.Sort != nil {
+				return queryErrorf(tok.pos, "there can only be one order: term")
+			}
+			sort, err := parseSortSpec(node)
+			if err != nil {
+				return err
+			}
+			b.req.Sort = sort
+			return nil
+		case "seed":
+			seed, err := strconv.ParseInt(tok.value, 10, 64)
+			if err != nil {
+				return queryErrorf(tok.valuePos, "seed: %q is not a number", tok.value)
+			}
+			b.seed = seed
+			b.seedPos = tok.pos
+			return nil
+		}
+	}
+	param, err := b.param(node)
+	if err != nil {
+		return err
+	}
+	b.req.Searchparams = append(b.req.Searchparams, param)
+	return nil
+}
+
+func (b *searchRequestBuilder) param(node *queryNode) (*pb.SearchRequest_SearchParam, error) {
+	if node.term != nil {
+		return termParam(node.term)
+	}
+	children := node.children
+	if node.op == pb.SearchRequest_NOT && children[0].term == nil &&
+		children[0].op == pb.SearchRequest_AND {
+		// NOT already negates the AND of its params.
+		children = children[0].children
+	}
+	params := make([]*pb.SearchRequest_SearchParam, len(children))
+	for i, child := range children {
+		param, err := b.param(child)
+		if err != nil {
+			return nil, err
+		}
+		params[i] = param
+	}
+	return SearchDescGroup(node.op, params...), nil
+}
+
+var sortFields = map[string]pb.SearchRequest_SortSpec_Field{
+	"prob":         pb.SearchRequest_SortSpec_PROBABILITY,
+	"probability":  pb.SearchRequest_SortSpec_PROBABILITY,
+	"diff":         pb.SearchRequest_SortSpec_DIFFICULTY,
+	"difficulty":   pb.SearchRequest_SortSpec_DIFFICULTY,
+	"play":         pb.SearchRequest_SortSpec_PLAYABILITY,
+	"playability":  pb.SearchRequest_SortSpec_PLAYABILITY,
+	"combinations": pb.SearchRequest_SortSpec_COMBINATIONS,
+	"alphagram":    pb.SearchRequest_SortSpec_ALPHAGRAM,
+	"points":       pb.SearchRequest_SortSpec_POINT_VALUE,
+	"random":       pb.SearchRequest_SortSpec_RANDOM,
+}
+
+func parseSortSpec(node *queryNode) (*pb.SearchRequest_SortSpec, error) {
+	tok := node.term
+	field, ok := sortFields[strings.ToLower(tok.value)]
+	if !ok {
+		return nil, queryErrorf(tok.valuePos, "can't order by %q; use probability, difficulty, "+
+			"playability, combinations, alphagram, points or random", tok.value)
+	}
+	sort := &pb.SearchRequest_SortSpec{Field: field}
+	if node.direction != nil {
+		sort.Descending = strings.ToLower(node.direction.text) == "desc"
+	}
+	return sort, nil
+}
+
+// termParam makes the search param for one field:value term.
+func termParam(tok *queryToken) (*pb.SearchRequest_SearchParam, error) {
+	value := strings.TrimSpace(tok.value)
+	if value == "" {
+		return nil, queryErrorf(tok.valuePos, "%s: needs a value", tok.field)
+	}
+	rangeParam := func(c pb.SearchRequest_Condition) (*pb.SearchRequest_SearchParam, error) {
+		min, max, err := parseQueryRange(tok)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.SearchRequest_SearchParam{Condition: c, Conditionparam: minMaxParam(min, max)}, nil
+	}
+	switch tok.field {
+	case "lex", "lexicon", "order", "seed":
+		return nil, queryErrorf(tok.pos, "%s: can't be negated or used inside an OR", tok.field)
+	case "len", "length":
+		return rangeParam(pb.SearchRequest_LENGTH)
+	case "prob", "probability":
+		return rangeParam(pb.SearchRequest_PROBABILITY_RANGE)
+	case "limit":
+		return rangeParam(pb.SearchRequest_PROBABILITY_LIMIT)
+	case "diff", "difficulty":
+		return rangeParam(pb.SearchRequest_DIFFICULTY_RANGE)
+	case "play", "playability":
+		return rangeParam(pb.SearchRequest_PLAYABILITY_RANGE)
+	case "anagrams":
+		return rangeParam(pb.SearchRequest_NUMBER_OF_ANAGRAMS)
+	case "vowels":
+		return rangeParam(pb.SearchRequest_NUMBER_OF_VOWELS)
+	case "points":
+		return rangeParam(pb.SearchRequest_POINT_VALUE)
+	case "distinct":
+		return rangeParam(pb.SearchRequest_NUM_DISTINCT_LETTERS)
+	case "repeats":
+		return rangeParam(pb.SearchRequest_MAX_LETTER_REPEATS)
+	case "fronthooks":
+		return rangeParam(pb.SearchRequest_NUM_FRONT_HOOKS)
+	case "backhooks":
+		return rangeParam(pb.SearchRequest_NUM_BACK_HOOKS)
+	case "frontext":
+		return rangeParam(pb.SearchRequest_NUM_FRONT_EXTENSIONS)
+	case "backext":
+		return rangeParam(pb.SearchRequest_NUM_BACK_EXTENSIONS)
+	case "contains":
+		return SearchDescContainsLetters(value), nil
+	case "excludes":
+		return SearchDescExcludesLetters(value), nil
+	case "anagram":
+		return &pb.SearchRequest_SearchParam{
+			Condition:      pb.SearchRequest_MATCHING_ANAGRAM,
+			Conditionparam: stringParam(value),
+		}, nil
+	case "pattern":
+		return SearchDescPattern(&pb.PatternParam{Pattern: value}), nil
+	case "hooks":
+		return hooksParam(tok, value)
+	case "def", "definition":
+		return &pb.SearchRequest_SearchParam{
+			Condition:      pb.SearchRequest_DEFINITION_CONTAINS,
+			Conditionparam: stringParam(value),
+		}, nil
+	case "pos":
+		return SearchDescPartOfSpeech(value), nil
+	case "root":
+		return SearchDescInflectionOf(value), nil
+	case "symbols":
+		return SearchDescLexiconSymbols(value), nil
+	case "added":
+		return SearchDescAddedInLexicon(value), nil
+	case "since":
+		return SearchDescInEveryLexiconSince(value), nil
+	case "in":
+		return SearchDescCrossLexicon(splitQueryList(value), nil, false), nil
+	case "notin":
+		return SearchDescCrossLexicon(nil, splitQueryList(value), false), nil
+	case "alphagrams":
+		return SearchDescAlphagramList(splitQueryList(strings.ToUpper(value))), nil
+	case "is":
+		switch strings.ToLower(value) {
+		case "deleted":
+			return SearchDescDeleted(), nil
+		case "hookless":
+			return SearchDescHookless(), nil
+		}
+		return nil, queryErrorf(tok.valuePos, "is: can be deleted or hookless, not %q", value)
+	}
+	return nil, queryErrorf(tok.pos, "unknown field %q", tok.field)
+}
+
+func hooksParam(tok *queryToken, value string) (*pb.SearchRequest_SearchParam, error) {
+	hooks := func(t pb.SearchRequest_HookType, letters string) *pb.SearchRequest_SearchParam {
+		return &pb.SearchRequest_SearchParam{
+			Condition: pb.SearchRequest_CONTAINS_HOOKS,
+			Conditionparam: &pb.SearchRequest_SearchParam_Hooksparam{
+				Hooksparam: &pb.SearchRequest_HooksParam{HookType: t, Hooks: letters},
+			},
+		}
+	}
+	kind, letters, hasLetters := strings.Cut(value, "=")
+	switch strings.ToLower(kind) {
+	case "front", "back":
+		if !hasLetters || letters == "" {
+			return nil, queryErrorf(tok.valuePos, "hooks:%s needs letters, e.g. hooks:%s=S", kind, kind)
+		}
+		if strings.ToLower(kind) == "front" {
+			return hooks(pb.SearchRequest_FRONT_HOOKS, letters), nil
+		}
+		return hooks(pb.SearchRequest_BACK_HOOKS, letters), nil
+	case "inner":
+		return hooks(pb.SearchRequest_INNER_HOOKS, ""), nil
+	case "none":
+		return SearchDescHookless(), nil
+	}
+	if value[0] >= '0' && value[0] <= '9' {
+		min, max, err := parseQueryRange(tok)
+		if err != nil {
+			return nil, err
+		}
+		return SearchDescHookCount(pb.SearchRequest_NUM_HOOKS, min, max), nil
+	}
+	return nil, queryErrorf(tok.valuePos,
+		"hooks: can be front=LETTERS, back=LETTERS, inner, none or a number of hooks, not %q", value)
+}
+
+// parseQueryRange reads a value like 7 or 7-8.
+func parseQueryRange(tok *queryToken) (int, int, error) {
+	value := strings.TrimSpace(tok.value)
+	lo, hi, isRange := strings.Cut(value, "-")
+	min, err := strconv.Atoi(lo)
+	if err != nil {
+		return 0, 0, queryErrorf(tok.valuePos, "%s: %q is not a number or a range like 7-8",
+			tok.field, value)
+	}
+	max := min
+	if isRange {
+		max, err = strconv.Atoi(hi)
+		if err != nil {
+			return 0, 0, queryErrorf(tok.valuePos, "%s: %q is not a number or a range like 7-8",
+				tok.field, value)
+		}
+	}
+	if max < min {
+		return 0, 0, queryErrorf(tok.valuePos, "%s: the range %s is backwards", tok.field, value)
+	}
+	return min, max, nil
+}
+
+func splitQueryList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,157 @@
+package searchserver
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	contains := func(letters string) *pb.SearchRequest_SearchParam {
+		return SearchDescContainsLetters(letters)
+	}
+	backHooks := &pb.SearchRequest_SearchParam{
+		Condition: pb.SearchRequest_CONTAINS_HOOKS,
+		Conditionparam: &pb.SearchRequest_SearchParam_Hooksparam{
+			Hooksparam: &pb.SearchRequest_HooksParam{HookType: pb.SearchRequest_BACK_HOOKS, Hooks: "S"},
+		},
+	}
+	definition := &pb.SearchRequest_SearchParam{
+		Condition:      pb.SearchRequest_DEFINITION_CONTAINS,
+		Conditionparam: stringParam("fish bone"),
+	}
+	anagram := &pb.SearchRequest_SearchParam{
+		Condition:      pb.SearchRequest_MATCHING_ANAGRAM,
+		Conditionparam: stringParam("AB(CD)"),
+	}
+
+	tests := []struct {
+		query    string
+		expected *pb.SearchRequest
+	}{{
+		`lex:CSW24 len:7-8 prob:1-5000 hooks:back=S -contains:U def:"fish bone" order:difficulty desc`,
+		&pb.SearchRequest{
+			Searchparams: []*pb.SearchRequest_SearchParam{
+				SearchDescLexicon("CSW24"), SearchDescLength(7, 8), SearchDescProbRange(1, 5000),
+				backHooks, SearchDescGroup(pb.SearchRequest_NOT, contains("U")), definition,
+			},
+			Sort: &pb.SearchRequest_SortSpec{
+				Field: pb.SearchRequest_SortSpec_DIFFICULTY, Descending: true},
+		},
+	}, {
+		// The default lexicon, OR, and parentheses.
+		`LEN:7 (contains:Q OR contains:Z) -(hooks:none diff:50-100)`,
+		&pb.SearchRequest{
+			Searchparams: []*pb.SearchRequest_SearchParam{
+				SearchDescLexicon("NWL2023"), SearchDescLength(7, 7),
+				SearchDescGroup(pb.SearchRequest_OR, contains("Q"), contains("Z")),
+				SearchDescGroup(pb.SearchRequest_NOT, SearchDescHookless(),
+					SearchDescDifficultyRange(50, 100)),
+			},
+		},
+	}, {
+		// AND binds tighter than OR.
+		`len:7 contains:Q OR len:8 AND contains:Z`,
+		&pb.SearchRequest{
+			Searchparams: []*pb.SearchRequest_SearchParam{
+				SearchDescLexicon("NWL2023"),
+				SearchDescGroup(pb.SearchRequest_OR,
+					SearchDescGroup(pb.SearchRequest_AND, SearchDescLength(7, 7), contains("Q")),
+					SearchDescGroup(pb.SearchRequest_AND, SearchDescLength(8, 8), contains("Z"))),
+			},
+		},
+	}, {
+		`anagram:AB(CD) in:NWL2023,CSW19 order:random seed:42 lex:CSW24`,
+		&pb.SearchRequest{
+			Searchparams: []*pb.SearchRequest_SearchParam{
+				SearchDescLexicon("CSW24"), anagram,
+				SearchDescCrossLexicon([]string{"NWL2023", "CSW19"}, nil, false),
+			},
+			Sort: &pb.SearchRequest_SortSpec{Field: pb.SearchRequest_SortSpec_RANDOM, Seed: 42},
+		},
+	}, {
+		`is:deleted len:15 hooks:2-3 fronthooks:0 limit:1-200`,
+		&pb.SearchRequest{
+			Searchparams: []*pb.SearchRequest_SearchParam{
+				SearchDescLexicon("NWL2023"), SearchDescDeleted(), SearchDescLength(15, 15),
+				SearchDescHookCount(pb.SearchRequest_NUM_HOOKS, 2, 3),
+				SearchDescHookCount(pb.SearchRequest_NUM_FRONT_HOOKS, 0, 0),
+				SearchDescProbLimit(1, 200),
+			},
+		},
+	}}
+	for _, tc := range tests {
+		req, err := ParseSearchQuery(tc.query, "NWL2023")
+		assert.Nil(t, err, tc.query)
+		assert.True(t, proto.Equal(tc.expected, req), "%s: got %v", tc.query, req)
+	}
+}
+
+func TestParseSearchQueryErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int
+		msg   string
+	}{
+		{`lex:CSW24 len:7-x`, 15, `len: "7-x" is not a number or a range like 7-8`},
+		{`lex:CSW24 len:8-7`, 15, `len: the range 8-7 is backwards`},
+		{`lex:CSW24 colour:red`, 11, `unknown field "colour"`},
+		{`lex:CSW24 fish`, 11, `expected field:value, got "fish"`},
+		{`lex:CSW24 def:"fish`, 15, `unterminated quote`},
+		{`lex:CSW24 (len:7 OR len:8`, 11, `( is never closed`},
+		{`lex:CSW24 len:7)`, 16, `unexpected )`},
+		{`lex:CSW24 len:7 OR`, 19, `expected a condition`},
+		{`-lex:CSW24 len:7`, 2, `lex: can't be negated or used inside an OR`},
+		{`lex:CSW24 lex:NWL2023`, 11, `the lexicon was already given at position 1`},
+		{`lex:CSW24 order:length`, 17, `can't order by "length"; use probability, difficulty, ` +
+			`playability, combinations, alphagram, points or random`},
+		{`lex:CSW24 desc`, 11, `desc must come right after an order: term`},
+		{`lex:CSW24 seed:3`, 11, `seed: only applies to order:random`},
+		{`lex:CSW24 hooks:back`, 17, `hooks:back needs letters, e.g. hooks:back=S`},
+		{`lex:CSW24 len:`, 15, `len: needs a value`},
+	}
+	for _, tc := range tests {
+		_, err := ParseSearchQuery(tc.query, "")
+		qerr, ok := err.(*QueryError)
+		if assert.True(t, ok, "%s: %v", tc.query, err) {
+			assert.Equal(t, tc.pos, qerr.Pos, tc.query)
+			assert.Equal(t, tc.msg, qerr.Msg, tc.query)
+		}
+	}
+
+	_, err := ParseSearchQuery(`len:7`, "")
+	assert.EqualError(t, err, "the search query needs a lexicon, e.g. lex:CSW24")
+}
+
+func TestSearchByQuery(t *testing.T) {
+	cfg := makeTestLexiconDB(t, "TEST")
+	writeTestWords(t, cfg, "TEST", `
+		DROP TABLE alphagrams;
+		CREATE TABLE alphagrams (alphagram varchar(20), length int, probability int,
+			difficulty int, num_anagrams int);
+		INSERT INTO alphagrams VALUES ('AEINST', 6, 2, 12, 2), ('AEINRST', 7, 1, 0, 3);
+		INSERT INTO words (word, alphagram, inner_front_hook, inner_back_hook) VALUES
+			('SATINE', 'AEINST', 0, 0), ('TISANE', 'AEINST', 0, 0),
+			('RETINAS', 'AEINRST', 0, 0), ('RETAINS', 'AEINRST', 0, 0);`)
+
+	s := &Server{Config: cfg}
+	resp, err := s.SearchByQuery(context.Background(), connect.NewRequest(&pb.SearchByQueryRequest{
+		Query: "len:6-7 order:probability desc", DefaultLexicon: "TEST"}))
+	assert.Nil(t, err)
+	assert.Equal(t, "TEST", resp.Msg.Lexicon)
+	alphas := []string{}
+	for _, a := range resp.Msg.Alphagrams {
+		alphas = append(alphas, a.Alphagram)
+	}
+	assert.Equal(t, []string{"AEINST", "AEINRST"}, alphas)
+
+	_, err = s.SearchByQuery(context.Background(), connect.NewRequest(&pb.SearchByQueryRequest{
+		Query: "len:six", DefaultLexicon: "TEST"}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	assert.Contains(t, err.Error(), "at position 5")
+}
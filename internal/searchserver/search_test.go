@@ -2,12 +2,19 @@ package searchserver
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"connectrpc.com/connect"
 	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
 	"github.com/domino14/word_db_server/config"
+	"github.com/domino14/word_db_server/dbmaker"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -113,6 +120,353 @@ func TestPtsNumAnagrams(t *testing.T) {
 	assert.Equal(t, []string{"AEGINRS", "AEGINST", "EIPRSST"}, alphagrams(resp))
 }
 
+func TestPlayabilityRange(t *testing.T) {
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("NWL23"),
+		SearchDescLength(7, 7),
+		SearchDescPlayabilityRange(1, 1000000),
+	}, true)
+	resp, err := searchHelper(req)
+	assert.Nil(t, err)
+	for _, a := range resp.Alphagrams {
+		assert.Greater(t, a.Playability, int32(0))
+	}
+}
+
+func TestOrGroup(t *testing.T) {
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("NWL18"),
+		SearchDescLength(8, 8),
+		SearchDescGroup(pb.SearchRequest_OR,
+			SearchDescProbRange(201, 202),
+			SearchDescProbRange(203, 204)),
+	}, false)
+	resp, err := searchHelper(req)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ADEEGORT", "AEEGLNOT", "DEEGIORT", "EEGILNOR"},
+		alphagrams(resp))
+}
+
+func TestNotGroup(t *testing.T) {
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("NWL18"),
+		SearchDescLength(8, 8),
+		SearchDescProbRange(201, 204),
+		SearchDescGroup(pb.SearchRequest_NOT,
+			SearchDescAlphagramList([]string{"AEEGLNOT", "EEGILNOR"})),
+	}, false)
+	resp, err := searchHelper(req)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ADEEGORT", "DEEGIORT"}, alphagrams(resp))
+}
+
+func TestWalkSearchParamsStops(t *testing.T) {
+	params := []*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("NWL18"),
+		SearchDescGroup(pb.SearchRequest_AND,
+			SearchDescLength(8, 8),
+			SearchDescDeleted(),
+			SearchDescProbRange(201, 204)),
+		SearchDescPointValue(10, 20),
+	}
+	seen := []pb.SearchRequest_Condition{}
+	done := walkSearchParams(params, func(p *pb.SearchRequest_SearchParam) bool {
+		seen = append(seen, p.Condition)
+		return p.Condition != pb.SearchRequest_DELETED_WORD
+	})
+	assert.False(t, done)
+	assert.Equal(t, []pb.SearchRequest_Condition{
+		pb.SearchRequest_LEXICON, pb.SearchRequest_CONDITION_GROUP,
+		pb.SearchRequest_LENGTH, pb.SearchRequest_DELETED_WORD}, seen)
+}
+
+func TestPagination(t *testing.T) {
+	params := []*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("NWL18"),
+		SearchDescLength(8, 8),
+		SearchDescProbRange(101, 350),
+	}
+	resp, err := searchHelper(WordSearch(params, false))
+	assert.Nil(t, err)
+	all := alphagrams(resp)
+
+	req := WordSearch(params, false)
+	req.PageSize = 100
+	paged := []string{}
+	pages := 0
+	for {
+		resp, err := searchHelper(req)
+		assert.Nil(t, err)
+		paged = append(paged, alphagrams(resp)...)
+		pages++
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	assert.Equal(t, 3, pages)
+	assert.Equal(t, all, paged)
+}
+
+func TestPaginationChunkedQueries(t *testing.T) {
+	probs := []int32{}
+	for i := int32(1); i <= 2500; i++ {
+		probs = append(probs, i)
+	}
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("NWL18"),
+		SearchDescLength(7, 7),
+		SearchDescProbabilityList(probs),
+	}, false)
+	req.PageSize = 1000
+	total := 0
+	for {
+		resp, err := searchHelper(req)
+		assert.Nil(t, err)
+		total += len(resp.Alphagrams)
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	assert.Equal(t, 2500, total)
+}
+
+func TestHardestSevens(t *testing.T) {
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("NWL23"),
+		SearchDescLength(7, 7),
+		SearchDescProbLimit(1, 200),
+	}, true)
+	req.Sort = &pb.SearchRequest_SortSpec{
+		Field:      pb.SearchRequest_SortSpec_DIFFICULTY,
+		Descending: true,
+	}
+	resp, err := searchHelper(req)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, len(resp.Alphagrams))
+	for i := 1; i < len(resp.Alphagrams); i++ {
+		assert.GreaterOrEqual(t, resp.Alphagrams[i-1].Difficulty, resp.Alphagrams[i].Difficulty)
+	}
+}
+
+func TestSeededRandomOrder(t *testing.T) {
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("NWL18"),
+		SearchDescLength(8, 8),
+		SearchDescProbRange(1, 1000),
+		SearchDescProbLimit(1, 50),
+	}, false)
+	req.Sort = &pb.SearchRequest_SortSpec{
+		Field: pb.SearchRequest_SortSpec_RANDOM,
+		Seed:  1234,
+	}
+	first, err := searchHelper(req)
+	assert.Nil(t, err)
+	second, err := searchHelper(req)
+	assert.Nil(t, err)
+	assert.Equal(t, 50, len(first.Alphagrams))
+	assert.Equal(t, alphagrams(first), alphagrams(second))
+
+	req.Sort.Seed = 4321
+	third, err := searchHelper(req)
+	assert.Nil(t, err)
+	assert.NotEqual(t, alphagrams(first), alphagrams(third))
+}
+
+func TestSeededRandomOrderSharesStatement(t *testing.T) {
+	s := makeCachedTestLexiconDB(t)
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("TEST"), SearchDescLength(6, 7)}, false)
+	req.Sort = &pb.SearchRequest_SortSpec{Field: pb.SearchRequest_SortSpec_RANDOM}
+	orders := map[string]bool{}
+	for seed := int64(1); seed <= 10; seed++ {
+		req.Sort.Seed = seed
+		resp, err := s.Search(context.Background(), connect.NewRequest(req))
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []string{"AEINST", "AEINRST"}, alphagrams(resp.Msg))
+		orders[strings.Join(alphagrams(resp.Msg), " ")] = true
+	}
+	// Both orders come up, all from the one prepared statement.
+	assert.Equal(t, 2, len(orders))
+	ldb, err := RegistryFor(s.Config).get("TEST")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(ldb.stmts))
+}
+
+func TestContainsLetters(t *testing.T) {
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("NWL18"),
+		SearchDescLength(7, 7),
+		SearchDescContainsLetters("SATIRE"),
+	}, false)
+	resp, err := searchHelper(req)
+	assert.Nil(t, err)
+	assert.Contains(t, alphagrams(resp), "AEINRST")
+	assert.Contains(t, alphagrams(resp), "AEIRSTT")
+	for _, a := range alphagrams(resp) {
+		for _, l := range "AEIRST" {
+			assert.Contains(t, a, string(l))
+		}
+	}
+}
+
+func TestContainsAndExcludesLetters(t *testing.T) {
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("NWL18"),
+		SearchDescLength(2, 8),
+		SearchDescContainsLetters("QEE"),
+		SearchDescExcludesLetters("U"),
+	}, false)
+	resp, err := searchHelper(req)
+	assert.Nil(t, err)
+	assert.NotZero(t, len(resp.Alphagrams))
+	for _, a := range alphagrams(resp) {
+		assert.Contains(t, a, "Q")
+		assert.Contains(t, a, "EE")
+		assert.NotContains(t, a, "U")
+	}
+}
+
+func TestLetterRepeats(t *testing.T) {
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("NWL18"),
+		SearchDescLength(7, 7),
+		SearchDescNumDistinctLetters(7, 7),
+		SearchDescMaxLetterRepeats(2, 3),
+	}, false)
+	resp, err := searchHelper(req)
+	assert.Nil(t, err)
+	// Seven distinct letters in a seven can't repeat any of them.
+	assert.Equal(t, 0, len(resp.Alphagrams))
+
+	req = WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("NWL18"),
+		SearchDescLength(4, 4),
+		SearchDescNumDistinctLetters(1, 1),
+	}, false)
+	resp, err = searchHelper(req)
+	assert.Nil(t, err)
+	for _, a := range alphagrams(resp) {
+		assert.Equal(t, strings.Repeat(a[:1], 4), a)
+	}
+}
+
+func TestWordPattern(t *testing.T) {
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("NWL18"),
+		SearchDescPattern(&pb.PatternParam{Pattern: "IEST$", MinLength: 8, MaxLength: 8}),
+	}, true)
+	resp, err := searchHelper(req)
+	assert.Nil(t, err)
+	assert.NotZero(t, len(resp.Alphagrams))
+	found := false
+	for _, a := range resp.Alphagrams {
+		assert.Equal(t, int32(8), a.Length)
+		for _, w := range a.Words {
+			if w.Word == "HEAVIEST" {
+				found = true
+			}
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestWordSearchPattern(t *testing.T) {
+	s := &WordSearchServer{Config: DefaultConfig}
+	resp, err := s.WordSearch(context.Background(), connect.NewRequest(&pb.WordSearchRequest{
+		Lexicon: "NWL18",
+		Pattern: &pb.PatternParam{
+			Pattern: "^Q",
+			LetterCounts: []*pb.LetterCountConstraint{
+				{Letters: "U", Min: 0, Max: 0},
+			},
+			MaxLength: 5,
+		},
+	}))
+	assert.Nil(t, err)
+	words := []string{}
+	for _, w := range resp.Msg.Words {
+		words = append(words, w.Word)
+	}
+	assert.Contains(t, words, "QI")
+	assert.Contains(t, words, "QAT")
+	assert.NotContains(t, words, "QUA")
+	assert.NotContains(t, words, "AQUA")
+}
+
+func TestWordSearchPatternLimit(t *testing.T) {
+	s := &WordSearchServer{Config: DefaultConfig}
+	search := func(limit int32) (*connect.Response[pb.WordSearchResponse], error) {
+		return s.WordSearch(context.Background(), connect.NewRequest(&pb.WordSearchRequest{
+			Lexicon: "NWL18",
+			Pattern: &pb.PatternParam{Pattern: "^Q", MaxLength: 5},
+			Limit:   limit,
+		}))
+	}
+	resp, err := search(3)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(resp.Msg.Words))
+	assert.Equal(t, "QADI", resp.Msg.Words[0].Word)
+
+	_, err = search(int32(DefaultConfig.MaxQueryResults) + 1)
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+}
+
+func TestPatternLimit(t *testing.T) {
+	limit, err := patternLimit(0, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, 100, limit)
+	limit, err = patternLimit(10, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, limit)
+	limit, err = patternLimit(10, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, limit)
+	_, err = patternLimit(101, 100)
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+}
+
+func TestHookless(t *testing.T) {
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("NWL18"),
+		SearchDescLength(7, 7),
+		SearchDescProbRange(1, 500),
+		SearchDescHookless(),
+	}, true)
+	resp, err := searchHelper(req)
+	assert.Nil(t, err)
+	assert.NotZero(t, len(resp.Alphagrams))
+	for _, a := range resp.Alphagrams {
+		hookless := false
+		for _, w := range a.Words {
+			if w.FrontHooks == "" && w.BackHooks == "" {
+				hookless = true
+			}
+		}
+		assert.True(t, hookless, a.Alphagram)
+	}
+}
+
+func TestNumHooks(t *testing.T) {
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("NWL18"),
+		SearchDescLength(4, 4),
+		SearchDescHookCount(pb.SearchRequest_NUM_FRONT_HOOKS, 8, 26),
+		SearchDescHookCount(pb.SearchRequest_NUM_BACK_EXTENSIONS, 1, 100),
+	}, true)
+	resp, err := searchHelper(req)
+	assert.Nil(t, err)
+	assert.NotZero(t, len(resp.Alphagrams))
+	for _, a := range resp.Alphagrams {
+		most := 0
+		for _, w := range a.Words {
+			most = max(most, len(w.FrontHooks))
+		}
+		assert.GreaterOrEqual(t, most, 8, a.Alphagram)
+	}
+}
+
 func TestAlphagramList(t *testing.T) {
 	req := WordSearch([]*pb.SearchRequest_SearchParam{
 		SearchDescLexicon("NWL18"),
@@ -252,14 +606,14 @@ func TestProbabilityListMultipleQueries(t *testing.T) {
 		Config: DefaultConfig,
 	}
 
-	db, err := getDbConnection(s.Config, qgen.LexiconName())
+	db, err := acquireDB(context.Background(), s.Config, qgen.LexiconName())
 	assert.Nil(t, err)
-	defer db.Close()
-	queries, err := qgen.Generate()
+	defer db.Release()
+	queries, err := qgen.Generate(context.Background())
 	assert.Nil(t, err)
 	// There should be 5 queries (max chunk size is 2 and we have 9 elements in list)
 	assert.Equal(t, 5, len(queries))
-	pbAlphas, err := combineQueryResults(queries, db, expand, qgen.Type(), DefaultConfig)
+	pbAlphas, err := combineQueryResults(context.Background(), queries, db, expand, qgen.Type(), DefaultConfig)
 	assert.Nil(t, err)
 	assert.Equal(t, []string{
 		"ADELNOR", "EILNORS", // 73, 92
@@ -283,15 +637,361 @@ func TestProbabilityListMultipleQueriesOther(t *testing.T) {
 	s := &Server{
 		Config: DefaultConfig,
 	}
-	db, _ := getDbConnection(s.Config, qgen.LexiconName())
-	defer db.Close()
-	queries, _ := qgen.Generate()
+	db, _ := acquireDB(context.Background(), s.Config, qgen.LexiconName())
+	defer db.Release()
+	queries, _ := qgen.Generate(context.Background())
 	// There should be 3 queries (max chunk size is 2 and we have 9 elements in list)
 	assert.Equal(t, 3, len(queries))
-	pbAlphas, _ := combineQueryResults(queries, db, expand, qgen.Type(), DefaultConfig)
+	pbAlphas, _ := combineQueryResults(context.Background(), queries, db, expand, qgen.Type(), DefaultConfig)
 	assert.Equal(t, []string{
 		"ADELNOR", "AENORSU", "EILNORS", // 73, 85, 92
 		"AEGINOS", "AINORTU", "CEINORT", // 43, 61, 185
 		"AEEILNT", "AAEEINT", "AEINNRT", // 25, 33, 99
 	}, alphsFromPB(pbAlphas))
 }
+
+func TestCrossLexiconWords(t *testing.T) {
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("CSW21"),
+		SearchDescLength(7, 7),
+		SearchDescProbRange(1, 1000),
+		SearchDescCrossLexicon(nil, []string{"NWL18"}, true),
+	}, true)
+	resp, err := searchHelper(req)
+	assert.Nil(t, err)
+	assert.NotZero(t, len(resp.Alphagrams))
+	for _, a := range resp.Alphagrams {
+		for _, w := range a.Words {
+			assert.Contains(t, w.LexiconSymbols, "#", w.Word)
+		}
+	}
+}
+
+func TestCrossLexiconAlphagrams(t *testing.T) {
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("CSW21"),
+		SearchDescLength(7, 7),
+		SearchDescProbRange(1, 1000),
+		SearchDescCrossLexicon(nil, []string{"NWL18"}, false),
+	}, false)
+	alphaLevel, err := searchHelper(req)
+	assert.Nil(t, err)
+
+	req = WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("CSW21"),
+		SearchDescLength(7, 7),
+		SearchDescProbRange(1, 1000),
+		SearchDescCrossLexicon(nil, []string{"NWL18"}, true),
+	}, false)
+	wordLevel, err := searchHelper(req)
+	assert.Nil(t, err)
+	// An alphagram with no NWL18 anagram has only words that are not in NWL18.
+	assert.LessOrEqual(t, len(alphaLevel.Alphagrams), len(wordLevel.Alphagrams))
+}
+
+func TestLexiconSymbols(t *testing.T) {
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("CSW21"),
+		SearchDescLength(7, 7),
+		SearchDescProbRange(1, 1000),
+		SearchDescLexiconSymbols("#"),
+	}, true)
+	resp, err := searchHelper(req)
+	assert.Nil(t, err)
+	assert.NotZero(t, len(resp.Alphagrams))
+	for _, a := range resp.Alphagrams {
+		found := false
+		for _, w := range a.Words {
+			if strings.Contains(w.LexiconSymbols, "#") {
+				found = true
+			}
+		}
+		assert.True(t, found, a.Alphagram)
+	}
+}
+
+func TestGetWordInformationDefinitions(t *testing.T) {
+	cfg := makeTestLexiconDB(t, "TEST")
+	writeTestWords(t, cfg, "TEST", `
+		INSERT INTO words (word, alphagram, definition, inner_front_hook, inner_back_hook) VALUES
+			('HEX', 'EHX', 'to cast an evil spell upon [v HEXED HEXES HEXING]', 0, 0),
+			('HEXED', 'DEEHX', 'HEX, to cast an evil spell upon [v]', 1, 0);
+		INSERT INTO definitions VALUES
+			('HEX', 1, 'n', 'a hexagon', 'HEXES', ''),
+			('HEX', 0, 'v', 'to cast an evil spell upon', 'HEXED HEXES HEXING', ''),
+			('HEXED', 0, 'v', 'HEX, to cast an evil spell upon', '', 'HEX');`)
+
+	s := &WordSearchServer{Config: cfg}
+	resp, err := s.GetWordInformation(context.Background(),
+		connect.NewRequest(&pb.DefineRequest{Lexicon: "TEST", Word: "hex"}))
+	assert.Nil(t, err)
+	assert.Len(t, resp.Msg.Words, 1)
+	defs := resp.Msg.Words[0].Definitions
+	assert.Len(t, defs, 2)
+	assert.Equal(t, "v", defs[0].PartOfSpeech)
+	assert.Equal(t, []string{"HEXED", "HEXES", "HEXING"}, defs[0].Inflections)
+	assert.Equal(t, "a hexagon", defs[1].Gloss)
+
+	resp, err = s.GetWordInformation(context.Background(),
+		connect.NewRequest(&pb.DefineRequest{Lexicon: "TEST", Word: "hexed"}))
+	assert.Nil(t, err)
+	assert.Equal(t, "HEX", resp.Msg.Words[0].Definitions[0].RootWord)
+	assert.Empty(t, resp.Msg.Words[0].Definitions[0].Inflections)
+}
+
+func TestSearchCancelled(t *testing.T) {
+	cfg := makeTestLexiconDB(t, "TEST")
+	writeTestWords(t, cfg, "TEST", `
+		DROP TABLE alphagrams;
+		CREATE TABLE alphagrams (alphagram varchar(20), length int, probability int);
+		INSERT INTO alphagrams VALUES ('AEINST', 6, 1);
+		INSERT INTO words (word, alphagram) VALUES ('SATINE', 'AEINST');`)
+
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("TEST"), SearchDescLength(6, 6)}, false)
+	qgen, err := createQueryGen(req, cfg, MaxSQLChunkSize)
+	assert.Nil(t, err)
+	db, err := acquireDB(context.Background(), cfg, "TEST")
+	assert.Nil(t, err)
+	defer db.Release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	queries, err := generateQueries(ctx, qgen)
+	assert.Nil(t, err)
+	cancel()
+	_, err = combineQueryResults(ctx, queries, db, false, qgen.Type(), cfg)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, _, err = fetchPage(ctx, queries, db, false, qgen.Type(), cfg, pageToken{}, 10)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSortedPagesOfLongList(t *testing.T) {
+	s := makeBenchLexiconDB(t, 0)
+	// More alphagrams than fit in one query's bind parameters.
+	alphas := []string{}
+	for i := 1; i <= MaxSQLChunkSize+50; i++ {
+		alphas = append(alphas, fmt.Sprintf("AB%05d", i))
+	}
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("BENCH"), SearchDescAlphagramList(alphas)}, false)
+	req.Sort = &pb.SearchRequest_SortSpec{Field: pb.SearchRequest_SortSpec_ALPHAGRAM, Descending: true}
+	req.PageSize = 400
+	paged := []string{}
+	for {
+		resp, err := s.Search(context.Background(), connect.NewRequest(req))
+		assert.Nil(t, err)
+		paged = append(paged, alphagrams(resp.Msg)...)
+		if resp.Msg.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.Msg.NextPageToken
+	}
+	slices.Reverse(alphas)
+	assert.Equal(t, alphas, paged)
+}
+
+// makeBenchLexiconDB makes a lexicon of 2000 alphagrams of 7 letters, each
+// with two words.
+func makeBenchLexiconDB(b testing.TB, cacheSize int) *Server {
+	cfg := makeTestLexiconDB(b, "BENCH")
+	cfg.SearchCacheSize = cacheSize
+	var inserts strings.Builder
+	inserts.WriteString(`
+		DROP TABLE alphagrams;
+		CREATE TABLE alphagrams (alphagram varchar(20), length int, probability int,
+			combinations int, difficulty int, playability int, num_anagrams int);`)
+	for i := 1; i <= 2000; i++ {
+		alpha := fmt.Sprintf("AB%05d", i)
+		fmt.Fprintf(&inserts, `
+		INSERT INTO alphagrams VALUES ('%[1]s', 7, %[2]d, 1000, 50, %[2]d, 2);
+		INSERT INTO words (word, alphagram, definition, inner_front_hook, inner_back_hook) VALUES
+			('%[1]sX', '%[1]s', 'a word', 0, 0), ('%[1]sY', '%[1]s', 'another word', 0, 0);`, alpha, i)
+	}
+	writeTestWords(b, cfg, "BENCH", inserts.String())
+	return &Server{Config: cfg}
+}
+
+// The cold searches go to the database every time, and the warm ones are
+// answered from the cache.
+func BenchmarkSearchCache(b *testing.B) {
+	defer zerolog.SetGlobalLevel(zerolog.GlobalLevel())
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("BENCH"), SearchDescLength(7, 7), SearchDescProbRange(1, 1000)}, true)
+	for _, bc := range []struct {
+		name      string
+		cacheSize int
+	}{{"cold", 0}, {"warm", 100000}} {
+		b.Run(bc.name, func(b *testing.B) {
+			s := makeBenchLexiconDB(b, bc.cacheSize)
+			for i := 0; i <= b.N; i++ {
+				if i == 1 {
+					b.ResetTimer()
+				}
+				if _, err := s.Search(context.Background(), connect.NewRequest(req)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// Looking cards up in a word vault expands lists of alphagrams that mostly
+// overlap from one request to the next.
+func BenchmarkAlphagramListCache(b *testing.B) {
+	defer zerolog.SetGlobalLevel(zerolog.GlobalLevel())
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	for _, bc := range []struct {
+		name      string
+		cacheSize int
+	}{{"cold", 0}, {"warm", 100000}} {
+		b.Run(bc.name, func(b *testing.B) {
+			s := makeBenchLexiconDB(b, bc.cacheSize)
+			for i := 0; i <= b.N; i++ {
+				if i == 1 {
+					b.ResetTimer()
+				}
+				// A sliding window of 50 cards, one new one each time.
+				alphas := []string{}
+				for j := 0; j < 50; j++ {
+					alphas = append(alphas, fmt.Sprintf("AB%05d", (i+j)%2000+1))
+				}
+				_, err := s.Search(context.Background(), connect.NewRequest(WordSearch(
+					[]*pb.SearchRequest_SearchParam{SearchDescLexicon("BENCH"), SearchDescAlphagramList(alphas)},
+					true)))
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchChangedSince(t *testing.T) {
+	s := makeCachedTestLexiconDB(t)
+	search := func(kinds ...string) ([]string, error) {
+		resp, err := s.Search(context.Background(), connect.NewRequest(WordSearch(
+			[]*pb.SearchRequest_SearchParam{SearchDescLexicon("TEST"),
+				SearchDescChangedSince("OLD", kinds...)}, false)))
+		if err != nil {
+			return nil, err
+		}
+		return alphagrams(resp.Msg), nil
+	}
+	// Nothing has been stored yet.
+	_, err := search()
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	db, err := sql.Open("sqlite3", filepath.Join(s.Config.DataPath, "lexica", "db", "TEST.db"))
+	assert.Nil(t, err)
+	d := &dbmaker.LexiconDiff{From: "OLD", To: "TEST", Changes: []dbmaker.LexiconChange{
+		{Kind: dbmaker.ChangeAdded, Word: "STAINER", Alphagram: "AEINRST", Length: 7},
+		{Kind: dbmaker.ChangeHooks, Word: "SATINE", Alphagram: "AEINST", Length: 6,
+			Before: ":", After: ":S"},
+	}}
+	assert.Nil(t, d.StoreChanges(db))
+	assert.Nil(t, db.Close())
+	assert.Nil(t, RegistryFor(s.Config).Reload("TEST"))
+
+	found, err := search()
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"AEINRST", "AEINST"}, found)
+	found, err = search("hooks")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"AEINST"}, found)
+	found, err = search("deleted")
+	assert.Nil(t, err)
+	assert.Empty(t, found)
+	_, err = search("renamed")
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestSearchOlderDatabase(t *testing.T) {
+	s := makeCachedTestLexiconDB(t)
+	// Take the database back to version 11, before word history and
+	// definition senses.
+	db, err := sql.Open("sqlite3", filepath.Join(s.Config.DataPath, "lexica", "db", "TEST.db"))
+	assert.Nil(t, err)
+	_, err = db.Exec(`
+		ALTER TABLE words DROP COLUMN first_lexicon;
+		ALTER TABLE words DROP COLUMN last_lexicon;
+		DROP TABLE definitions;
+		DROP TABLE definition_links;
+		UPDATE db_version SET version = 11;`)
+	assert.Nil(t, err)
+	assert.Nil(t, db.Close())
+
+	resp, err := s.Search(context.Background(), connect.NewRequest(WordSearch(
+		[]*pb.SearchRequest_SearchParam{SearchDescLexicon("TEST"), SearchDescLength(6, 6)}, true)))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"AEINST"}, alphagrams(resp.Msg))
+	assert.Equal(t, 2, len(resp.Msg.Alphagrams[0].Words))
+	assert.Equal(t, "", resp.Msg.Alphagrams[0].Words[0].FirstLexicon)
+	assert.Empty(t, resp.Msg.Alphagrams[0].Words[0].Definitions)
+
+	for _, param := range []*pb.SearchRequest_SearchParam{
+		SearchDescAddedInLexicon("NWL20"), SearchDescInEveryLexiconSince("NWL20")} {
+		_, err = s.Search(context.Background(), connect.NewRequest(WordSearch(
+			[]*pb.SearchRequest_SearchParam{SearchDescLexicon("TEST"), param}, true)))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+		assert.ErrorContains(t, err, "no lexicon history is stored in the TEST database (version 11)")
+	}
+
+	ws := &WordSearchServer{Config: s.Config}
+	info, err := ws.GetWordInformation(context.Background(), connect.NewRequest(&pb.DefineRequest{
+		Lexicon: "TEST", Word: "retains"}))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(info.Msg.Words))
+	assert.Equal(t, "keeps", info.Msg.Words[0].Definition)
+}
+
+func TestSearchVersion6Database(t *testing.T) {
+	s := makeCachedTestLexiconDB(t)
+	// Version 6 databases, which are still deployed, have no playability.
+	db, err := sql.Open("sqlite3", filepath.Join(s.Config.DataPath, "lexica", "db", "TEST.db"))
+	assert.Nil(t, err)
+	_, err = db.Exec(`
+		ALTER TABLE alphagrams DROP COLUMN playability;
+		ALTER TABLE words DROP COLUMN first_lexicon;
+		ALTER TABLE words DROP COLUMN last_lexicon;
+		DROP TABLE definitions;
+		DROP TABLE definition_links;
+		UPDATE db_version SET version = 6;`)
+	assert.Nil(t, err)
+	assert.Nil(t, db.Close())
+
+	search := func(expand bool, params ...*pb.SearchRequest_SearchParam) (*pb.SearchResponse, error) {
+		resp, err := s.Search(context.Background(), connect.NewRequest(WordSearch(
+			append([]*pb.SearchRequest_SearchParam{SearchDescLexicon("TEST")}, params...), expand)))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+	resp, err := search(true, SearchDescLength(6, 7))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"AEINRST", "AEINST"}, alphagrams(resp))
+	assert.Equal(t, int32(0), resp.Alphagrams[0].Playability)
+	assert.Equal(t, int32(12), resp.Alphagrams[1].Difficulty)
+
+	// Alphagram lists are expanded from the alphagram info too.
+	resp, err = search(true, SearchDescAlphagramList([]string{"AEINST"}))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"AEINST"}, alphagrams(resp))
+	expanded, err := s.Expand(context.Background(), connect.NewRequest(&pb.SearchResponse{
+		Lexicon: "TEST",
+		Alphagrams: []*pb.Alphagram{
+			{Alphagram: "AEINRST", Words: []*pb.Word{{Word: "RETAINS"}}}},
+	}))
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), expanded.Msg.Alphagrams[0].Probability)
+
+	_, err = search(false, SearchDescPlayabilityRange(1, 100))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	assert.ErrorContains(t, err, "needs version 7")
+	req := WordSearch([]*pb.SearchRequest_SearchParam{SearchDescLexicon("TEST"), SearchDescLength(6, 7)}, false)
+	req.Sort = &pb.SearchRequest_SortSpec{Field: pb.SearchRequest_SortSpec_PLAYABILITY}
+	_, err = s.Search(context.Background(), connect.NewRequest(req))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	assert.ErrorContains(t, err, "needs version 7")
+}
@@ -1,17 +1,18 @@
 package searchserver
 
 import (
+	"context"
 	"database/sql"
-	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	"sync"
 	"time"
 
 	// sqlite3 driver is used by this server.
-	"github.com/domino14/word_db_server/config"
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
 	"github.com/rs/zerolog/log"
+
+	"github.com/domino14/word_db_server/config"
+	"github.com/domino14/word_db_server/internal/querygen"
 )
 
 const (
@@ -21,25 +22,46 @@ const (
 	MaxSQLChunkSize = 950
 )
 
+// sqlite3 drivers with the SQL functions that our generated queries rely
+// on. The cross-lexicon functions read KWGs from the data path, so there is
+// one driver per data path.
+var (
+	sqliteDriversMu sync.Mutex
+	sqliteDrivers   = map[string]string{}
+)
+
+func sqliteDriverName(dataPath string) string {
+	sqliteDriversMu.Lock()
+	defer sqliteDriversMu.Unlock()
+	if name, ok := sqliteDrivers[dataPath]; ok {
+		return name
+	}
+	name := fmt.Sprintf("sqlite3_wdb_%d", len(sqliteDrivers))
+	lexFuncs := querygen.NewLexiconFuncs(dataPath)
+	sql.Register(name, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			if err := conn.RegisterFunc(querygen.SeededRankFunc, querygen.SeededRank, true); err != nil {
+				return err
+			}
+			if err := conn.RegisterFunc(querygen.InLexiconFunc, lexFuncs.InLexicon, true); err != nil {
+				return err
+			}
+			return conn.RegisterFunc(querygen.AlphagramInLexiconFunc, lexFuncs.AlphagramInLexicon, true)
+		},
+	})
+	sqliteDrivers[dataPath] = name
+	return name
+}
+
 // Server implements the WordSearcher service
 type Server struct {
 	Config *config.Config
 }
 
-func getDbConnection(cfg *config.Config, lexName string) (*sql.DB, error) {
-	// Try to connect to the db.
-	if lexName == "" {
-		return nil, errors.New("lexicon not specified")
-	}
-
-	lexPath := filepath.Join(cfg.DataPath, "lexica")
-
-	fileName := filepath.Join(lexPath, "db", lexName+".db")
-	_, err := os.Stat(fileName)
-	if os.IsNotExist(err) {
-		return nil, fmt.Errorf("the lexicon %v is not supported", lexName)
-	}
-	return sql.Open("sqlite3", fileName)
+// acquireDB gets a lexicon database from the shared registry. The caller
+// must Release it.
+func acquireDB(ctx context.Context, cfg *config.Config, lexName string) (*LexiconDB, error) {
+	return RegistryFor(cfg).Acquire(ctx, lexName)
 }
 
 func timeTrack(start time.Time, name string) {
@@ -47,15 +69,16 @@ func timeTrack(start time.Time, name string) {
 	log.Info().Dur("elapsed-ms", elapsed).Str("name", name).Msgf("time-track")
 }
 
-func (s *Server) HasAlphagram(alpha, lexicon string) (bool, error) {
-	db, err := getDbConnection(s.Config, lexicon)
+func (s *Server) HasAlphagram(ctx context.Context, alpha, lexicon string) (bool, error) {
+	db, err := acquireDB(ctx, s.Config, lexicon)
 	if err != nil {
 		return false, err
 	}
-	defer db.Close()
+	defer db.Release()
 	// Prepare the query
 	var count int
-	err = db.QueryRow("SELECT count(*) FROM alphagrams WHERE alphagram = ?", alpha).Scan(&count)
+	err = db.QueryRowContext(ctx, "SELECT count(*) FROM alphagrams WHERE alphagram = ?", alpha).
+		Scan(&count)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return false, nil // No rows found means no matching alphagram
@@ -64,3 +87,31 @@ func (s *Server) HasAlphagram(alpha, lexicon string) (bool, error) {
 	}
 	return count > 0, nil
 }
+
+// TopStems returns the most productive stems stored in the lexicon
+// database, most productive first.
+func (s *Server) TopStems(ctx context.Context, lexicon string, stemLength, numAdded, limit int) (
+	[]string, error) {
+
+	db, err := acquireDB(ctx, s.Config, lexicon)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Release()
+	rows, err := db.QueryContext(ctx, `
+		SELECT stem FROM stems WHERE stem_length = ? AND num_added = ?
+		ORDER BY num_bingos DESC, stem LIMIT ?`, stemLength, numAdded, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+	stems := []string{}
+	for rows.Next() {
+		var stem string
+		if err := rows.Scan(&stem); err != nil {
+			return nil, err
+		}
+		stems = append(stems, stem)
+	}
+	return stems, rows.Err()
+}
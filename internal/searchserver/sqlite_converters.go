@@ -2,7 +2,11 @@ package searchserver
 
 import (
 	"database/sql"
+	"encoding/json"
 	"strconv"
+	"strings"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
 )
 
 // This file contains custom-built sqlite converters. We use these instead of
@@ -23,3 +27,24 @@ func toint64(bts sql.RawBytes) int64 {
 	val, _ := strconv.ParseInt(string(bts), 10, 64)
 	return val
 }
+
+// todefinitions parses the JSON array of [part of speech, gloss,
+// inflections, root word] arrays selected for a word's definitions.
+func todefinitions(bts sql.RawBytes) []*pb.Definition {
+	var senses [][]string
+	// ignore error, as above.
+	json.Unmarshal(bts, &senses)
+	defs := make([]*pb.Definition, 0, len(senses))
+	for _, sense := range senses {
+		if len(sense) != 4 {
+			continue
+		}
+		defs = append(defs, &pb.Definition{
+			PartOfSpeech: sense[0],
+			Gloss:        sense[1],
+			Inflections:  strings.Fields(sense[2]),
+			RootWord:     sense[3],
+		})
+	}
+	return defs
+}
@@ -0,0 +1,96 @@
+package searchserver
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+	"github.com/domino14/word_db_server/internal/querygen"
+)
+
+// DefaultStreamBatchSize is how many alphagrams go in each SearchStream
+// message if the request doesn't set a page size.
+const DefaultStreamBatchSize = 500
+
+// SearchStream implements the streaming variant of Search. Alphagrams are
+// read and sent a batch at a time, so the full result set is never held in
+// memory, and neither Config.MaxQueryResults nor the search cost budgets
+// apply. The lexicon database is only held while a batch is being read,
+// not while it is being sent, so slow clients don't keep other requests
+// waiting. If the lexicon is reloaded partway through, the stream ends with
+// FAILED_PRECONDITION, as paging through it with Search would.
+func (s *Server) SearchStream(ctx context.Context, req *connect.Request[pb.SearchRequest],
+	stream *connect.ServerStream[pb.SearchResponse]) error {
+
+	defer timeTrack(time.Now(), "search-stream")
+	log.Info().Str("desc", searchReqDescription(req.Msg)).Msg("searchStreamRequest")
+
+	qgen, err := createQueryGen(req.Msg, s.Config, MaxSQLChunkSize)
+	if err != nil {
+		return err
+	}
+	// Streams are read a page at a time, and their tokens work with Search.
+	qgen.SetSingleQuery(true)
+	batchSize := DefaultStreamBatchSize
+	if req.Msg.PageSize > 0 {
+		batchSize = pageSize(req.Msg.PageSize)
+	}
+
+	db, err := acquireDB(ctx, s.Config, qgen.LexiconName())
+	if err != nil {
+		return err
+	}
+	tok, err := decodePageToken(req.Msg.PageToken, searchFingerprint(req.Msg), db.generation())
+	if err != nil {
+		db.Release()
+		return pageTokenError(err)
+	}
+	fitQueryGen(qgen, db)
+	queries, err := generateQueries(ctx, qgen)
+	db.Release()
+	if err != nil {
+		return err
+	}
+
+	next := &tok
+	for next != nil {
+		var batch []*pb.Alphagram
+		batch, next, err = s.streamBatch(ctx, qgen, queries, req.Msg.Expand, *next, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		resp := &pb.SearchResponse{
+			Alphagrams: batch,
+			Lexicon:    qgen.LexiconName(),
+		}
+		if next != nil {
+			resp.NextPageToken = next.encode()
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamBatch reads the batch of a stream that starts at tok, holding the
+// lexicon database just while it does.
+func (s *Server) streamBatch(ctx context.Context, qgen *querygen.QueryGen, queries []*querygen.Query,
+	expand bool, tok pageToken, size int) ([]*pb.Alphagram, *pageToken, error) {
+
+	db, err := acquireDB(ctx, s.Config, qgen.LexiconName())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer db.Release()
+	if db.generation() != tok.Generation {
+		return nil, nil, pageTokenError(errStalePageToken)
+	}
+	return fetchPage(ctx, queries, db, expand, qgen.Type(), s.Config, tok, size)
+}
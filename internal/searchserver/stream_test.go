@@ -0,0 +1,95 @@
+package searchserver
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+	"github.com/domino14/word_db_server/api/rpc/wordsearcher/wordsearcherconnect"
+)
+
+func streamHelper(t *testing.T, req *pb.SearchRequest) ([]*pb.SearchResponse, error) {
+	return streamFrom(t, &Server{Config: DefaultConfig}, req)
+}
+
+func streamFrom(t *testing.T, s *Server, req *pb.SearchRequest) ([]*pb.SearchResponse, error) {
+	mux := http.NewServeMux()
+	mux.Handle(wordsearcherconnect.NewQuestionSearcherHandler(s))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := wordsearcherconnect.NewQuestionSearcherClient(srv.Client(), srv.URL)
+	stream, err := client.SearchStream(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+	msgs := []*pb.SearchResponse{}
+	for stream.Receive() {
+		msgs = append(msgs, stream.Msg())
+	}
+	return msgs, stream.Err()
+}
+
+func TestSearchStream(t *testing.T) {
+	params := []*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("NWL18"),
+		SearchDescLength(8, 8),
+		SearchDescProbRange(101, 350),
+	}
+	resp, err := searchHelper(WordSearch(params, true))
+	assert.Nil(t, err)
+
+	req := WordSearch(params, true)
+	req.PageSize = 100
+	msgs, err := streamHelper(t, req)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(msgs))
+
+	streamed := []*pb.Alphagram{}
+	for _, m := range msgs {
+		streamed = append(streamed, m.Alphagrams...)
+	}
+	assert.Equal(t, alphagrams(resp), alphsFromPB(streamed))
+	// Expanded streams carry the word details too.
+	assert.Equal(t, resp.Alphagrams[0].Words[0].Definition, streamed[0].Words[0].Definition)
+
+	// Resume from the token after the first batch.
+	req.PageToken = msgs[0].NextPageToken
+	resumed, err := streamHelper(t, req)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(resumed))
+	assert.Equal(t, alphsFromPB(msgs[1].Alphagrams), alphsFromPB(resumed[0].Alphagrams))
+}
+
+func TestSearchStreamBatches(t *testing.T) {
+	s := makeCachedTestLexiconDB(t)
+	// The database allows one query at a time, and every batch needs it.
+	req := WordSearch([]*pb.SearchRequest_SearchParam{
+		SearchDescLexicon("TEST"), SearchDescLength(6, 7)}, true)
+	req.PageSize = 1
+	msgs, err := streamFrom(t, s, req)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(msgs))
+	assert.Equal(t, []string{"AEINRST"}, alphsFromPB(msgs[0].Alphagrams))
+	assert.Equal(t, []string{"AEINST"}, alphsFromPB(msgs[1].Alphagrams))
+	assert.Empty(t, msgs[1].NextPageToken)
+
+	// A token from before a reload can't be resumed from.
+	db, err := sql.Open("sqlite3", filepath.Join(s.Config.DataPath, "lexica", "db", "TEST.db"))
+	assert.Nil(t, err)
+	_, err = db.Exec("UPDATE db_version SET version = version + 1")
+	assert.Nil(t, err)
+	assert.Nil(t, db.Close())
+	assert.Nil(t, RegistryFor(s.Config).Reload("TEST"))
+	req.PageToken = msgs[0].NextPageToken
+	_, err = streamFrom(t, s, req)
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+}
@@ -0,0 +1,161 @@
+package searchserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+	"github.com/domino14/word_db_server/internal/querygen"
+)
+
+const (
+	DefaultProbabilityBucketSize = 1000
+	DefaultDifficultyBandSize    = 10
+)
+
+// SearchSummary counts what a search matches with aggregate queries, so
+// the matches never leave the database.
+func (s *Server) SearchSummary(ctx context.Context, req *connect.Request[pb.SearchSummaryRequest]) (
+	*connect.Response[pb.SearchSummaryResponse], error) {
+
+	defer timeTrack(time.Now(), "search-summary")
+	if req.Msg.Search == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("search is required"))
+	}
+	// Only which words match matters here.
+	search := proto.Clone(req.Msg.Search).(*pb.SearchRequest)
+	search.Expand = false
+	search.Sort = nil
+	log.Info().Str("desc", searchReqDescription(search)).Strs("facets", req.Msg.Facets).
+		Msg("searchSummaryRequest")
+
+	qgen, err := createQueryGen(search, s.Config, MaxSQLChunkSize)
+	if err != nil {
+		return nil, err
+	}
+	db, err := acquireDB(ctx, s.Config, qgen.LexiconName())
+	if err != nil {
+		return nil, err
+	}
+	defer db.Release()
+	fitQueryGen(qgen, db)
+	queries, err := generateQueries(ctx, qgen)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.SearchSummaryResponse{Lexicon: qgen.LexiconName()}
+	for _, query := range queries {
+		stmt, bp, err := query.CountQuery()
+		if err != nil {
+			return nil, err
+		}
+		var numAlphagrams, numWords int32
+		if err := db.QueryRowContext(ctx, stmt, bp...).Scan(&numAlphagrams, &numWords); err != nil {
+			return nil, err
+		}
+		resp.NumAlphagrams += numAlphagrams
+		resp.NumWords += numWords
+	}
+
+	for _, name := range req.Msg.Facets {
+		bucketSize := 0
+		switch name {
+		case querygen.FacetProbability:
+			bucketSize = int(req.Msg.ProbabilityBucketSize)
+			if bucketSize <= 0 {
+				bucketSize = DefaultProbabilityBucketSize
+			}
+		case querygen.FacetDifficulty:
+			bucketSize = int(req.Msg.DifficultyBandSize)
+			if bucketSize <= 0 {
+				bucketSize = DefaultDifficultyBandSize
+			}
+		}
+		facet, err := countFacet(ctx, db, queries, name, bucketSize)
+		if err != nil {
+			return nil, err
+		}
+		resp.Facets = append(resp.Facets, facet)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// countFacet adds up the facet's buckets over all of the queries.
+func countFacet(ctx context.Context, db *LexiconDB, queries []*querygen.Query, name string,
+	bucketSize int) (*pb.Facet, error) {
+
+	facet := &pb.Facet{Name: name}
+	buckets := map[string]*pb.FacetBucket{}
+	for _, query := range queries {
+		stmt, bp, err := query.FacetQuery(name, bucketSize)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		rows, err := db.QueryContext(ctx, stmt, bp...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var value string
+			var numAlphagrams, numWords int32
+			if err := rows.Scan(&value, &numAlphagrams, &numWords); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			b, ok := buckets[value]
+			if !ok {
+				b = newFacetBucket(value, bucketSize)
+				buckets[value] = b
+				facet.Buckets = append(facet.Buckets, b)
+			}
+			b.NumAlphagrams += numAlphagrams
+			b.NumWords += numWords
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(queries) > 1 {
+		sortFacetBuckets(facet)
+	}
+	return facet, nil
+}
+
+// newFacetBucket makes the bucket that starts at value.
+func newFacetBucket(value string, bucketSize int) *pb.FacetBucket {
+	b := &pb.FacetBucket{Value: value}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		// Lexicon symbols aren't numbers.
+		return b
+	}
+	b.Min, b.Max = n, n
+	if bucketSize > 0 && n > 0 {
+		b.Max = n + int64(bucketSize) - 1
+		if b.Max > b.Min {
+			b.Value = fmt.Sprintf("%d-%d", b.Min, b.Max)
+		}
+	}
+	return b
+}
+
+func sortFacetBuckets(facet *pb.Facet) {
+	sort.Slice(facet.Buckets, func(i, j int) bool {
+		a, b := facet.Buckets[i], facet.Buckets[j]
+		if a.Min != b.Min {
+			return a.Min < b.Min
+		}
+		return a.Value < b.Value
+	})
+}
@@ -0,0 +1,77 @@
+package searchserver
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
+)
+
+func TestSearchSummary(t *testing.T) {
+	cfg := makeTestLexiconDB(t, "TEST")
+	writeTestWords(t, cfg, "TEST", `
+		DROP TABLE alphagrams;
+		CREATE TABLE alphagrams (alphagram varchar(20), length int, probability int,
+			difficulty int, num_anagrams int);
+		INSERT INTO alphagrams VALUES ('AEINST', 6, 1, 12, 2), ('AEINRST', 7, 1, 0, 3),
+			('AEINRT', 6, 1500, 95, 1);
+		INSERT INTO words (word, alphagram, lexicon_symbols, inner_front_hook, inner_back_hook) VALUES
+			('SATINE', 'AEINST', '', 0, 0), ('TISANE', 'AEINST', '#', 0, 0),
+			('RETINAS', 'AEINRST', '#+', 0, 0), ('RETAINS', 'AEINRST', '', 0, 0),
+			('STAINER', 'AEINRST', '+', 0, 0), ('RETAIN', 'AEINRT', '', 0, 0);`)
+
+	s := &Server{Config: cfg}
+	summary := func(req *pb.SearchSummaryRequest) *pb.SearchSummaryResponse {
+		resp, err := s.SearchSummary(context.Background(), connect.NewRequest(req))
+		assert.Nil(t, err)
+		return resp.Msg
+	}
+	resp := summary(&pb.SearchSummaryRequest{
+		Search: &pb.SearchRequest{Searchparams: []*pb.SearchRequest_SearchParam{
+			SearchDescLexicon("TEST"), SearchDescLength(6, 7)}},
+		Facets: []string{"length", "probability", "difficulty", "num_anagrams", "lexicon_symbol"},
+	})
+	assert.Equal(t, int32(3), resp.NumAlphagrams)
+	assert.Equal(t, int32(6), resp.NumWords)
+
+	facets := map[string][]*pb.FacetBucket{}
+	for _, f := range resp.Facets {
+		facets[f.Name] = f.Buckets
+	}
+	bucket := func(value string, min, max int64, alphas, words int32) *pb.FacetBucket {
+		return &pb.FacetBucket{Value: value, Min: min, Max: max, NumAlphagrams: alphas,
+			NumWords: words}
+	}
+	assert.Equal(t, []*pb.FacetBucket{bucket("6", 6, 6, 2, 3), bucket("7", 7, 7, 1, 3)},
+		facets["length"])
+	assert.Equal(t, []*pb.FacetBucket{bucket("1-1000", 1, 1000, 2, 5),
+		bucket("1001-2000", 1001, 2000, 1, 1)}, facets["probability"])
+	assert.Equal(t, []*pb.FacetBucket{bucket("0", 0, 0, 1, 3), bucket("11-20", 11, 20, 1, 2),
+		bucket("91-100", 91, 100, 1, 1)}, facets["difficulty"])
+	assert.Equal(t, []*pb.FacetBucket{bucket("1", 1, 1, 1, 1), bucket("2", 2, 2, 1, 2),
+		bucket("3", 3, 3, 1, 3)}, facets["num_anagrams"])
+	assert.Equal(t, []*pb.FacetBucket{bucket("#", 0, 0, 2, 2), bucket("+", 0, 0, 1, 2)},
+		facets["lexicon_symbol"])
+
+	// Word-level conditions count every word of the matching alphagrams,
+	// the way a search returns them.
+	resp = summary(&pb.SearchSummaryRequest{
+		Search: &pb.SearchRequest{Searchparams: []*pb.SearchRequest_SearchParam{
+			SearchDescLexicon("TEST"), SearchDescLexiconSymbols("+")}},
+		Facets:                []string{"probability"},
+		ProbabilityBucketSize: 10,
+	})
+	assert.Equal(t, int32(1), resp.NumAlphagrams)
+	assert.Equal(t, int32(3), resp.NumWords)
+	assert.Equal(t, []*pb.FacetBucket{bucket("1-10", 1, 10, 1, 3)}, resp.Facets[0].Buckets)
+
+	_, err := s.SearchSummary(context.Background(), connect.NewRequest(&pb.SearchSummaryRequest{
+		Search: &pb.SearchRequest{Searchparams: []*pb.SearchRequest_SearchParam{
+			SearchDescLexicon("TEST")}},
+		Facets: []string{"colour"},
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
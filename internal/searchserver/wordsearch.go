@@ -2,14 +2,19 @@ package searchserver
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 
 	"connectrpc.com/connect"
+	wglconfig "github.com/domino14/word-golib/config"
+	"github.com/domino14/word-golib/kwg"
+	"github.com/domino14/word-golib/tilemapping"
 	pb "github.com/domino14/word_db_server/api/rpc/wordsearcher"
 	"github.com/domino14/word_db_server/config"
 	"github.com/domino14/word_db_server/internal/querygen"
+	"github.com/domino14/word_db_server/internal/wordpattern"
 	"github.com/rs/zerolog/log"
 )
 
@@ -21,16 +26,22 @@ func (s *WordSearchServer) WordSearch(ctx context.Context, req *connect.Request[
 	*connect.Response[pb.WordSearchResponse], error) {
 	// Uses a glob to search the database directly.
 
-	db, err := getDbConnection(s.Config, req.Msg.Lexicon)
+	db, err := acquireDB(ctx, s.Config, req.Msg.Lexicon)
 	if err != nil {
 		return nil, err
 	}
-	defer db.Close()
+	defer db.Release()
+	if req.Msg.Pattern != nil {
+		return s.patternSearch(ctx, db, req.Msg)
+	}
 	column := ""
 	switch req.Msg.AppliesTo {
 	case "word":
 		column = "word"
 	case "definition":
+		if db.hasFTS {
+			return s.definitionSearch(ctx, db, req.Msg)
+		}
 		column = "definition"
 	default:
 		return nil, errors.New("applies_to must be only word or definition")
@@ -40,39 +51,115 @@ func (s *WordSearchServer) WordSearch(ctx context.Context, req *connect.Request[
 	glob = strings.ReplaceAll(glob, "*", "%")
 	glob = strings.ReplaceAll(glob, "?", "_")
 
-	queryTemplate := querygen.WordInfoQuery
+	queryTemplate := querygen.ForVersion(querygen.WordInfoQuery, db.version)
 	where := fmt.Sprintf("%s LIKE ?", column)
-	query := fmt.Sprintf(queryTemplate, where, "")
+	query := fmt.Sprintf(queryTemplate, where, "word", "")
 	log.Debug().Str("query", query).Str("glob", glob).Msg("word-search-query")
 	rows, err := db.QueryContext(ctx, query, glob)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	words := []*pb.Word{}
-	words = append(words, processWordRows(rows)...)
+	words, err := processWordRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&pb.WordSearchResponse{Words: words}), nil
+}
+
+// patternSearch finds the words matching a pattern by walking the lexicon's
+// KWG, and then looks up their info.
+func (s *WordSearchServer) patternSearch(ctx context.Context, db *LexiconDB, req *pb.WordSearchRequest) (
+	*connect.Response[pb.WordSearchResponse], error) {
+
+	dawg, err := kwg.GetKWG(&wglconfig.Config{DataPath: s.Config.DataPath}, req.Lexicon)
+	if err != nil {
+		return nil, err
+	}
+	pattern, err := wordpattern.FromParam(req.Pattern, dawg.GetAlphabet())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	limit, err := patternLimit(req.Limit, s.Config.MaxQueryResults)
+	if err != nil {
+		return nil, err
+	}
+	matches := []string{}
+	err = pattern.Walk(ctx, dawg, func(word tilemapping.MachineWord) error {
+		if limit > 0 && len(matches) == limit {
+			return errEnoughMatches
+		}
+		matches = append(matches, word.UserVisible(dawg.GetAlphabet()))
+		return nil
+	})
+	if errors.Is(err, errEnoughMatches) {
+		if req.Limit <= 0 {
+			return nil, connect.NewError(connect.CodeResourceExhausted, fmt.Errorf(
+				"the pattern matches more than %d words; narrow it down or set a limit", limit))
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return connect.NewResponse(&pb.WordSearchResponse{Words: []*pb.Word{}}), nil
+	}
+	encoded, err := json.Marshal(matches)
+	if err != nil {
+		return nil, err
+	}
+
+	where := "word IN (SELECT value FROM json_each(?))"
+	query := fmt.Sprintf(querygen.ForVersion(querygen.WordInfoQuery, db.version), where, "word", "")
+	rows, err := db.QueryContext(ctx, query, string(encoded))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	words, err := processWordRows(rows)
+	if err != nil {
+		return nil, err
+	}
 
 	return connect.NewResponse(&pb.WordSearchResponse{Words: words}), nil
 }
 
+var errEnoughMatches = errors.New("enough pattern matches")
+
+// patternLimit works out how many words a pattern search may return. A
+// requested limit can't be more than the server's maximum; without one, the
+// search fails if it matches more than the maximum.
+func patternLimit(requested int32, max int) (int, error) {
+	if max > 0 && int(requested) > max {
+		return 0, connect.NewError(connect.CodeResourceExhausted, fmt.Errorf(
+			"a pattern search can return at most %d words", max))
+	}
+	if requested > 0 {
+		return int(requested), nil
+	}
+	return max, nil
+}
+
 func (s *WordSearchServer) GetWordInformation(ctx context.Context, req *connect.Request[pb.DefineRequest]) (
 	*connect.Response[pb.WordSearchResponse], error) {
-	db, err := getDbConnection(s.Config, req.Msg.Lexicon)
+	db, err := acquireDB(ctx, s.Config, req.Msg.Lexicon)
 	if err != nil {
 		return nil, err
 	}
-	defer db.Close()
+	defer db.Release()
 
-	queryTemplate := querygen.WordInfoQuery
+	queryTemplate := querygen.ForVersion(querygen.WordInfoQuery, db.version)
 	where := "word = ?"
-	query := fmt.Sprintf(queryTemplate, where, "")
+	query := fmt.Sprintf(queryTemplate, where, "word", "")
 	rows, err := db.QueryContext(ctx, query, strings.ToUpper(req.Msg.Word))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	words := []*pb.Word{}
-	words = append(words, processWordRows(rows)...)
+	words, err := processWordRows(rows)
+	if err != nil {
+		return nil, err
+	}
 
 	return connect.NewResponse(&pb.WordSearchResponse{Words: words}), nil
 }
@@ -0,0 +1,342 @@
+// Package wordpattern matches words against a small pattern language (see
+// PatternParam in searcher.proto) by walking a KWG, so only the parts of
+// the lexicon that can still match are ever visited.
+package wordpattern
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/domino14/word-golib/kwg"
+	"github.com/domino14/word-golib/tilemapping"
+
+	"github.com/domino14/word_db_server/api/rpc/wordsearcher"
+)
+
+// maxElements is the most elements a pattern can have; the matcher keeps
+// its state set in a uint64.
+const maxElements = 63
+
+// tileSet is a set of machine letters.
+type tileSet uint64
+
+func (s tileSet) has(ml tilemapping.MachineLetter) bool {
+	return ml < 64 && s&(1<<ml) != 0
+}
+
+// element is one position in the pattern: a single tile from set, or, if
+// star is true, any number of tiles from set.
+type element struct {
+	set  tileSet
+	star bool
+}
+
+type letterCount struct {
+	set      tileSet
+	min, max int
+}
+
+// Pattern is a compiled pattern. Its zero value is not usable; create one
+// with Compile.
+type Pattern struct {
+	elements  []element
+	minLength int
+	maxLength int
+	counts    []letterCount
+	anyTile   tileSet
+	tm        *tilemapping.TileMapping
+}
+
+// Compile parses a pattern. Letters are parsed with the tile mapping, so
+// multi-character tiles are matched the same way they are in words.
+func Compile(expr string, tm *tilemapping.TileMapping) (*Pattern, error) {
+	p := &Pattern{tm: tm}
+	for _, ml := range tm.Vals() {
+		if ml != 0 {
+			p.anyTile |= 1 << ml
+		}
+	}
+
+	runes := []rune(strings.ToUpper(strings.TrimSpace(expr)))
+	if len(runes) == 0 {
+		return nil, errors.New("empty pattern")
+	}
+	anchoredStart, anchoredEnd := false, false
+	if runes[0] == '^' {
+		anchoredStart = true
+		runes = runes[1:]
+	}
+	if len(runes) > 0 && runes[len(runes)-1] == '$' {
+		anchoredEnd = true
+		runes = runes[:len(runes)-1]
+	}
+	if !anchoredStart {
+		p.elements = append(p.elements, element{set: p.anyTile, star: true})
+	}
+
+	for i := 0; i < len(runes); {
+		switch runes[i] {
+		case '?', '.':
+			p.elements = append(p.elements, element{set: p.anyTile})
+			i++
+		case '*':
+			p.elements = append(p.elements, element{set: p.anyTile, star: true})
+			i++
+		case '[':
+			end := i + 1
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, errors.New("unterminated [ in pattern")
+			}
+			class := runes[i+1 : end]
+			negated := len(class) > 0 && class[0] == '^'
+			if negated {
+				class = class[1:]
+			}
+			set, err := p.tiles(string(class))
+			if err != nil {
+				return nil, err
+			}
+			if set == 0 {
+				return nil, errors.New("empty [] in pattern")
+			}
+			if negated {
+				set = p.anyTile &^ set
+			}
+			p.elements = append(p.elements, element{set: set})
+			i = end + 1
+		case '^', '$', ']':
+			return nil, fmt.Errorf("unexpected %c in pattern", runes[i])
+		default:
+			ml, n, err := p.tile(runes[i:])
+			if err != nil {
+				return nil, err
+			}
+			p.elements = append(p.elements, element{set: 1 << ml})
+			i += n
+		}
+	}
+
+	if !anchoredEnd {
+		p.elements = append(p.elements, element{set: p.anyTile, star: true})
+	}
+	if len(p.elements) > maxElements {
+		return nil, fmt.Errorf("pattern is too long (at most %d elements)", maxElements)
+	}
+	return p, nil
+}
+
+// FromParam compiles a pattern search param, including its length and
+// letter count constraints.
+func FromParam(param *wordsearcher.PatternParam, tm *tilemapping.TileMapping) (*Pattern, error) {
+	if param == nil {
+		return nil, errors.New("pattern not provided")
+	}
+	p, err := Compile(param.GetPattern(), tm)
+	if err != nil {
+		return nil, err
+	}
+	p.SetLength(int(param.GetMinLength()), int(param.GetMaxLength()))
+	for _, lc := range param.GetLetterCounts() {
+		max := int(lc.GetMax())
+		if lc.GetMax() < lc.GetMin() {
+			max = -1
+		}
+		if err := p.AddLetterCount(lc.GetLetters(), int(lc.GetMin()), max); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// tile parses the longest tile at the start of runes, returning it and the
+// number of runes it took up.
+func (p *Pattern) tile(runes []rune) (tilemapping.MachineLetter, int, error) {
+	for n := len(runes); n > 0; n-- {
+		if ml, ok := p.tm.Vals()[string(runes[:n])]; ok && ml != 0 {
+			return ml, n, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("%c is not a letter in this lexicon", runes[0])
+}
+
+func (p *Pattern) tiles(letters string) (tileSet, error) {
+	var set tileSet
+	runes := []rune(strings.ToUpper(letters))
+	for i := 0; i < len(runes); {
+		ml, n, err := p.tile(runes[i:])
+		if err != nil {
+			return 0, err
+		}
+		set |= 1 << ml
+		i += n
+	}
+	return set, nil
+}
+
+// SetLength restricts matches to words with between min and max tiles.
+// A bound of 0 means no bound.
+func (p *Pattern) SetLength(min, max int) {
+	p.minLength = min
+	p.maxLength = max
+}
+
+// AddLetterCount requires that between min and max of a word's tiles are
+// among the given letters. A negative max means no upper bound.
+func (p *Pattern) AddLetterCount(letters string, min, max int) error {
+	set, err := p.tiles(letters)
+	if err != nil {
+		return err
+	}
+	if set == 0 {
+		return errors.New("no letters provided for letter count")
+	}
+	p.counts = append(p.counts, letterCount{set: set, min: min, max: max})
+	return nil
+}
+
+// closure adds to a state set every state reachable by skipping stars.
+func (p *Pattern) closure(states uint64) uint64 {
+	for i, e := range p.elements {
+		if states&(1<<i) != 0 && e.star {
+			states |= 1 << (i + 1)
+		}
+	}
+	return states
+}
+
+// step returns the states reachable from a state set on a tile.
+func (p *Pattern) step(states uint64, ml tilemapping.MachineLetter) uint64 {
+	var next uint64
+	for i, e := range p.elements {
+		if states&(1<<i) == 0 || !e.set.has(ml) {
+			continue
+		}
+		if e.star {
+			next |= 1 << i
+		} else {
+			next |= 1 << (i + 1)
+		}
+	}
+	return p.closure(next)
+}
+
+func (p *Pattern) final(states uint64) bool {
+	return states&(1<<len(p.elements)) != 0
+}
+
+func (p *Pattern) countsOK(counts []int) bool {
+	for i, lc := range p.counts {
+		if counts[i] < lc.min {
+			return false
+		}
+	}
+	return true
+}
+
+// addTile updates the letter counts for a tile, and returns false if that
+// puts any of them over its maximum.
+func (p *Pattern) addTile(counts []int, ml tilemapping.MachineLetter, delta int) bool {
+	ok := true
+	for i, lc := range p.counts {
+		if lc.set.has(ml) {
+			counts[i] += delta
+			if lc.max >= 0 && counts[i] > lc.max {
+				ok = false
+			}
+		}
+	}
+	return ok
+}
+
+// Match returns whether a word matches the pattern.
+func (p *Pattern) Match(word tilemapping.MachineWord) bool {
+	if (p.minLength > 0 && len(word) < p.minLength) ||
+		(p.maxLength > 0 && len(word) > p.maxLength) {
+		return false
+	}
+	counts := make([]int, len(p.counts))
+	states := p.closure(1)
+	for _, ml := range word {
+		if !p.addTile(counts, ml, 1) {
+			return false
+		}
+		states = p.step(states, ml)
+		if states == 0 {
+			return false
+		}
+	}
+	return p.final(states) && p.countsOK(counts)
+}
+
+// Walk calls fn for every word in the word graph that matches the pattern,
+// in alphabetical order. The word passed to fn is only valid during the
+// call. If fn returns an error, the walk stops and returns it; so it does,
+// with the context's error, if ctx is done first.
+func (p *Pattern) Walk(ctx context.Context, g *kwg.KWG, fn func(word tilemapping.MachineWord) error) error {
+	root := g.ArcIndex(0)
+	if root == 0 {
+		return nil
+	}
+	counts := make([]int, len(p.counts))
+	return p.walk(ctx, g, root, make(tilemapping.MachineWord, 0, 16), p.closure(1), counts, fn)
+}
+
+func (p *Pattern) walk(ctx context.Context, g *kwg.KWG, nodeIdx uint32, word tilemapping.MachineWord,
+	states uint64, counts []int, fn func(tilemapping.MachineWord) error) error {
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	for i := nodeIdx; ; i++ {
+		ml := tilemapping.MachineLetter(g.Tile(i))
+		next := p.step(states, ml)
+		if next != 0 && p.addTile(counts, ml, 1) {
+			word = append(word, ml)
+			if g.Accepts(i) && p.final(next) && len(word) >= p.minLength &&
+				p.countsOK(counts) {
+				if err := fn(word); err != nil {
+					return err
+				}
+			}
+			if arc := g.ArcIndex(i); arc != 0 && (p.maxLength == 0 || len(word) < p.maxLength) {
+				if err := p.walk(ctx, g, arc, word, next, counts, fn); err != nil {
+					return err
+				}
+			}
+			word = word[:len(word)-1]
+		}
+		if next != 0 {
+			p.addTile(counts, ml, -1)
+		}
+		if g.IsEnd(i) {
+			return nil
+		}
+	}
+}
+
+// Words returns all the words in the word graph that match the pattern.
+func (p *Pattern) Words(g *kwg.KWG) []string {
+	words, _ := p.WordsContext(context.Background(), g)
+	return words
+}
+
+// WordsContext is like Words, but stops with the context's error if ctx is
+// done first.
+func (p *Pattern) WordsContext(ctx context.Context, g *kwg.KWG) ([]string, error) {
+	words := []string{}
+	err := p.Walk(ctx, g, func(word tilemapping.MachineWord) error {
+		words = append(words, word.UserVisible(p.tm))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return words, nil
+}
@@ -0,0 +1,138 @@
+package wordpattern
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/domino14/word-golib/kwg"
+	"github.com/domino14/word-golib/tilemapping"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domino14/word_db_server/api/rpc/wordsearcher"
+	"github.com/domino14/word_db_server/internal/kwgtest"
+)
+
+const testDistribution = `?,2,0,0
+A,9,1,1
+B,2,3,0
+C,2,3,0
+CH,1,5,0
+D,4,2,0
+E,12,1,1
+H,2,4,0
+I,9,1,1
+L,4,1,0
+N,6,1,0
+O,8,1,1
+Q,1,10,0
+R,6,1,0
+S,4,1,0
+T,6,1,0
+U,4,1,1
+Y,2,4,0
+`
+
+var testWords = []string{
+	"BEST", "CHEST", "CHIC", "EASIEST", "HEIST", "LEST", "NEST", "NICEST",
+	"QUEST", "RESTS", "SILLIEST", "TEST", "UNBOLT", "UNDO", "UNTIE",
+	"UNTIED", "BUSIEST",
+}
+
+func testSetup(t *testing.T) (*kwg.KWG, *tilemapping.TileMapping) {
+	ld, err := tilemapping.ScanLetterDistribution(strings.NewReader(testDistribution))
+	assert.Nil(t, err)
+	g, err := kwgtest.Build(testWords, ld.TileMapping())
+	assert.Nil(t, err)
+	return g, ld.TileMapping()
+}
+
+func TestPatternWalk(t *testing.T) {
+	g, tm := testSetup(t)
+	for _, tc := range []struct {
+		pattern  string
+		expected []string
+	}{
+		{"IEST$", []string{"BUSIEST", "EASIEST", "SILLIEST"}},
+		{"^UN", []string{"UNBOLT", "UNDO", "UNTIE", "UNTIED"}},
+		{"^UN..$", []string{"UNDO"}},
+		{"^[BLN]EST$", []string{"BEST", "LEST", "NEST"}},
+		{"^[^BLN]EST$", []string{"CHEST", "TEST"}},
+		{"^?*EST$", []string{"BEST", "BUSIEST", "CHEST", "EASIEST", "LEST",
+			"NEST", "NICEST", "QUEST", "SILLIEST", "TEST"}},
+		// CH is a single tile, so CHEST has 4 tiles.
+		{"^....$", []string{"BEST", "CHEST", "LEST", "NEST", "TEST", "UNDO"}},
+		{"^CH", []string{"CHEST", "CHIC"}},
+		{"uti", []string{}},
+		{"TIE", []string{"UNTIE", "UNTIED"}},
+		{"TIE$", []string{"UNTIE"}},
+		{"ST*S", []string{"RESTS"}},
+	} {
+		p, err := Compile(tc.pattern, tm)
+		assert.Nil(t, err, tc.pattern)
+		assert.Equal(t, tc.expected, p.Words(g), tc.pattern)
+	}
+}
+
+func TestPatternConstraints(t *testing.T) {
+	g, tm := testSetup(t)
+
+	p, err := Compile("EST$", tm)
+	assert.Nil(t, err)
+	p.SetLength(6, 7)
+	assert.Equal(t, []string{"BUSIEST", "EASIEST", "NICEST"}, p.Words(g))
+
+	p, err = Compile("EST$", tm)
+	assert.Nil(t, err)
+	assert.Nil(t, p.AddLetterCount("AEIOU", 3, -1))
+	assert.Equal(t, []string{"BUSIEST", "EASIEST", "SILLIEST"}, p.Words(g))
+
+	p, err = Compile("EST$", tm)
+	assert.Nil(t, err)
+	assert.Nil(t, p.AddLetterCount("U", 0, 0))
+	assert.Nil(t, p.AddLetterCount("S", 1, 1))
+	assert.Equal(t, []string{"BEST", "CHEST", "LEST", "NEST", "NICEST", "TEST"},
+		p.Words(g))
+}
+
+func TestFromParam(t *testing.T) {
+	g, tm := testSetup(t)
+	p, err := FromParam(&wordsearcher.PatternParam{
+		Pattern:   "IEST$",
+		MaxLength: 7,
+		LetterCounts: []*wordsearcher.LetterCountConstraint{
+			// max < min means no upper bound.
+			{Letters: "S", Min: 2, Max: 0},
+		},
+	}, tm)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"BUSIEST", "EASIEST"}, p.Words(g))
+
+	mw, err := tilemapping.ToMachineLetters("SILLIEST", tm)
+	assert.Nil(t, err)
+	assert.False(t, p.Match(mw))
+	mw, err = tilemapping.ToMachineLetters("EASIEST", tm)
+	assert.Nil(t, err)
+	assert.True(t, p.Match(mw))
+}
+
+func TestCompileErrors(t *testing.T) {
+	_, tm := testSetup(t)
+	for _, pattern := range []string{"", "[AB", "A]", "[]", "A^B", "XYZ"} {
+		_, err := Compile(pattern, tm)
+		assert.NotNil(t, err, pattern)
+	}
+	_, err := Compile(strings.Repeat("?", 64), tm)
+	assert.NotNil(t, err)
+}
+
+func TestWordsContextCancelled(t *testing.T) {
+	g, tm := testSetup(t)
+	p, err := Compile("EST$", tm)
+	assert.Nil(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	words, err := p.WordsContext(ctx, g)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, words)
+}
@@ -84,7 +84,7 @@ func LeitnerImport(ctx context.Context, searchServer *searchserver.Server, lexic
 			return 0, nil, fmt.Errorf("failed to scan question: %w", err)
 		}
 
-		exists, err := searchServer.HasAlphagram(question, lexicon)
+		exists, err := searchServer.HasAlphagram(ctx, question, lexicon)
 		if err != nil {
 			return 0, nil, fmt.Errorf("failed to find alphagram: %w", err)
 		}